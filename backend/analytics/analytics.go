@@ -0,0 +1,221 @@
+// Package analytics reports on how an auction went. Every report is one SQL
+// query that aggregates in the database, so cost does not grow with what the
+// server would otherwise load into memory.
+package analytics
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// categorySQL derives a player's category in SQL, matching Player.GetPlayerCategory
+const categorySQL = `CASE
+	WHEN p.gender = 'female' THEN 'women'
+	WHEN p.gender = 'male' AND EXTRACT(YEAR FROM AGE(p.date_of_birth)) < 35 THEN 'men_under_35'
+	WHEN p.gender = 'male' THEN 'men_35_plus'
+	ELSE 'unknown' END`
+
+// categoryNames are the display names of the player categories
+var categoryNames = map[string]string{
+	"women":        "Women Players",
+	"men_under_35": "Men Under 35 Years",
+	"men_35_plus":  "Men 35 and Above Years",
+	"unknown":      "Unknown Category",
+}
+
+// CategoryPrice summarises the prices paid for one player category
+type CategoryPrice struct {
+	Category     string  `json:"category"`
+	CategoryName string  `json:"category_name"`
+	Sold         int     `json:"sold"`
+	TotalSpent   int     `json:"total_spent"`
+	Average      float64 `json:"average"`
+	Median       float64 `json:"median"`
+	Max          int     `json:"max"`
+}
+
+// CategoryPrices returns the average, median and highest price paid in each
+// category, over players sold to a team
+func CategoryPrices(db *gorm.DB) ([]CategoryPrice, error) {
+	var rows []CategoryPrice
+	err := db.Raw(`
+		SELECT ` + categorySQL + ` AS category,
+			COUNT(*) AS sold,
+			SUM(p.current_price) AS total_spent,
+			ROUND(AVG(p.current_price), 2) AS average,
+			PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY p.current_price) AS median,
+			MAX(p.current_price) AS max
+		FROM players p
+		WHERE p.is_sold AND p.current_team_id IS NOT NULL
+		GROUP BY 1
+		ORDER BY 1`).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range rows {
+		rows[i].CategoryName = categoryNames[rows[i].Category]
+	}
+	if rows == nil {
+		rows = []CategoryPrice{}
+	}
+	return rows, nil
+}
+
+// SpendPoint is a team's total spend just after one ledger entry
+type SpendPoint struct {
+	Time   time.Time `json:"time"`
+	Amount int       `json:"amount"`
+	Spent  int       `json:"spent"`
+}
+
+// SpendCurve is a team's running spend over time
+type SpendCurve struct {
+	TeamID   uuid.UUID    `json:"team_id"`
+	TeamName string       `json:"team_name"`
+	Points   []SpendPoint `json:"points"`
+}
+
+// SpendCurves returns each team's running total of points spent, one point per
+// ledger entry, oldest first. Refunds bring the curve down.
+func SpendCurves(db *gorm.DB) ([]SpendCurve, error) {
+	var rows []struct {
+		TeamID    uuid.UUID
+		TeamName  string
+		CreatedAt time.Time
+		Amount    int
+		Spent     int
+	}
+	err := db.Raw(`
+		SELECT t.id AS team_id, t.name AS team_name, pt.created_at, pt.amount,
+			SUM(pt.amount) OVER (PARTITION BY pt.team_id ORDER BY pt.created_at, pt.id) AS spent
+		FROM teams t
+		JOIN points_transactions pt ON pt.team_id = t.id
+		ORDER BY t.name, t.id, pt.created_at, pt.id`).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	curves := []SpendCurve{}
+	for _, row := range rows {
+		if len(curves) == 0 || curves[len(curves)-1].TeamID != row.TeamID {
+			curves = append(curves, SpendCurve{TeamID: row.TeamID, TeamName: row.TeamName})
+		}
+		curve := &curves[len(curves)-1]
+		curve.Points = append(curve.Points, SpendPoint{Time: row.CreatedAt, Amount: row.Amount, Spent: row.Spent})
+	}
+	return curves, nil
+}
+
+// Lot is one player's turn in an auction, as seen from its bids
+type Lot struct {
+	AuctionID    uuid.UUID  `json:"auction_id"`
+	AuctionTitle string     `json:"auction_title"`
+	PlayerID     uuid.UUID  `json:"player_id"`
+	PlayerName   string     `json:"player_name"`
+	Bids         int        `json:"bids"`
+	Teams        int        `json:"teams"`
+	FinalBid     int        `json:"final_bid"`
+	OpenedAt     time.Time  `json:"opened_at"`
+	FirstBidAt   time.Time  `json:"first_bid_at"`
+	LastBidAt    time.Time  `json:"last_bid_at"`
+	SoldAt       *time.Time `json:"sold_at"`
+	// Seconds runs from the lot opening to the sale, or to the last bid when unsold
+	Seconds float64 `json:"seconds"`
+}
+
+// lotsSQL aggregates bids per lot. A lot opens when an admin puts the player
+// up, as the audit log records, or failing that at its first bid; it closes
+// at the purchase entry in the ledger.
+const lotsSQL = `
+	WITH lots AS (
+		SELECT b.auction_id, b.player_id,
+			COUNT(*) AS bids,
+			COUNT(DISTINCT b.team_id) AS teams,
+			MAX(b.amount) AS final_bid,
+			MIN(b.created_at) AS first_bid_at,
+			MAX(b.created_at) AS last_bid_at
+		FROM bids b
+		GROUP BY b.auction_id, b.player_id
+	), timed AS (
+		SELECT l.*,
+			COALESCE((SELECT MAX(ae.created_at) FROM audit_events ae
+				WHERE ae.entity_type = 'auction' AND ae.entity_id = l.auction_id::text
+					AND ae.action IN ('auction.started', 'auction.next_player', 'auction.player_assigned')
+					AND ae.after->>'current_player_id' = l.player_id::text
+					AND ae.created_at <= l.first_bid_at), l.first_bid_at) AS opened_at,
+			(SELECT MIN(pt.created_at) FROM points_transactions pt
+				WHERE pt.auction_id = l.auction_id AND pt.player_id = l.player_id
+					AND pt.type = 'purchase') AS sold_at
+		FROM lots l
+	)
+	SELECT t.*, a.title AS auction_title, p.name AS player_name,
+		EXTRACT(EPOCH FROM COALESCE(t.sold_at, t.last_bid_at) - t.opened_at) AS seconds
+	FROM timed t
+	JOIN auctions a ON a.id = t.auction_id
+	JOIN players p ON p.id = t.player_id`
+
+// ContestedLots returns up to limit lots with the most teams bidding, then the most bids
+func ContestedLots(db *gorm.DB, limit int) ([]Lot, error) {
+	lots := []Lot{}
+	err := db.Raw(lotsSQL+`
+		ORDER BY t.teams DESC, t.bids DESC, t.final_bid DESC
+		LIMIT ?`, limit).Scan(&lots).Error
+	return lots, err
+}
+
+// LotTimes returns how long each lot took, in the order the lots were run
+func LotTimes(db *gorm.DB) ([]Lot, error) {
+	lots := []Lot{}
+	err := db.Raw(lotsSQL + `
+		ORDER BY t.opened_at`).Scan(&lots).Error
+	return lots, err
+}
+
+// TeamEfficiency ranks how much a team got for its points
+type TeamEfficiency struct {
+	Rank            int       `json:"rank"`
+	TeamID          uuid.UUID `json:"team_id"`
+	TeamName        string    `json:"team_name"`
+	Players         int       `json:"players"`
+	Spent           int       `json:"spent"`
+	RemainingPoints int       `json:"remaining_points"`
+	AveragePrice    float64   `json:"average_price"`
+	// MarketValue is what the squad would cost at each category's average price
+	MarketValue float64 `json:"market_value"`
+	// Efficiency is MarketValue over Spent: above 1 means the team paid less than the market
+	Efficiency float64 `json:"efficiency"`
+}
+
+// BudgetEfficiency ranks teams by the market value of their squad per point
+// spent. Teams that bought nobody come last.
+func BudgetEfficiency(db *gorm.DB) ([]TeamEfficiency, error) {
+	rows := []TeamEfficiency{}
+	err := db.Raw(`
+		WITH sold AS (
+			SELECT p.current_team_id AS team_id, p.current_price, ` + categorySQL + ` AS category
+			FROM players p
+			WHERE p.is_sold AND p.current_team_id IS NOT NULL
+		), market AS (
+			SELECT category, AVG(current_price) AS average FROM sold GROUP BY category
+		), squads AS (
+			SELECT s.team_id, COUNT(*) AS players, SUM(s.current_price) AS spent,
+				AVG(s.current_price) AS average_price, SUM(m.average) AS market_value
+			FROM sold s JOIN market m ON m.category = s.category
+			GROUP BY s.team_id
+		)
+		SELECT RANK() OVER (ORDER BY COALESCE(sq.market_value / NULLIF(sq.spent, 0), 0) DESC) AS rank,
+			t.id AS team_id, t.name AS team_name,
+			COALESCE(sq.players, 0) AS players,
+			COALESCE(sq.spent, 0) AS spent,
+			t.total_points - t.used_points AS remaining_points,
+			ROUND(COALESCE(sq.average_price, 0), 2) AS average_price,
+			ROUND(COALESCE(sq.market_value, 0), 2) AS market_value,
+			ROUND(COALESCE(sq.market_value / NULLIF(sq.spent, 0), 0), 3) AS efficiency
+		FROM teams t
+		LEFT JOIN squads sq ON sq.team_id = t.id
+		ORDER BY rank, t.name`).Scan(&rows).Error
+	return rows, err
+}
@@ -0,0 +1,81 @@
+package analytics
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"auction-backend/database"
+	"auction-backend/models"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// testDB opens the PostgreSQL database named by TEST_DATABASE_DSN, migrated
+// and emptied. The reports are SQL only, so the test is skipped without one.
+func testDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	dsn := os.Getenv("TEST_DATABASE_DSN")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_DSN is not set")
+	}
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Exec(`TRUNCATE users, teams, players, auctions, bids, audit_events, points_transactions CASCADE`).Error; err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func create(t *testing.T, db *gorm.DB, value interface{}) {
+	t.Helper()
+
+	if err := db.Create(value).Error; err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLotTimesOpenFirstLotAtAuctionStart(t *testing.T) {
+	db := testDB(t)
+
+	user := models.User{Username: "asha", Email: "asha@example.com", Password: "x", Role: "player"}
+	create(t, db, &user)
+	player := models.Player{UserID: user.ID, Name: "asha", Gender: "female", DateOfBirth: time.Date(1998, 1, 1, 0, 0, 0, 0, time.UTC), Mobile: "9800000000", PlayingCategory: "singles"}
+	create(t, db, &player)
+	team := models.Team{Name: "Smashers", TotalPoints: 12000}
+	create(t, db, &team)
+	auction := models.Auction{Title: "Main", Status: "active", CurrentPlayerID: &player.ID}
+	create(t, db, &auction)
+
+	// The first lot goes up when the auction starts, a minute before its first bid
+	started := time.Now().Add(-time.Hour).Truncate(time.Second)
+	create(t, db, &models.AuditEvent{
+		Action:     "auction.started",
+		EntityType: "auction",
+		EntityID:   auction.ID.String(),
+		After:      models.JSON(fmt.Sprintf(`{"current_player_id": %q}`, player.ID)),
+		CreatedAt:  started,
+	})
+	create(t, db, &models.Bid{AuctionID: auction.ID, PlayerID: player.ID, TeamID: team.ID, Amount: 500, CreatedAt: started.Add(time.Minute)})
+	create(t, db, &models.PointsTransaction{TeamID: team.ID, PlayerID: &player.ID, AuctionID: &auction.ID, Type: "purchase", Amount: 500, CreatedAt: started.Add(90 * time.Second)})
+
+	lots, err := LotTimes(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lots) != 1 {
+		t.Fatalf("lots = %+v, want one", lots)
+	}
+	if !lots[0].OpenedAt.Equal(started) || lots[0].Seconds != 90 {
+		t.Fatalf("lot opened at %v and took %vs, want %v and 90s", lots[0].OpenedAt, lots[0].Seconds, started)
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"auction-backend/database"
+	"auction-backend/doctor"
+
+	"github.com/joho/godotenv"
+)
+
+func main() {
+	fix := flag.Bool("fix", false, "repair fixable issues")
+	dryRun := flag.Bool("dry-run", false, "with -fix, show what would change without committing")
+	flag.Parse()
+
+	if err := godotenv.Load(); err != nil {
+		log.Println("No .env file found, using system environment variables")
+	}
+
+	db, err := database.InitDB()
+	if err != nil {
+		log.Fatal("Failed to connect to database:", err)
+	}
+
+	if !*fix {
+		issues, err := doctor.Scan(db)
+		if err != nil {
+			log.Fatal("Scan failed:", err)
+		}
+		printIssues("Found", issues)
+		if len(issues) > 0 {
+			os.Exit(1)
+		}
+		return
+	}
+
+	report, err := doctor.Repair(db, *dryRun)
+	if err != nil {
+		log.Fatal("Repair failed:", err)
+	}
+
+	if report.DryRun {
+		fmt.Println("Dry run: no changes were committed")
+	}
+	printIssues("Found", report.Found)
+	printIssues("Fixed", report.Fixed)
+	printIssues("Remaining", report.Remaining)
+
+	if len(report.Remaining) > 0 {
+		os.Exit(1)
+	}
+}
+
+func printIssues(heading string, issues []doctor.Issue) {
+	fmt.Printf("%s: %d issue(s)\n", heading, len(issues))
+	for _, issue := range issues {
+		fixable := ""
+		if !issue.Fixable {
+			fixable = " (manual)"
+		}
+		fmt.Printf("  [%s] %s %s: %s%s\n", issue.Code, issue.EntityType, issue.EntityID, issue.Description, fixable)
+	}
+}
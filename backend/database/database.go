@@ -5,8 +5,6 @@ import (
 	"log"
 	"os"
 
-	"auction-backend/models"
-
 	"github.com/go-redis/redis/v8"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
@@ -16,8 +14,8 @@ import (
 var DB *gorm.DB
 var RedisClient *redis.Client
 
-// InitDB initializes the PostgreSQL database connection
-func InitDB() (*gorm.DB, error) {
+// Connect opens the PostgreSQL connection without checking the schema
+func Connect() (*gorm.DB, error) {
 	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=disable TimeZone=Asia/Kolkata",
 		os.Getenv("DB_HOST"),
 		os.Getenv("DB_USER"),
@@ -34,9 +32,19 @@ func InitDB() (*gorm.DB, error) {
 		return nil, fmt.Errorf("failed to connect to database: %v", err)
 	}
 
-	// Auto migrate the database
-	if err := AutoMigrate(db); err != nil {
-		return nil, fmt.Errorf("failed to migrate database: %v", err)
+	return db, nil
+}
+
+// InitDB initializes the PostgreSQL database connection and verifies the schema
+// version. Migrations are applied separately with the migrate subcommand.
+func InitDB() (*gorm.DB, error) {
+	db, err := Connect()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := CheckSchemaVersion(db); err != nil {
+		return nil, err
 	}
 
 	DB = db
@@ -56,17 +64,3 @@ func InitRedis() *redis.Client {
 	log.Println("Redis connected successfully")
 	return redisClient
 }
-
-// AutoMigrate runs database migrations
-func AutoMigrate(db *gorm.DB) error {
-	return db.AutoMigrate(
-		&models.User{},
-		&models.Team{},
-		&models.Player{},
-		&models.Auction{},
-		&models.Bid{},
-		&models.Category{},
-		&models.PlayerCategory{},
-		&models.RetainedPlayer{},
-	)
-}
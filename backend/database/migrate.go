@@ -0,0 +1,257 @@
+package database
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// Migration is a numbered schema change with its up and down SQL
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// MigrationState reports whether a migration has been applied
+type MigrationState struct {
+	Version   int        `json:"version"`
+	Name      string     `json:"name"`
+	AppliedAt *time.Time `json:"applied_at"`
+}
+
+// schemaMigration is a row in the schema_migrations table
+type schemaMigration struct {
+	Version   int       `gorm:"primaryKey;autoIncrement:false"`
+	Name      string    `gorm:"not null"`
+	AppliedAt time.Time `gorm:"not null"`
+}
+
+func (schemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// LoadMigrations reads the embedded migrations, ordered by version.
+// Files are named NNNN_name.up.sql and NNNN_name.down.sql.
+func LoadMigrations() ([]Migration, error) {
+	entries, err := fs.ReadDir(migrationFiles, "migrations")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int]*Migration{}
+	for _, entry := range entries {
+		fileName := entry.Name()
+
+		var direction string
+		switch {
+		case strings.HasSuffix(fileName, ".up.sql"):
+			direction = "up"
+		case strings.HasSuffix(fileName, ".down.sql"):
+			direction = "down"
+		default:
+			continue
+		}
+
+		base := strings.TrimSuffix(fileName, "."+direction+".sql")
+		prefix, name, ok := strings.Cut(base, "_")
+		if !ok {
+			return nil, fmt.Errorf("invalid migration file name: %s", fileName)
+		}
+		version, err := strconv.Atoi(prefix)
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration version in %s: %v", fileName, err)
+		}
+
+		body, err := fs.ReadFile(migrationFiles, "migrations/"+fileName)
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: name}
+			byVersion[version] = m
+		}
+		if direction == "up" {
+			m.Up = string(body)
+		} else {
+			m.Down = string(body)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migration %04d_%s needs both up and down files", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// LatestVersion returns the highest migration version shipped with this build
+func LatestVersion() (int, error) {
+	migrations, err := LoadMigrations()
+	if err != nil {
+		return 0, err
+	}
+	if len(migrations) == 0 {
+		return 0, nil
+	}
+	return migrations[len(migrations)-1].Version, nil
+}
+
+// SchemaVersion returns the highest migration version applied to the database
+func SchemaVersion(db *gorm.DB) (int, error) {
+	if err := ensureMigrationsTable(db); err != nil {
+		return 0, err
+	}
+
+	var version int
+	err := db.Model(&schemaMigration{}).Select("COALESCE(MAX(version), 0)").Scan(&version).Error
+	return version, err
+}
+
+// CheckSchemaVersion fails unless the database is at the version this build expects
+func CheckSchemaVersion(db *gorm.DB) error {
+	current, err := SchemaVersion(db)
+	if err != nil {
+		return err
+	}
+	latest, err := LatestVersion()
+	if err != nil {
+		return err
+	}
+
+	if current < latest {
+		return fmt.Errorf("database schema is at version %d but this build needs %d; run `migrate up`", current, latest)
+	}
+	if current > latest {
+		return fmt.Errorf("database schema is at version %d, newer than this build (%d)", current, latest)
+	}
+	return nil
+}
+
+// MigrateUp applies every pending migration, each in its own transaction
+func MigrateUp(db *gorm.DB) ([]Migration, error) {
+	migrations, err := LoadMigrations()
+	if err != nil {
+		return nil, err
+	}
+	current, err := SchemaVersion(db)
+	if err != nil {
+		return nil, err
+	}
+
+	applied := []Migration{}
+	for _, m := range migrations {
+		if m.Version <= current {
+			continue
+		}
+
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Exec(m.Up).Error; err != nil {
+				return err
+			}
+			return tx.Create(&schemaMigration{Version: m.Version, Name: m.Name, AppliedAt: time.Now()}).Error
+		})
+		if err != nil {
+			return applied, fmt.Errorf("migration %04d_%s failed: %v", m.Version, m.Name, err)
+		}
+		applied = append(applied, m)
+	}
+	return applied, nil
+}
+
+// MigrateDown rolls back the given number of applied migrations, newest first
+func MigrateDown(db *gorm.DB, steps int) ([]Migration, error) {
+	migrations, err := LoadMigrations()
+	if err != nil {
+		return nil, err
+	}
+	if err := ensureMigrationsTable(db); err != nil {
+		return nil, err
+	}
+
+	var appliedRows []schemaMigration
+	if err := db.Order("version DESC").Limit(steps).Find(&appliedRows).Error; err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int]Migration{}
+	for _, m := range migrations {
+		byVersion[m.Version] = m
+	}
+
+	reverted := []Migration{}
+	for _, row := range appliedRows {
+		m, ok := byVersion[row.Version]
+		if !ok {
+			return reverted, fmt.Errorf("no down migration for applied version %d", row.Version)
+		}
+
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Exec(m.Down).Error; err != nil {
+				return err
+			}
+			return tx.Delete(&schemaMigration{}, row.Version).Error
+		})
+		if err != nil {
+			return reverted, fmt.Errorf("rollback of %04d_%s failed: %v", m.Version, m.Name, err)
+		}
+		reverted = append(reverted, m)
+	}
+	return reverted, nil
+}
+
+// MigrationStatus lists every known migration and when it was applied
+func MigrationStatus(db *gorm.DB) ([]MigrationState, error) {
+	migrations, err := LoadMigrations()
+	if err != nil {
+		return nil, err
+	}
+	if err := ensureMigrationsTable(db); err != nil {
+		return nil, err
+	}
+
+	var appliedRows []schemaMigration
+	if err := db.Find(&appliedRows).Error; err != nil {
+		return nil, err
+	}
+	appliedAt := map[int]time.Time{}
+	for _, row := range appliedRows {
+		appliedAt[row.Version] = row.AppliedAt
+	}
+
+	states := make([]MigrationState, 0, len(migrations))
+	for _, m := range migrations {
+		state := MigrationState{Version: m.Version, Name: m.Name}
+		if t, ok := appliedAt[m.Version]; ok {
+			state.AppliedAt = &t
+		}
+		states = append(states, state)
+	}
+	return states, nil
+}
+
+func ensureMigrationsTable(db *gorm.DB) error {
+	return db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+		version BIGINT PRIMARY KEY,
+		name TEXT NOT NULL,
+		applied_at TIMESTAMPTZ NOT NULL
+	)`).Error
+}
@@ -0,0 +1,11 @@
+DROP TABLE IF EXISTS points_transactions;
+DROP TABLE IF EXISTS audit_events;
+DROP FUNCTION IF EXISTS audit_events_append_only();
+DROP TABLE IF EXISTS retained_players;
+DROP TABLE IF EXISTS bids;
+DROP TABLE IF EXISTS auctions;
+DROP TABLE IF EXISTS player_categories;
+DROP TABLE IF EXISTS categories;
+DROP TABLE IF EXISTS players;
+DROP TABLE IF EXISTS users;
+DROP TABLE IF EXISTS teams;
@@ -0,0 +1,160 @@
+-- Initial schema. Tables use IF NOT EXISTS so databases created by the old
+-- AutoMigrate start-up can be brought under versioned migrations in place.
+
+CREATE TABLE IF NOT EXISTS teams (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name TEXT NOT NULL,
+    total_points BIGINT DEFAULT 12000,
+    used_points BIGINT DEFAULT 0,
+    player_count BIGINT DEFAULT 0,
+    min_players BIGINT DEFAULT 12,
+    max_players BIGINT DEFAULT 20,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+
+CREATE TABLE IF NOT EXISTS users (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    username TEXT NOT NULL UNIQUE,
+    email TEXT NOT NULL UNIQUE,
+    password TEXT NOT NULL,
+    role TEXT NOT NULL DEFAULT 'player',
+    team_id UUID,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+
+CREATE TABLE IF NOT EXISTS players (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id),
+    name TEXT NOT NULL,
+    gender TEXT NOT NULL,
+    date_of_birth TIMESTAMPTZ NOT NULL,
+    mobile TEXT NOT NULL,
+    playing_category TEXT NOT NULL,
+    accomplishments TEXT,
+    is_retained BOOLEAN DEFAULT FALSE,
+    retained_by UUID,
+    current_team_id UUID REFERENCES teams(id),
+    base_price BIGINT DEFAULT 200,
+    current_price BIGINT DEFAULT 200,
+    is_sold BOOLEAN DEFAULT FALSE,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+
+CREATE TABLE IF NOT EXISTS categories (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name TEXT NOT NULL UNIQUE,
+    description TEXT,
+    min_age BIGINT,
+    max_age BIGINT,
+    gender TEXT,
+    type TEXT,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+
+CREATE TABLE IF NOT EXISTS player_categories (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    player_id UUID NOT NULL REFERENCES players(id),
+    category_id UUID NOT NULL REFERENCES categories(id),
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+
+CREATE TABLE IF NOT EXISTS auctions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    title TEXT NOT NULL,
+    status TEXT DEFAULT 'pending',
+    start_time TIMESTAMPTZ,
+    end_time TIMESTAMPTZ,
+    current_player_id UUID,
+    current_bid BIGINT DEFAULT 0,
+    winning_team_id UUID,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+
+CREATE TABLE IF NOT EXISTS bids (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    auction_id UUID NOT NULL REFERENCES auctions(id),
+    player_id UUID NOT NULL REFERENCES players(id),
+    team_id UUID NOT NULL REFERENCES teams(id),
+    amount BIGINT NOT NULL,
+    is_winning BOOLEAN DEFAULT FALSE,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+
+CREATE TABLE IF NOT EXISTS retained_players (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    player_id UUID NOT NULL REFERENCES players(id),
+    team_id UUID NOT NULL REFERENCES teams(id),
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+
+CREATE TABLE IF NOT EXISTS audit_events (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    actor_id TEXT,
+    actor_role TEXT,
+    action TEXT NOT NULL,
+    entity_type TEXT NOT NULL,
+    entity_id TEXT,
+    before JSONB,
+    after JSONB,
+    request_id TEXT,
+    ip_address TEXT,
+    created_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_events_actor_id ON audit_events (actor_id);
+CREATE INDEX IF NOT EXISTS idx_audit_events_action ON audit_events (action);
+CREATE INDEX IF NOT EXISTS idx_audit_entity ON audit_events (entity_type, entity_id);
+CREATE INDEX IF NOT EXISTS idx_audit_events_request_id ON audit_events (request_id);
+CREATE INDEX IF NOT EXISTS idx_audit_events_created_at ON audit_events (created_at);
+
+-- The audit log is append-only
+CREATE OR REPLACE FUNCTION audit_events_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS audit_events_append_only ON audit_events;
+CREATE TRIGGER audit_events_append_only
+    BEFORE UPDATE OR DELETE ON audit_events
+    FOR EACH ROW EXECUTE FUNCTION audit_events_append_only();
+
+CREATE TABLE IF NOT EXISTS points_transactions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    team_id UUID NOT NULL REFERENCES teams(id),
+    player_id UUID,
+    auction_id UUID,
+    type TEXT NOT NULL,
+    amount BIGINT NOT NULL,
+    note TEXT,
+    created_by TEXT,
+    created_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_points_transactions_team_id ON points_transactions (team_id);
+CREATE INDEX IF NOT EXISTS idx_points_transactions_player_id ON points_transactions (player_id);
+
+-- Hot query paths
+CREATE INDEX IF NOT EXISTS idx_bids_auction_id ON bids (auction_id);
+CREATE INDEX IF NOT EXISTS idx_players_is_sold ON players (is_sold);
+CREATE INDEX IF NOT EXISTS idx_players_current_team_id ON players (current_team_id);
+
+-- At most one winning bid per auction. Older duplicates are cleared first so
+-- the index can be built on existing data.
+UPDATE bids b SET is_winning = FALSE
+WHERE b.is_winning AND EXISTS (
+    SELECT 1 FROM bids newer
+    WHERE newer.auction_id = b.auction_id
+      AND newer.is_winning
+      AND (newer.created_at, newer.id) > (b.created_at, b.id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uniq_bids_auction_winning ON bids (auction_id) WHERE is_winning;
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_players_user_id;
+DROP INDEX IF EXISTS idx_players_registration_status;
+
+ALTER TABLE players DROP COLUMN IF EXISTS reviewed_at;
+ALTER TABLE players DROP COLUMN IF EXISTS reviewed_by;
+ALTER TABLE players DROP COLUMN IF EXISTS review_reason;
+ALTER TABLE players DROP COLUMN IF EXISTS registration_status;
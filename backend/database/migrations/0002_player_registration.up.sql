@@ -0,0 +1,11 @@
+-- Players can register themselves and wait for admin review. Existing
+-- players were all entered by admins, so they start out approved.
+ALTER TABLE players ADD COLUMN IF NOT EXISTS registration_status TEXT NOT NULL DEFAULT 'approved';
+ALTER TABLE players ADD COLUMN IF NOT EXISTS review_reason TEXT;
+ALTER TABLE players ADD COLUMN IF NOT EXISTS reviewed_by TEXT;
+ALTER TABLE players ADD COLUMN IF NOT EXISTS reviewed_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_players_registration_status ON players (registration_status);
+
+-- A login has at most one player profile
+CREATE UNIQUE INDEX IF NOT EXISTS idx_players_user_id ON players (user_id);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS player_documents;
+
+ALTER TABLE players DROP COLUMN IF EXISTS photo_card_url;
+ALTER TABLE players DROP COLUMN IF EXISTS photo_thumb_url;
+ALTER TABLE players DROP COLUMN IF EXISTS photo_key;
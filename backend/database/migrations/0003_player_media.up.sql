@@ -0,0 +1,18 @@
+-- Player photos are stored as resized renditions; the URLs are kept for responses
+ALTER TABLE players ADD COLUMN IF NOT EXISTS photo_key TEXT;
+ALTER TABLE players ADD COLUMN IF NOT EXISTS photo_thumb_url TEXT;
+ALTER TABLE players ADD COLUMN IF NOT EXISTS photo_card_url TEXT;
+
+CREATE TABLE IF NOT EXISTS player_documents (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    player_id UUID NOT NULL REFERENCES players(id) ON DELETE CASCADE,
+    kind TEXT NOT NULL,
+    file_name TEXT NOT NULL,
+    content_type TEXT NOT NULL,
+    size BIGINT NOT NULL,
+    storage_key TEXT NOT NULL,
+    uploaded_by TEXT,
+    created_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_player_documents_player_id ON player_documents (player_id);
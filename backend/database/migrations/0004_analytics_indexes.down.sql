@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_points_transactions_team_created;
+DROP INDEX IF EXISTS idx_bids_lot;
@@ -0,0 +1,3 @@
+-- Analytics group bids by lot and walk each team's ledger in time order
+CREATE INDEX IF NOT EXISTS idx_bids_lot ON bids (auction_id, player_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_points_transactions_team_created ON points_transactions (team_id, created_at);
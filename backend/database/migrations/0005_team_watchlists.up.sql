@@ -0,0 +1,14 @@
+-- Private watchlists: each team's targets with priority, target price and notes
+CREATE TABLE IF NOT EXISTS watchlist_entries (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    team_id UUID NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+    player_id UUID NOT NULL REFERENCES players(id) ON DELETE CASCADE,
+    priority BIGINT NOT NULL DEFAULT 3,
+    target_price BIGINT DEFAULT 0,
+    notes TEXT,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_watchlist_team_player ON watchlist_entries (team_id, player_id);
+CREATE INDEX IF NOT EXISTS idx_watchlist_entries_player_id ON watchlist_entries (player_id);
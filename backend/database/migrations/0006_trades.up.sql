@@ -0,0 +1,31 @@
+-- Trades move players and points between teams after the auction
+CREATE TABLE IF NOT EXISTS trades (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    proposer_team_id UUID NOT NULL REFERENCES teams(id),
+    receiver_team_id UUID NOT NULL REFERENCES teams(id),
+    status TEXT NOT NULL DEFAULT 'proposed',
+    points BIGINT DEFAULT 0,
+    note TEXT,
+    proposed_by TEXT,
+    responded_at TIMESTAMPTZ,
+    reviewed_by TEXT,
+    review_reason TEXT,
+    completed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_trades_proposer_team_id ON trades (proposer_team_id);
+CREATE INDEX IF NOT EXISTS idx_trades_receiver_team_id ON trades (receiver_team_id);
+CREATE INDEX IF NOT EXISTS idx_trades_status ON trades (status);
+
+CREATE TABLE IF NOT EXISTS trade_items (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    trade_id UUID NOT NULL REFERENCES trades(id) ON DELETE CASCADE,
+    player_id UUID NOT NULL REFERENCES players(id),
+    from_team_id UUID NOT NULL REFERENCES teams(id),
+    to_team_id UUID NOT NULL REFERENCES teams(id),
+    price BIGINT DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_trade_items_trade_id ON trade_items (trade_id);
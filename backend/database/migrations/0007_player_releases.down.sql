@@ -0,0 +1,2 @@
+ALTER TABLE auctions DROP COLUMN IF EXISTS supplementary;
+DROP TABLE IF EXISTS releases;
@@ -0,0 +1,29 @@
+-- Releases return a player to the pool and give the team a replacement slot
+CREATE TABLE IF NOT EXISTS releases (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    team_id UUID NOT NULL REFERENCES teams(id),
+    player_id UUID NOT NULL REFERENCES players(id),
+    status TEXT NOT NULL DEFAULT 'requested',
+    reason TEXT,
+    requested_by TEXT,
+    price BIGINT DEFAULT 0,
+    refund_percent BIGINT DEFAULT 0,
+    refund_amount BIGINT DEFAULT 0,
+    reviewed_by TEXT,
+    review_reason TEXT,
+    reviewed_at TIMESTAMPTZ,
+    replacement_player_id UUID REFERENCES players(id),
+    replacement_price BIGINT DEFAULT 0,
+    replaced_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_releases_team_id ON releases (team_id);
+CREATE INDEX IF NOT EXISTS idx_releases_player_id ON releases (player_id);
+CREATE INDEX IF NOT EXISTS idx_releases_status ON releases (status);
+-- A player can have only one release awaiting review
+CREATE UNIQUE INDEX IF NOT EXISTS idx_releases_pending_player ON releases (player_id) WHERE status = 'requested';
+
+-- Supplementary auctions sign replacements into open slots
+ALTER TABLE auctions ADD COLUMN IF NOT EXISTS supplementary BOOLEAN DEFAULT FALSE;
@@ -0,0 +1,297 @@
+package doctor
+
+import (
+	"errors"
+	"fmt"
+
+	"auction-backend/ledger"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Issue codes reported by Scan
+const (
+	SoldWithoutTeam     = "sold_without_team"
+	TeamWithoutSale     = "team_without_sale"
+	PlayerCountMismatch = "player_count_mismatch"
+	MultipleWinningBids = "multiple_winning_bids"
+	LedgerDrift         = "ledger_drift"
+	RosterLedgerDrift   = "roster_ledger_drift"
+)
+
+// Issue is a single invariant violation found in the auction data
+type Issue struct {
+	Code        string    `json:"code"`
+	EntityType  string    `json:"entity_type"`
+	EntityID    uuid.UUID `json:"entity_id"`
+	Description string    `json:"description"`
+	Fixable     bool      `json:"fixable"`
+}
+
+// Report is the outcome of a Repair run
+type Report struct {
+	DryRun    bool    `json:"dry_run"`
+	Found     []Issue `json:"found"`
+	Fixed     []Issue `json:"fixed"`
+	Remaining []Issue `json:"remaining"`
+}
+
+// errDryRun rolls back the repair transaction once a dry run has been evaluated
+var errDryRun = errors.New("dry run")
+
+// Scan checks the auction data for invariant violations
+func Scan(db *gorm.DB) ([]Issue, error) {
+	issues := []Issue{}
+
+	checks := []func(*gorm.DB) ([]Issue, error){
+		soldWithoutTeam,
+		teamWithoutSale,
+		playerCountMismatch,
+		multipleWinningBids,
+		ledgerDrift,
+	}
+	for _, check := range checks {
+		found, err := check(db)
+		if err != nil {
+			return nil, err
+		}
+		issues = append(issues, found...)
+	}
+
+	return issues, nil
+}
+
+// Repair fixes every fixable issue inside a single transaction. With dryRun the
+// fixes are applied, the data re-scanned, and the transaction rolled back, so the
+// report shows exactly what a real run would change.
+func Repair(db *gorm.DB, dryRun bool) (*Report, error) {
+	report := &Report{DryRun: dryRun}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		found, err := Scan(tx)
+		if err != nil {
+			return err
+		}
+		report.Found = found
+
+		for _, issue := range found {
+			if !issue.Fixable {
+				continue
+			}
+			if err := fix(tx, issue); err != nil {
+				return fmt.Errorf("failed to fix %s for %s: %v", issue.Code, issue.EntityID, err)
+			}
+		}
+
+		remaining, err := Scan(tx)
+		if err != nil {
+			return err
+		}
+		report.Remaining = remaining
+		report.Fixed = resolved(found, remaining)
+
+		if dryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errDryRun) {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// resolved returns the issues in found that no longer appear in remaining
+func resolved(found, remaining []Issue) []Issue {
+	still := make(map[string]bool, len(remaining))
+	for _, issue := range remaining {
+		still[issue.Code+issue.EntityID.String()] = true
+	}
+
+	fixed := []Issue{}
+	for _, issue := range found {
+		if !still[issue.Code+issue.EntityID.String()] {
+			fixed = append(fixed, issue)
+		}
+	}
+	return fixed
+}
+
+func soldWithoutTeam(db *gorm.DB) ([]Issue, error) {
+	var rows []struct {
+		ID   uuid.UUID
+		Name string
+	}
+	if err := db.Raw("SELECT id, name FROM players WHERE is_sold AND current_team_id IS NULL").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	issues := []Issue{}
+	for _, row := range rows {
+		issues = append(issues, Issue{
+			Code:        SoldWithoutTeam,
+			EntityType:  "player",
+			EntityID:    row.ID,
+			Description: fmt.Sprintf("Player %s is marked sold but has no team", row.Name),
+			Fixable:     true,
+		})
+	}
+	return issues, nil
+}
+
+func teamWithoutSale(db *gorm.DB) ([]Issue, error) {
+	var rows []struct {
+		ID   uuid.UUID
+		Name string
+	}
+	if err := db.Raw("SELECT id, name FROM players WHERE NOT is_sold AND current_team_id IS NOT NULL").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	issues := []Issue{}
+	for _, row := range rows {
+		issues = append(issues, Issue{
+			Code:        TeamWithoutSale,
+			EntityType:  "player",
+			EntityID:    row.ID,
+			Description: fmt.Sprintf("Player %s belongs to a team but is not marked sold", row.Name),
+			Fixable:     true,
+		})
+	}
+	return issues, nil
+}
+
+func playerCountMismatch(db *gorm.DB) ([]Issue, error) {
+	var rows []struct {
+		ID          uuid.UUID
+		Name        string
+		PlayerCount int
+		Roster      int
+	}
+	err := db.Raw(`
+		SELECT t.id, t.name, t.player_count, COUNT(p.id) AS roster
+		FROM teams t
+		LEFT JOIN players p ON p.current_team_id = t.id AND p.is_sold
+		GROUP BY t.id, t.name, t.player_count
+		HAVING t.player_count <> COUNT(p.id)`).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	issues := []Issue{}
+	for _, row := range rows {
+		issues = append(issues, Issue{
+			Code:        PlayerCountMismatch,
+			EntityType:  "team",
+			EntityID:    row.ID,
+			Description: fmt.Sprintf("Team %s has player_count %d but %d sold players on its roster", row.Name, row.PlayerCount, row.Roster),
+			Fixable:     true,
+		})
+	}
+	return issues, nil
+}
+
+func multipleWinningBids(db *gorm.DB) ([]Issue, error) {
+	var rows []struct {
+		AuctionID uuid.UUID
+		Winning   int
+	}
+	err := db.Raw(`
+		SELECT auction_id, COUNT(*) AS winning
+		FROM bids
+		WHERE is_winning
+		GROUP BY auction_id
+		HAVING COUNT(*) > 1`).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	issues := []Issue{}
+	for _, row := range rows {
+		issues = append(issues, Issue{
+			Code:        MultipleWinningBids,
+			EntityType:  "auction",
+			EntityID:    row.AuctionID,
+			Description: fmt.Sprintf("Auction has %d bids marked winning", row.Winning),
+			Fixable:     true,
+		})
+	}
+	return issues, nil
+}
+
+func ledgerDrift(db *gorm.DB) ([]Issue, error) {
+	drifts, err := ledger.Reconcile(db)
+	if err != nil {
+		return nil, err
+	}
+
+	issues := []Issue{}
+	for _, d := range drifts {
+		if d.LedgerTotal != d.UsedPoints {
+			issues = append(issues, Issue{
+				Code:        LedgerDrift,
+				EntityType:  "team",
+				EntityID:    d.TeamID,
+				Description: fmt.Sprintf("Team %s has used_points %d but its ledger sums to %d", d.TeamName, d.UsedPoints, d.LedgerTotal),
+				Fixable:     true,
+			})
+		}
+		if d.RosterLedgerTotal != d.RosterTotal {
+			// Which side is wrong needs a human decision, so this is report-only
+			issues = append(issues, Issue{
+				Code:        RosterLedgerDrift,
+				EntityType:  "team",
+				EntityID:    d.TeamID,
+				Description: fmt.Sprintf("Team %s roster prices sum to %d but ledger purchases for them sum to %d", d.TeamName, d.RosterTotal, d.RosterLedgerTotal),
+				Fixable:     false,
+			})
+		}
+	}
+	return issues, nil
+}
+
+// fix applies the repair for a single issue
+func fix(tx *gorm.DB, issue Issue) error {
+	switch issue.Code {
+	case SoldWithoutTeam:
+		// Restore the team from the player's latest ledger purchase, otherwise return them to the pool
+		var purchase struct {
+			TeamID *uuid.UUID
+		}
+		if err := tx.Raw(`
+			SELECT team_id FROM points_transactions
+			WHERE player_id = ? AND type IN ?
+			ORDER BY created_at DESC LIMIT 1`, issue.EntityID, ledger.AcquisitionTypes).
+			Scan(&purchase).Error; err != nil {
+			return err
+		}
+		if purchase.TeamID != nil {
+			return tx.Exec("UPDATE players SET current_team_id = ? WHERE id = ?", *purchase.TeamID, issue.EntityID).Error
+		}
+		return tx.Exec("UPDATE players SET is_sold = false, current_price = base_price WHERE id = ?", issue.EntityID).Error
+
+	case TeamWithoutSale:
+		return tx.Exec("UPDATE players SET is_sold = true WHERE id = ?", issue.EntityID).Error
+
+	case PlayerCountMismatch:
+		return tx.Exec(`UPDATE teams SET player_count = (
+			SELECT COUNT(*) FROM players WHERE current_team_id = teams.id AND is_sold
+		) WHERE id = ?`, issue.EntityID).Error
+
+	case MultipleWinningBids:
+		// Keep only the most recent bid as the winner
+		return tx.Exec(`UPDATE bids SET is_winning = false
+			WHERE auction_id = ? AND is_winning AND id <> (
+				SELECT id FROM bids WHERE auction_id = ? AND is_winning ORDER BY created_at DESC LIMIT 1
+			)`, issue.EntityID, issue.EntityID).Error
+
+	case LedgerDrift:
+		return tx.Exec(`UPDATE teams SET used_points = (
+			SELECT COALESCE(SUM(amount), 0) FROM points_transactions WHERE team_id = teams.id
+		) WHERE id = ?`, issue.EntityID).Error
+	}
+
+	return fmt.Errorf("no repair for issue %s", issue.Code)
+}
@@ -0,0 +1,145 @@
+// Package export writes tabular reports as CSV, XLSX workbooks or printable PDF
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// Formats a report can be written in
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+	FormatPDF  = "pdf"
+)
+
+// ContentTypes maps each format to its MIME type
+var ContentTypes = map[string]string{
+	FormatCSV:  "text/csv; charset=utf-8",
+	FormatXLSX: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	FormatPDF:  "application/pdf",
+}
+
+// Field is a labelled value shown above a table, such as a team's remaining budget
+type Field struct {
+	Label string
+	Value interface{}
+}
+
+// Table is one section of a report. Cells are strings, ints, floats, bools
+// or times; numbers stay numbers in XLSX.
+type Table struct {
+	Name    string
+	Summary []Field
+	Columns []string
+	Rows    [][]interface{}
+}
+
+// Report is a titled set of tables. CSV holds a single table, so there the
+// tables are merged; see Flatten.
+type Report struct {
+	Title       string
+	GeneratedAt time.Time
+	GroupLabel  string
+	Tables      []Table
+	// OneSheet merges the tables onto one XLSX sheet, for reports with many small tables
+	OneSheet bool
+}
+
+// Write writes the report in the given format
+func Write(w io.Writer, format string, report Report) error {
+	switch format {
+	case FormatCSV:
+		return WriteCSV(w, report)
+	case FormatXLSX:
+		return WriteXLSX(w, report)
+	case FormatPDF:
+		return WritePDF(w, report)
+	default:
+		return fmt.Errorf("unknown export format %q", format)
+	}
+}
+
+// Flatten merges the report's tables into one. Tables of a report share their
+// columns and summary labels; each row starts with its table's name under
+// GroupLabel, followed by the table's summary values. A single table without
+// a summary is returned as it is.
+func (r Report) Flatten() Table {
+	if len(r.Tables) == 1 && len(r.Tables[0].Summary) == 0 {
+		return r.Tables[0]
+	}
+
+	flat := Table{Name: r.Title}
+	if len(r.Tables) == 0 {
+		return flat
+	}
+	flat.Columns = append(flat.Columns, r.GroupLabel)
+	for _, field := range r.Tables[0].Summary {
+		flat.Columns = append(flat.Columns, field.Label)
+	}
+	flat.Columns = append(flat.Columns, r.Tables[0].Columns...)
+
+	for _, table := range r.Tables {
+		prefix := []interface{}{table.Name}
+		for _, field := range table.Summary {
+			prefix = append(prefix, field.Value)
+		}
+		for _, row := range table.Rows {
+			flat.Rows = append(flat.Rows, append(append([]interface{}(nil), prefix...), row...))
+		}
+	}
+	return flat
+}
+
+// WriteCSV writes the report as one CSV table
+func WriteCSV(w io.Writer, report Report) error {
+	table := report.Flatten()
+	out := csv.NewWriter(w)
+	if err := out.Write(table.Columns); err != nil {
+		return err
+	}
+	for _, row := range table.Rows {
+		if err := out.Write(cellStrings(row)); err != nil {
+			return err
+		}
+	}
+	out.Flush()
+	return out.Error()
+}
+
+func cellStrings(row []interface{}) []string {
+	cells := make([]string, len(row))
+	for i, cell := range row {
+		cells[i] = FormatCell(cell)
+	}
+	return cells
+}
+
+// FormatCell renders a cell as text
+func FormatCell(cell interface{}) string {
+	switch v := cell.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case int:
+		return strconv.Itoa(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		if v {
+			return "Yes"
+		}
+		return "No"
+	case time.Time:
+		if v.IsZero() {
+			return ""
+		}
+		return v.Format("2006-01-02 15:04:05")
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,92 @@
+package export
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func squadReport(rows int) Report {
+	table := Table{
+		Name:    "Smashers",
+		Summary: []Field{{Label: "Remaining", Value: 1200}},
+		Columns: []string{"Player", "Price", "Signed"},
+	}
+	for i := 0; i < rows; i++ {
+		table.Rows = append(table.Rows, []interface{}{"Asha <Rao>", 500 + i, time.Date(2026, 3, 1, 18, 30, 0, 0, time.UTC)})
+	}
+	return Report{Title: "Squads", Tables: []Table{table, table}}
+}
+
+func TestWriteXLSXRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteXLSX(&buf, squadReport(2)); err != nil {
+		t.Fatal(err)
+	}
+	book, err := excelize.OpenReader(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer book.Close()
+
+	if sheets := book.GetSheetList(); len(sheets) != 2 || sheets[0] != "Smashers" || sheets[1] != "Smashers (2)" {
+		t.Fatalf("sheets = %q", sheets)
+	}
+	rows, err := book.GetRows("Smashers", excelize.Options{RawCellValue: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rows[0][0] != "Squads - Smashers" || rows[1][0] != "Remaining" || rows[1][1] != "1200" {
+		t.Fatalf("title and summary = %q", rows[:2])
+	}
+	if got := rows[3]; len(got) != 3 || got[0] != "Player" || got[2] != "Signed" {
+		t.Fatalf("header = %q", got)
+	}
+	// 1 March 2026 18:30 is day 46082 of the spreadsheet calendar
+	if got := rows[4]; got[0] != "Asha <Rao>" || got[1] != "500" || got[2] != "46082.770833" {
+		t.Fatalf("first row = %q", got)
+	}
+
+	if kind, err := book.GetCellType("Smashers", "B5"); err != nil || kind == excelize.CellTypeSharedString || kind == excelize.CellTypeInlineString {
+		t.Fatalf("price cell type = %v (%v), want a number", kind, err)
+	}
+	styleOf := func(cell string) *excelize.Style {
+		id, err := book.GetCellStyle("Smashers", cell)
+		if err != nil {
+			t.Fatal(err)
+		}
+		style, err := book.GetStyle(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return style
+	}
+	if style := styleOf("A4"); style.Font == nil || !style.Font.Bold {
+		t.Fatal("header is not bold")
+	}
+	if style := styleOf("C5"); style.NumFmt != 22 {
+		t.Fatalf("date number format = %d, want 22", style.NumFmt)
+	}
+}
+
+func TestWritePDFBreaksLongTablesAcrossPages(t *testing.T) {
+	count := func(rows int) int {
+		var buf bytes.Buffer
+		if err := WritePDF(&buf, squadReport(rows)); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.HasPrefix(buf.Bytes(), []byte("%PDF-")) {
+			t.Fatalf("not a PDF: %q", buf.Bytes()[:8])
+		}
+		return bytes.Count(buf.Bytes(), []byte("/Type /Page\n"))
+	}
+
+	if pages := count(3); pages != 1 {
+		t.Fatalf("short report has %d pages, want 1", pages)
+	}
+	if pages := count(100); pages < 5 {
+		t.Fatalf("two tables of 100 rows fit %d pages, want at least 5", pages)
+	}
+}
@@ -0,0 +1,244 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-pdf/fpdf"
+)
+
+// Page layout in points on A4 landscape, which fits wide result tables
+const (
+	pageMargin   = 36.0
+	footerHeight = 20.0
+
+	titleSize   = 16.0
+	headingSize = 12.0
+	textSize    = 9.0
+	footerSize  = 8.0
+	rowHeight   = 14.0
+	cellPadding = 4.0
+)
+
+// oneLine keeps cell text on its line
+var oneLine = strings.NewReplacer("\t", " ", "\n", " ", "\r", " ")
+
+// pdfLayout places content on pages top down in the standard Helvetica fonts,
+// starting a new page when one fills up
+type pdfLayout struct {
+	pdf *fpdf.Fpdf
+	// encode converts text to WinAnsiEncoding, the encoding of the standard
+	// fonts; characters it lacks become '.'
+	encode        func(string) string
+	width, height float64
+	y             float64
+}
+
+func newPDFLayout() *pdfLayout {
+	pdf := fpdf.New("L", "pt", "A4", "")
+	pdf.SetAutoPageBreak(false, 0)
+	width, height := pdf.GetPageSize()
+	return &pdfLayout{
+		pdf:    pdf,
+		encode: pdf.UnicodeTranslatorFromDescriptor(""),
+		width:  width,
+		height: height,
+	}
+}
+
+func (l *pdfLayout) newPage() {
+	l.pdf.AddPage()
+	l.y = pageMargin
+}
+
+// room reports whether height fits above the footer on the current page
+func (l *pdfLayout) room(height float64) bool {
+	return l.y+height <= l.height-pageMargin-footerHeight
+}
+
+func (l *pdfLayout) font(size float64, bold bool) {
+	style := ""
+	if bold {
+		style = "B"
+	}
+	l.pdf.SetFont("Helvetica", style, size)
+}
+
+// textWidth measures text in points
+func (l *pdfLayout) textWidth(s string, size float64, bold bool) float64 {
+	l.font(size, bold)
+	return l.pdf.GetStringWidth(l.encode(oneLine.Replace(s)))
+}
+
+// fitText shortens text with an ellipsis until it fits width
+func (l *pdfLayout) fitText(s string, width, size float64, bold bool) string {
+	width += 0.01 // a column sized to its text must not lose it to rounding
+	if l.textWidth(s, size, bold) <= width {
+		return s
+	}
+	runes := []rune(s)
+	for len(runes) > 0 && l.textWidth(string(runes)+"...", size, bold) > width {
+		runes = runes[:len(runes)-1]
+	}
+	if len(runes) == 0 {
+		return ""
+	}
+	return string(runes) + "..."
+}
+
+// text writes s with its baseline at y
+func (l *pdfLayout) text(x, y float64, s string, size float64, bold bool) {
+	l.font(size, bold)
+	l.pdf.Text(x, y, l.encode(oneLine.Replace(s)))
+}
+
+func (l *pdfLayout) fill(x, y, w, h float64, gray int) {
+	l.pdf.SetFillColor(gray, gray, gray)
+	l.pdf.Rect(x, y, w, h, "F")
+}
+
+func (l *pdfLayout) line(x1, y1, x2, y2 float64) {
+	l.pdf.SetDrawColor(153, 153, 153)
+	l.pdf.SetLineWidth(0.5)
+	l.pdf.Line(x1, y1, x2, y2)
+}
+
+// WritePDF writes the report as a printable document: the title, then each
+// table under its name and summary, continuing across pages with the column
+// headers repeated
+func WritePDF(w io.Writer, report Report) error {
+	layout := newPDFLayout()
+	created := report.GeneratedAt
+	if created.IsZero() {
+		created = time.Now()
+	}
+	layout.pdf.SetTitle(report.Title, true)
+	layout.pdf.SetCreationDate(created)
+	layout.newPage()
+
+	layout.y += titleSize
+	layout.text(pageMargin, layout.y, report.Title, titleSize, true)
+	if !report.GeneratedAt.IsZero() {
+		layout.y += rowHeight
+		layout.text(pageMargin, layout.y, "Generated "+report.GeneratedAt.Format("2 Jan 2006 15:04 MST"), textSize, false)
+	}
+	layout.y += rowHeight
+
+	for _, table := range report.Tables {
+		layoutTable(layout, table)
+	}
+
+	// Number the pages once their count is known
+	pages := layout.pdf.PageCount()
+	for i := 1; i <= pages; i++ {
+		layout.pdf.SetPage(i)
+		footer := fmt.Sprintf("%s - page %d of %d", report.Title, i, pages)
+		layout.text((layout.width-layout.textWidth(footer, footerSize, false))/2, layout.height-pageMargin/2, footer, footerSize, false)
+	}
+
+	return layout.pdf.Output(w)
+}
+
+func layoutTable(l *pdfLayout, table Table) {
+	available := l.width - 2*pageMargin
+
+	// Columns take their natural width. When the table is too wide, narrow
+	// columns keep theirs and the wide ones share what is left.
+	widths := make([]float64, len(table.Columns))
+	for i, column := range table.Columns {
+		widths[i] = l.textWidth(column, textSize, true)
+	}
+	for _, row := range table.Rows {
+		for i, cell := range row {
+			if i < len(widths) {
+				widths[i] = max(widths[i], l.textWidth(FormatCell(cell), textSize, false))
+			}
+		}
+	}
+	total := 0.0
+	for i := range widths {
+		widths[i] += 2 * cellPadding
+		total += widths[i]
+	}
+	if total > available {
+		order := make([]int, len(widths))
+		for i := range order {
+			order[i] = i
+		}
+		sort.Slice(order, func(a, b int) bool { return widths[order[a]] < widths[order[b]] })
+		remaining := available
+		for n, i := range order {
+			widths[i] = min(widths[i], remaining/float64(len(order)-n))
+			remaining -= widths[i]
+		}
+		total = available
+	}
+
+	// Keep the heading with the header and first row
+	headingHeight := 0.0
+	if table.Name != "" {
+		headingHeight += headingSize + 8
+	}
+	headingHeight += float64(len(table.Summary)) * rowHeight
+	if !l.room(headingHeight + 3*rowHeight) {
+		l.newPage()
+	}
+
+	l.y += 8
+	if table.Name != "" {
+		l.y += headingSize
+		l.text(pageMargin, l.y, table.Name, headingSize, true)
+		l.y += 6
+	}
+	for _, field := range table.Summary {
+		l.y += rowHeight
+		label := field.Label + ": "
+		l.text(pageMargin, l.y-3, label, textSize, true)
+		l.text(pageMargin+l.textWidth(label, textSize, true), l.y-3, FormatCell(field.Value), textSize, false)
+	}
+
+	header := func() {
+		l.y += rowHeight
+		x := pageMargin
+		for i, column := range table.Columns {
+			l.text(x+cellPadding, l.y-4, l.fitText(column, widths[i]-2*cellPadding, textSize, true), textSize, true)
+			x += widths[i]
+		}
+		l.line(pageMargin, l.y, pageMargin+total, l.y)
+	}
+	l.y += 4
+	header()
+
+	if len(table.Rows) == 0 {
+		l.y += rowHeight
+		l.text(pageMargin+cellPadding, l.y-4, "No entries", textSize, false)
+	}
+	for r, row := range table.Rows {
+		if !l.room(rowHeight) {
+			l.newPage()
+			header()
+		}
+		l.y += rowHeight
+		if r%2 == 1 {
+			l.fill(pageMargin, l.y-rowHeight, total, rowHeight, 242)
+		}
+		x := pageMargin
+		for i, cell := range row {
+			if i >= len(widths) {
+				break
+			}
+			text := l.fitText(FormatCell(cell), widths[i]-2*cellPadding, textSize, false)
+			tx := x + cellPadding
+			switch cell.(type) {
+			case int, float64:
+				tx = x + widths[i] - cellPadding - l.textWidth(text, textSize, false)
+			}
+			l.text(tx, l.y-4, text, textSize, false)
+			x += widths[i]
+		}
+	}
+	l.y += rowHeight / 2
+}
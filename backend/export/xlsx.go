@@ -0,0 +1,176 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// WriteXLSX writes the report as a workbook with one sheet per table, or a
+// single sheet when OneSheet is set
+func WriteXLSX(w io.Writer, report Report) error {
+	tables := report.Tables
+	if report.OneSheet {
+		tables = []Table{report.Flatten()}
+	}
+	if len(tables) == 0 {
+		tables = []Table{{Name: report.Title}}
+	}
+	names := sheetNames(tables)
+
+	book := excelize.NewFile()
+	defer book.Close()
+	bold, err := book.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+	if err != nil {
+		return err
+	}
+	date, err := book.NewStyle(&excelize.Style{NumFmt: 22}) // m/d/yy h:mm
+	if err != nil {
+		return err
+	}
+
+	for i, table := range tables {
+		if i == 0 {
+			err = book.SetSheetName(book.GetSheetName(0), names[i])
+		} else {
+			_, err = book.NewSheet(names[i])
+		}
+		if err != nil {
+			return err
+		}
+		if err := writeSheet(book, names[i], report, table, bold, date); err != nil {
+			return err
+		}
+	}
+	return book.Write(w)
+}
+
+// writeSheet lays out a table: the report title, the summary, then the rows
+// under a bold header
+func writeSheet(book *excelize.File, sheet string, report Report, table Table, bold, date int) error {
+	// boldCells holds how many leading cells of each row are bold
+	var rows [][]interface{}
+	var boldCells []int
+	addRow := func(cells []interface{}, bold int) {
+		rows = append(rows, cells)
+		boldCells = append(boldCells, bold)
+	}
+
+	title := report.Title
+	if table.Name != "" && table.Name != report.Title {
+		title += " - " + table.Name
+	}
+	addRow([]interface{}{title}, 1)
+	for _, field := range table.Summary {
+		addRow([]interface{}{field.Label, field.Value}, 1)
+	}
+	addRow(nil, 0)
+	header := make([]interface{}, len(table.Columns))
+	for i, column := range table.Columns {
+		header[i] = column
+	}
+	addRow(header, len(header))
+	for _, row := range table.Rows {
+		addRow(row, 0)
+	}
+
+	// Size each column to its longest value below the title
+	widths := make([]int, len(table.Columns))
+	for _, row := range rows[1:] {
+		for i, cell := range row {
+			if i < len(widths) {
+				widths[i] = max(widths[i], len([]rune(FormatCell(cell))))
+			}
+		}
+	}
+	for i, width := range widths {
+		column, err := excelize.ColumnNumberToName(i + 1)
+		if err != nil {
+			return err
+		}
+		if err := book.SetColWidth(sheet, column, column, float64(min(max(width+2, 8), 60))); err != nil {
+			return err
+		}
+	}
+
+	for r, row := range rows {
+		for c, cell := range row {
+			ref, err := excelize.CoordinatesToCellName(c+1, r+1)
+			if err != nil {
+				return err
+			}
+			style := 0
+			if c < boldCells[r] {
+				style = bold
+			}
+			switch v := cell.(type) {
+			case nil:
+				continue
+			case int, float64:
+				err = book.SetCellValue(sheet, ref, v)
+			case time.Time:
+				if v.IsZero() {
+					continue
+				}
+				err = book.SetCellFloat(sheet, ref, serialDate(v), 6, 64)
+				style = date
+			default:
+				err = book.SetCellStr(sheet, ref, FormatCell(cell))
+			}
+			if err != nil {
+				return err
+			}
+			if style != 0 {
+				if err := book.SetCellStyle(sheet, ref, ref, style); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// serialDate converts a time to the day count spreadsheets store dates as
+func serialDate(t time.Time) float64 {
+	// Spreadsheets have no time zones; keep the wall clock time
+	wall := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC)
+	return wall.Sub(time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)).Hours() / 24
+}
+
+// sheetNames makes the table names valid, unique sheet names: at most 31
+// characters, none of []:*?/\ and no apostrophe at either end
+func sheetNames(tables []Table) []string {
+	names := make([]string, len(tables))
+	used := make(map[string]bool)
+	for i, table := range tables {
+		name := strings.Map(func(r rune) rune {
+			if strings.ContainsRune(`[]:*?/\`, r) {
+				return '-'
+			}
+			return r
+		}, strings.Trim(strings.TrimSpace(table.Name), "'"))
+		if name == "" {
+			name = fmt.Sprintf("Sheet %d", i+1)
+		}
+		name = truncateRunes(name, 31)
+
+		base := name
+		for n := 2; used[strings.ToLower(name)]; n++ {
+			suffix := fmt.Sprintf(" (%d)", n)
+			name = truncateRunes(base, 31-len(suffix)) + suffix
+		}
+		used[strings.ToLower(name)] = true
+		names[i] = name
+	}
+	return names
+}
+
+func truncateRunes(s string, n int) string {
+	if runes := []rune(s); len(runes) > n {
+		return string(runes[:n])
+	}
+	return s
+}
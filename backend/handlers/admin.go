@@ -2,16 +2,17 @@ package handlers
 
 import (
 	"fmt"
-	"log"
 	"net/http"
 	"strings"
 	"time"
 
+	"auction-backend/ledger"
 	"auction-backend/models"
+	"auction-backend/repository"
+	"auction-backend/websocket"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
-	"gorm.io/gorm"
 )
 
 // DashboardStats represents the admin dashboard statistics
@@ -29,34 +30,22 @@ func (h *Handlers) GetAdminDashboard(c *gin.Context) {
 	var stats DashboardStats
 
 	// Get total players
-	var playerCount int64
-	h.DB.Model(&models.Player{}).Count(&playerCount)
-	stats.TotalPlayers = int(playerCount)
+	stats.TotalPlayers, _ = h.Store.Players().Count(repository.PlayerFilter{})
 
 	// Get total teams
-	var teamCount int64
-	h.DB.Model(&models.Team{}).Count(&teamCount)
-	stats.TotalTeams = int(teamCount)
+	stats.TotalTeams, _ = h.Store.Teams().Count()
 
 	// Get active auctions
-	var activeAuctionCount int64
-	h.DB.Model(&models.Auction{}).Where("status = ?", "active").Count(&activeAuctionCount)
-	stats.ActiveAuctions = int(activeAuctionCount)
+	stats.ActiveAuctions, _ = h.Store.Auctions().Count("active")
 
 	// Get total bids
-	var bidCount int64
-	h.DB.Model(&models.Bid{}).Count(&bidCount)
-	stats.TotalBids = int(bidCount)
+	stats.TotalBids, _ = h.Store.Bids().Count()
 
 	// Get total points (sum of all team used points)
-	var totalPoints int64
-	h.DB.Model(&models.Team{}).Select("COALESCE(SUM(used_points), 0)").Scan(&totalPoints)
-	stats.TotalPoints = int(totalPoints)
+	stats.TotalPoints, _ = h.Store.Teams().TotalUsedPoints()
 
-	// Get pending approvals (players not yet approved)
-	var pendingCount int64
-	h.DB.Model(&models.Player{}).Where("is_sold = ?", false).Count(&pendingCount)
-	stats.PendingApprovals = int(pendingCount)
+	// Get pending approvals (registrations awaiting review)
+	stats.PendingApprovals, _ = h.Store.Players().Count(repository.PlayerFilter{RegistrationStatus: models.RegistrationPending})
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
@@ -64,18 +53,41 @@ func (h *Handlers) GetAdminDashboard(c *gin.Context) {
 	})
 }
 
-// GetAuctions returns all auctions with filtering
-func (h *Handlers) GetAuctions(c *gin.Context) {
-	var auctions []models.Auction
+// AuctionResponse is an auction with its current player and winning team loaded
+type AuctionResponse struct {
+	models.Auction
+	CurrentPlayer *PlayerView  `json:"current_player,omitempty"`
+	WinningTeam   *models.Team `json:"winning_team,omitempty"`
+}
 
-	query := h.DB
+// newAuctionResponse loads the auction's current player, as shown to v, and winning team, if any
+func newAuctionResponse(store repository.Store, v viewer, auction models.Auction) AuctionResponse {
+	response := AuctionResponse{
+		Auction: auction,
+	}
 
-	// Add status filter if provided
-	if status := c.Query("status"); status != "" {
-		query = query.Where("status = ?", status)
+	// Load current player if exists
+	if auction.CurrentPlayerID != nil {
+		if player, err := store.Players().GetByID(*auction.CurrentPlayerID); err == nil {
+			response.CurrentPlayer = v.playerPtr(player)
+		}
 	}
 
-	if err := query.Find(&auctions).Error; err != nil {
+	// Load winning team if exists
+	if auction.WinningTeamID != nil {
+		if team, err := store.Teams().GetByID(*auction.WinningTeamID); err == nil {
+			response.WinningTeam = team
+		}
+	}
+
+	return response
+}
+
+// GetAuctions returns all auctions with filtering
+func (h *Handlers) GetAuctions(c *gin.Context) {
+	// Filter by status if provided
+	auctions, err := h.Store.Auctions().List(c.Query("status"))
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"success": false,
 			"error":   "Failed to fetch auctions",
@@ -84,35 +96,9 @@ func (h *Handlers) GetAuctions(c *gin.Context) {
 	}
 
 	// Create response with related data
-	type AuctionResponse struct {
-		models.Auction
-		CurrentPlayer *models.Player `json:"current_player,omitempty"`
-		WinningTeam   *models.Team   `json:"winning_team,omitempty"`
-	}
-
 	var auctionResponses []AuctionResponse
 	for _, auction := range auctions {
-		response := AuctionResponse{
-			Auction: auction,
-		}
-
-		// Load current player if exists
-		if auction.CurrentPlayerID != nil {
-			var player models.Player
-			if err := h.DB.First(&player, *auction.CurrentPlayerID).Error; err == nil {
-				response.CurrentPlayer = &player
-			}
-		}
-
-		// Load winning team if exists
-		if auction.WinningTeamID != nil {
-			var team models.Team
-			if err := h.DB.First(&team, *auction.WinningTeamID).Error; err == nil {
-				response.WinningTeam = &team
-			}
-		}
-
-		auctionResponses = append(auctionResponses, response)
+		auctionResponses = append(auctionResponses, newAuctionResponse(h.Store, viewerOf(c), auction))
 	}
 
 	c.JSON(http.StatusOK, gin.H{
@@ -124,8 +110,7 @@ func (h *Handlers) GetAuctions(c *gin.Context) {
 // CreateAuction creates a new auction
 func (h *Handlers) CreateAuction(c *gin.Context) {
 	// Check if there's already an active auction
-	var existingActiveAuction models.Auction
-	if err := h.DB.Where("status = ?", "active").First(&existingActiveAuction).Error; err == nil {
+	if _, err := h.Store.Auctions().GetActive(); err == nil {
 		c.JSON(http.StatusConflict, gin.H{
 			"success": false,
 			"error":   "There is already an active auction. Please end the current auction before creating a new one.",
@@ -146,11 +131,14 @@ func (h *Handlers) CreateAuction(c *gin.Context) {
 	auction.CreatedAt = time.Now()
 	auction.UpdatedAt = time.Now()
 
-	if err := h.DB.Create(&auction).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"success": false,
-			"error":   "Failed to create auction",
-		})
+	err := h.Store.Transaction(func(tx repository.Store) error {
+		if err := tx.Auctions().Create(&auction); err != nil {
+			return err
+		}
+		return h.recordAudit(tx, c, "auction.created", "auction", auction.ID, nil, auction)
+	})
+	if err != nil {
+		respondError(c, err, "Failed to create auction")
 		return
 	}
 
@@ -165,10 +153,17 @@ func (h *Handlers) CreateAuction(c *gin.Context) {
 
 // UpdateAuction updates an existing auction
 func (h *Handlers) UpdateAuction(c *gin.Context) {
-	auctionID := c.Param("id")
-	var auction models.Auction
+	auctionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid auction ID",
+		})
+		return
+	}
 
-	if err := h.DB.First(&auction, auctionID).Error; err != nil {
+	auction, err := h.Store.Auctions().GetByID(auctionID)
+	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{
 			"success": false,
 			"error":   "Auction not found",
@@ -186,12 +181,16 @@ func (h *Handlers) UpdateAuction(c *gin.Context) {
 	}
 
 	updateData.UpdatedAt = time.Now()
+	before := *auction
 
-	if err := h.DB.Model(&auction).Updates(updateData).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"success": false,
-			"error":   "Failed to update auction",
-		})
+	err = h.Store.Transaction(func(tx repository.Store) error {
+		if err := tx.Auctions().Updates(auction, &updateData); err != nil {
+			return err
+		}
+		return h.recordAudit(tx, c, "auction.updated", "auction", auction.ID, before, auction)
+	})
+	if err != nil {
+		respondError(c, err, "Failed to update auction")
 		return
 	}
 
@@ -206,10 +205,17 @@ func (h *Handlers) UpdateAuction(c *gin.Context) {
 
 // DeleteAuction deletes an auction
 func (h *Handlers) DeleteAuction(c *gin.Context) {
-	auctionID := c.Param("id")
-	var auction models.Auction
+	auctionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid auction ID",
+		})
+		return
+	}
 
-	if err := h.DB.First(&auction, auctionID).Error; err != nil {
+	auction, err := h.Store.Auctions().GetByID(auctionID)
+	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{
 			"success": false,
 			"error":   "Auction not found",
@@ -217,11 +223,14 @@ func (h *Handlers) DeleteAuction(c *gin.Context) {
 		return
 	}
 
-	if err := h.DB.Delete(&auction).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"success": false,
-			"error":   "Failed to delete auction",
-		})
+	err = h.Store.Transaction(func(tx repository.Store) error {
+		if err := tx.Auctions().Delete(auction.ID); err != nil {
+			return err
+		}
+		return h.recordAudit(tx, c, "auction.deleted", "auction", auction.ID, auction, nil)
+	})
+	if err != nil {
+		respondError(c, err, "Failed to delete auction")
 		return
 	}
 
@@ -236,10 +245,17 @@ func (h *Handlers) DeleteAuction(c *gin.Context) {
 
 // StartAuction starts an auction
 func (h *Handlers) StartAuction(c *gin.Context) {
-	auctionID := c.Param("id")
-	var auction models.Auction
+	auctionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid auction ID",
+		})
+		return
+	}
 
-	if err := h.DB.Where("id = ?", auctionID).First(&auction).Error; err != nil {
+	auction, err := h.Store.Auctions().GetByID(auctionID)
+	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{
 			"success": false,
 			"error":   "Auction not found",
@@ -247,8 +263,13 @@ func (h *Handlers) StartAuction(c *gin.Context) {
 		return
 	}
 
+	presenceWarning, overridden, ok := h.checkPresence(c)
+	if !ok {
+		return
+	}
+
 	// Get the first unsold player to start the auction (following category order)
-	firstPlayer, err := h.getNextPlayerByCategoryOrder(nil)
+	firstPlayer, err := h.getNextPlayerByCategoryOrder(h.Store, nil, auction.Supplementary)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"success": false,
@@ -258,44 +279,61 @@ func (h *Handlers) StartAuction(c *gin.Context) {
 	}
 
 	// Assign first player and start auction
+	before := *auction
 	auction.Status = "active"
 	auction.StartTime = time.Now()
 	auction.CurrentPlayerID = &firstPlayer.ID
 	auction.CurrentBid = 0 // Start with 0 to allow first bid at base price
 	auction.UpdatedAt = time.Now()
 
-	// Debug logging
-	log.Printf("StartAuction: Setting CurrentBid to 0 for auction %s", auction.ID)
-
-	if err := h.DB.Save(&auction).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"success": false,
-			"error":   "Failed to start auction",
-		})
+	err = h.Store.Transaction(func(tx repository.Store) error {
+		if err := tx.Auctions().Save(auction); err != nil {
+			return err
+		}
+		if err := h.recordPresenceOverride(tx, c, auction.ID, "start", overridden); err != nil {
+			return err
+		}
+		return h.recordAudit(tx, c, "auction.started", "auction", auction.ID, before, auction)
+	})
+	if err != nil {
+		respondError(c, err, "Failed to start auction")
 		return
 	}
 
 	// Broadcast auction start with first player
 	h.Hub.Broadcast("auction_started", gin.H{
 		"auction": auction,
-		"player":  firstPlayer,
+		"player":  publicViewer.playerPtr(firstPlayer),
 	})
+	h.publishOverlay(auction.ID)
+
+	data := gin.H{
+		"auction": auction,
+		"player":  firstPlayer,
+	}
+	if presenceWarning != nil {
+		data["presence_warning"] = presenceWarning
+	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
-		"data": gin.H{
-			"auction": auction,
-			"player":  firstPlayer,
-		},
+		"data":    data,
 	})
 }
 
 // EndAuction ends an auction
 func (h *Handlers) EndAuction(c *gin.Context) {
-	auctionID := c.Param("id")
-	var auction models.Auction
+	auctionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid auction ID",
+		})
+		return
+	}
 
-	if err := h.DB.Where("id = ?", auctionID).First(&auction).Error; err != nil {
+	auction, err := h.Store.Auctions().GetByID(auctionID)
+	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{
 			"success": false,
 			"error":   "Auction not found",
@@ -303,21 +341,26 @@ func (h *Handlers) EndAuction(c *gin.Context) {
 		return
 	}
 
+	before := *auction
 	auction.Status = "completed"
 	endTime := time.Now()
 	auction.EndTime = &endTime
 	auction.UpdatedAt = time.Now()
 
-	if err := h.DB.Save(&auction).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"success": false,
-			"error":   "Failed to end auction",
-		})
+	err = h.Store.Transaction(func(tx repository.Store) error {
+		if err := tx.Auctions().Save(auction); err != nil {
+			return err
+		}
+		return h.recordAudit(tx, c, "auction.ended", "auction", auction.ID, before, auction)
+	})
+	if err != nil {
+		respondError(c, err, "Failed to end auction")
 		return
 	}
 
 	// Broadcast auction end
 	h.Hub.Broadcast("auction_ended", auction)
+	h.publishOverlay(auction.ID)
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
@@ -325,17 +368,17 @@ func (h *Handlers) EndAuction(c *gin.Context) {
 	})
 }
 
-// getNextPlayerByCategoryOrder gets the next unsold player based on category priority
+// getNextPlayerByCategoryOrder gets the next unsold player in store based on category priority
 // Priority order: 1. Women, 2. Men Under 35, 3. Men 35+
-func (h *Handlers) getNextPlayerByCategoryOrder(currentPlayerID *uuid.UUID) (*models.Player, error) {
+// Released players come up only in a supplementary auction.
+func (h *Handlers) getNextPlayerByCategoryOrder(store repository.Store, currentPlayerID *uuid.UUID, supplementary bool) (*models.Player, error) {
 	// Define category priority order
 	categoryOrder := []string{"women", "men_under_35", "men_35_plus"}
 
 	// If there's a current player, get their category to determine where to start
 	var currentCategory string
 	if currentPlayerID != nil {
-		var currentPlayer models.Player
-		if err := h.DB.First(&currentPlayer, currentPlayerID).Error; err == nil {
+		if currentPlayer, err := store.Players().GetByID(*currentPlayerID); err == nil {
 			currentCategory = currentPlayer.GetPlayerCategory()
 		}
 	}
@@ -353,61 +396,118 @@ func (h *Handlers) getNextPlayerByCategoryOrder(currentPlayerID *uuid.UUID) (*mo
 
 	// Try to find next player in the same category first (if there's a current player)
 	if currentPlayerID != nil {
-		var nextPlayer models.Player
-		query := h.DB.Where("is_sold = ? AND id > ?", false, currentPlayerID)
-
-		// Add category filter based on current player's category
-		switch currentCategory {
-		case "women":
-			query = query.Where("gender = ?", "female")
-		case "men_under_35":
-			query = query.Where("gender = ? AND EXTRACT(YEAR FROM AGE(date_of_birth)) < 35", "male")
-		case "men_35_plus":
-			query = query.Where("gender = ? AND EXTRACT(YEAR FROM AGE(date_of_birth)) >= 35", "male")
-		}
-
-		if err := query.First(&nextPlayer).Error; err == nil {
-			return &nextPlayer, nil
+		if nextPlayer, err := store.Players().NextUnsold(currentCategory, currentPlayerID, supplementary); err == nil {
+			return nextPlayer, nil
 		}
 	}
 
 	// If no next player in same category, move to next category
 	for i := startCategoryIndex; i < len(categoryOrder); i++ {
-		category := categoryOrder[i]
-
 		// Skip the current category if we already tried it
 		if i == startCategoryIndex && currentPlayerID != nil {
 			continue
 		}
 
-		var nextPlayer models.Player
-		query := h.DB.Where("is_sold = ?", false)
-
-		// Add category filter
-		switch category {
-		case "women":
-			query = query.Where("gender = ?", "female")
-		case "men_under_35":
-			query = query.Where("gender = ? AND EXTRACT(YEAR FROM AGE(date_of_birth)) < 35", "male")
-		case "men_35_plus":
-			query = query.Where("gender = ? AND EXTRACT(YEAR FROM AGE(date_of_birth)) >= 35", "male")
+		if nextPlayer, err := store.Players().NextUnsold(categoryOrder[i], nil, supplementary); err == nil {
+			return nextPlayer, nil
 		}
+	}
+
+	// If no players found in any category, return error
+	return nil, repository.ErrNotFound
+}
+
+// sellCurrentPlayer sells the locked auction's current player to its winning
+// team at the current bid, returning the release whose slot the sale filled
+// in a supplementary auction
+func (h *Handlers) sellCurrentPlayer(tx repository.Store, c *gin.Context, auction *models.Auction) (*models.Release, error) {
+	var replaced *models.Release
+	currentPlayer, err := tx.Players().GetByID(*auction.CurrentPlayerID)
+	if err != nil {
+		// Nothing to sell if the player no longer exists
+		return nil, nil
+	}
+
+	// Update player information
+	playerBefore := *currentPlayer
+	currentPlayer.IsSold = true
+	currentPlayer.CurrentTeamID = auction.WinningTeamID
+	currentPlayer.CurrentPrice = auction.CurrentBid
+	if err := tx.Players().Save(currentPlayer); err != nil {
+		return nil, fail(http.StatusInternalServerError, "Failed to update player")
+	}
+
+	// Deduct points from winning team, locked so its player count is incremented
+	// from the committed value
+	winningTeam, err := tx.Teams().GetByIDForUpdate(*auction.WinningTeamID)
+	if err != nil {
+		return nil, fail(http.StatusInternalServerError, "Failed to find winning team")
+	}
 
-		if err := query.First(&nextPlayer).Error; err == nil {
-			return &nextPlayer, nil
+	// Record the purchase in the points ledger
+	teamBefore := *winningTeam
+	if err := tx.PointsTransactions().Record(&models.PointsTransaction{
+		TeamID:    winningTeam.ID,
+		PlayerID:  &currentPlayer.ID,
+		AuctionID: &auction.ID,
+		Type:      ledger.TypePurchase,
+		Amount:    auction.CurrentBid,
+		CreatedBy: c.GetString("user_id"),
+	}); err != nil {
+		return nil, fail(http.StatusInternalServerError, "Failed to update team points")
+	}
+	if winningTeam, err = tx.Teams().GetByID(winningTeam.ID); err != nil {
+		return nil, fail(http.StatusInternalServerError, "Failed to update team points")
+	}
+
+	// Count the player on the winning team's roster
+	winningTeam.PlayerCount += 1
+	if err := tx.Teams().Save(winningTeam); err != nil {
+		return nil, fail(http.StatusInternalServerError, "Failed to update team")
+	}
+
+	// A supplementary auction signs the player into the winner's oldest open replacement slot
+	if auction.Supplementary {
+		if replaced, err = fillReplacementSlot(tx, winningTeam.ID, currentPlayer, auction.CurrentBid); err != nil {
+			return nil, err
 		}
 	}
 
-	// If no players found in any category, return error
-	return nil, gorm.ErrRecordNotFound
+	if err := h.recordAudit(tx, c, "player.sold", "player", currentPlayer.ID, gin.H{
+		"player": playerBefore,
+		"team":   teamBefore,
+	}, gin.H{
+		"player":     currentPlayer,
+		"team":       winningTeam,
+		"auction_id": auction.ID,
+		"release":    replaced,
+	}); err != nil {
+		return nil, fail(http.StatusInternalServerError, "Failed to record audit event")
+	}
+	return replaced, nil
+}
+
+// sameID reports whether two optional IDs are equal
+func sameID(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
 }
 
 // NextPlayer moves to the next player in auction
 func (h *Handlers) NextPlayer(c *gin.Context) {
-	auctionID := c.Param("id")
-	var auction models.Auction
+	auctionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid auction ID",
+		})
+		return
+	}
 
-	if err := h.DB.Where("id = ?", auctionID).First(&auction).Error; err != nil {
+	auction, err := h.Store.Auctions().GetByID(auctionID)
+	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{
 			"success": false,
 			"error":   "Auction not found",
@@ -415,69 +515,74 @@ func (h *Handlers) NextPlayer(c *gin.Context) {
 		return
 	}
 
-	// Mark current player as sold if there's a winning bid
-	if auction.WinningTeamID != nil {
-		var currentPlayer models.Player
-		if err := h.DB.First(&currentPlayer, auction.CurrentPlayerID).Error; err == nil {
-			// Start a transaction to ensure data consistency
-			tx := h.DB.Begin()
-
-			// Update player information
-			currentPlayer.IsSold = true
-			currentPlayer.CurrentTeamID = auction.WinningTeamID
-			currentPlayer.CurrentPrice = auction.CurrentBid
-			if err := tx.Save(&currentPlayer).Error; err != nil {
-				tx.Rollback()
-				c.JSON(http.StatusInternalServerError, gin.H{
-					"success": false,
-					"error":   "Failed to update player",
-				})
-				return
-			}
+	presenceWarning, overridden, ok := h.checkPresence(c)
+	if !ok {
+		return
+	}
 
-			// Deduct points from winning team
-			var winningTeam models.Team
-			if err := tx.First(&winningTeam, auction.WinningTeamID).Error; err != nil {
-				tx.Rollback()
-				c.JSON(http.StatusInternalServerError, gin.H{
-					"success": false,
-					"error":   "Failed to find winning team",
-				})
-				return
-			}
+	// Sell the lot and open the next one in a single transaction on the locked
+	// auction, so a concurrent bid or NextPlayer waits instead of selling the
+	// same lot twice or at a stale price
+	var replaced *models.Release
+	var nextPlayer *models.Player
+	err = h.Store.Transaction(func(tx repository.Store) error {
+		lot := auction
+		var err error
+		if auction, err = tx.Auctions().GetByIDForUpdate(auctionID); err != nil {
+			return fail(http.StatusNotFound, "Auction not found")
+		}
+		if !sameID(auction.CurrentPlayerID, lot.CurrentPlayerID) || !sameID(auction.WinningTeamID, lot.WinningTeamID) {
+			return fail(http.StatusConflict, "The lot changed while moving on. Reload the auction and try again.")
+		}
 
-			// Update team's used points
-			winningTeam.UsedPoints += auction.CurrentBid
-			if err := tx.Save(&winningTeam).Error; err != nil {
-				tx.Rollback()
-				c.JSON(http.StatusInternalServerError, gin.H{
-					"success": false,
-					"error":   "Failed to update team points",
-				})
-				return
+		// Mark current player as sold if there's a winning bid
+		if auction.WinningTeamID != nil && auction.CurrentPlayerID != nil {
+			if replaced, err = h.sellCurrentPlayer(tx, c, auction); err != nil {
+				return err
 			}
+		}
 
-			// Commit transaction
-			tx.Commit()
+		// Get next unsold player based on category order
+		before := *auction
+		nextPlayer, err = h.getNextPlayerByCategoryOrder(tx, auction.CurrentPlayerID, auction.Supplementary)
+		if err != nil {
+			// No more players available, but don't end the auction
+			// Just clear the current player and let admin manually assign players
+			nextPlayer = nil
+			auction.CurrentPlayerID = nil
+		} else {
+			auction.CurrentPlayerID = &nextPlayer.ID
 		}
-	}
+		auction.CurrentBid = 0      // Start with 0 to allow first bid at base price
+		auction.WinningTeamID = nil // Reset winning team for new player
+		auction.UpdatedAt = time.Now()
 
-	// Get next unsold player based on category order
-	nextPlayer, err := h.getNextPlayerByCategoryOrder(auction.CurrentPlayerID)
+		if err := tx.Auctions().Save(auction); err != nil {
+			return fail(http.StatusInternalServerError, "Failed to update auction")
+		}
+		if err := h.recordPresenceOverride(tx, c, auction.ID, "next_player", overridden); err != nil {
+			return fail(http.StatusInternalServerError, "Failed to record audit event")
+		}
+		if err := h.recordAudit(tx, c, "auction.next_player", "auction", auction.ID, before, auction); err != nil {
+			return fail(http.StatusInternalServerError, "Failed to record audit event")
+		}
+		return nil
+	})
 	if err != nil {
-		// No more players available, but don't end the auction
-		// Just clear the current player and let admin manually assign players
-		auction.CurrentPlayerID = nil
-		auction.CurrentBid = 0
-		auction.WinningTeamID = nil
-		auction.UpdatedAt = time.Now()
-		h.DB.Save(&auction)
+		respondError(c, err, "Failed to move to the next player")
+		return
+	}
+	if replaced != nil {
+		h.publishRelease(replaced, false)
+	}
 
-		// Broadcast that no more players are available
-		h.Hub.Broadcast("no_more_players", gin.H{
+	if nextPlayer == nil {
+		// Tell the auction room that no more players are available
+		h.Hub.Publish(websocket.AuctionRoom(auction.ID.String()), "no_more_players", gin.H{
 			"auction_id": auction.ID,
 			"message":    "No more players available for automatic seeding",
 		})
+		h.publishOverlay(auction.ID)
 
 		c.JSON(http.StatusOK, gin.H{
 			"success": true,
@@ -490,55 +595,57 @@ func (h *Handlers) NextPlayer(c *gin.Context) {
 		return
 	}
 
-	// Move to next player
-	auction.CurrentPlayerID = &nextPlayer.ID
-	auction.CurrentBid = 0      // Start with 0 to allow first bid at base price
-	auction.WinningTeamID = nil // Reset winning team for new player
-	auction.UpdatedAt = time.Now()
-
-	// Debug logging
-	log.Printf("NextPlayer: Setting CurrentBid to 0 for next player %s", nextPlayer.ID)
-
-	if err := h.DB.Save(&auction).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"success": false,
-			"error":   "Failed to update auction",
-		})
-		return
-	}
-
-	// Broadcast next player
-	h.Hub.Broadcast("next_player", gin.H{
+	// Announce the next player to the auction room
+	h.Hub.Publish(websocket.AuctionRoom(auction.ID.String()), "next_player", gin.H{
 		"auction_id":  auction.ID,
-		"player":      nextPlayer,
+		"player":      publicViewer.playerPtr(nextPlayer),
 		"current_bid": auction.CurrentBid,
 	})
+	h.publishOverlay(auction.ID)
+	h.alertWatchers(auction.ID, nextPlayer)
+
+	data := gin.H{
+		"auction": auction,
+		"player":  nextPlayer,
+	}
+	if presenceWarning != nil {
+		data["presence_warning"] = presenceWarning
+	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
-		"data": gin.H{
-			"auction": auction,
-			"player":  nextPlayer,
-		},
+		"data":    data,
 	})
 }
 
-// GetAuctionStatus returns current auction status
+// GetAuctionStatus returns current auction status with its current player and winning team
 func (h *Handlers) GetAuctionStatus(c *gin.Context) {
-	auctionID := c.Param("id")
-	var auction models.Auction
-
-	if err := h.DB.Preload("CurrentPlayer").Preload("WinningTeam").First(&auction, auctionID).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{
+	auctionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
 			"success": false,
-			"error":   "Auction not found",
+			"error":   "Invalid auction ID",
 		})
 		return
 	}
 
+	var response AuctionResponse
+	err = h.Store.Snapshot(func(tx repository.Store) error {
+		auction, err := tx.Auctions().GetByID(auctionID)
+		if err != nil {
+			return fail(http.StatusNotFound, "Auction not found")
+		}
+		response = newAuctionResponse(tx, viewerOf(c), *auction)
+		return nil
+	})
+	if err != nil {
+		respondError(c, err, "Failed to fetch auction status")
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
-		"data":    auction,
+		"data":    response,
 	})
 }
 
@@ -576,8 +683,8 @@ func (h *Handlers) AssignPlayerToAuction(c *gin.Context) {
 		return
 	}
 
-	var auction models.Auction
-	if err := h.DB.First(&auction, parsedAuctionID).Error; err != nil {
+	auction, err := h.Store.Auctions().GetByID(parsedAuctionID)
+	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{
 			"success": false,
 			"error":   "Auction not found",
@@ -585,8 +692,8 @@ func (h *Handlers) AssignPlayerToAuction(c *gin.Context) {
 		return
 	}
 
-	var player models.Player
-	if err := h.DB.First(&player, parsedPlayerID).Error; err != nil {
+	player, err := h.Store.Players().GetByID(parsedPlayerID)
+	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{
 			"success": false,
 			"error":   "Player not found",
@@ -603,7 +710,17 @@ func (h *Handlers) AssignPlayerToAuction(c *gin.Context) {
 		return
 	}
 
+	// Only approved players enter the auction pool
+	if player.RegistrationStatus != models.RegistrationApproved {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Player registration is not approved",
+		})
+		return
+	}
+
 	// Assign player to auction and reactivate if completed
+	before := *auction
 	auction.CurrentPlayerID = &player.ID
 	auction.CurrentBid = 0      // Start with 0 to allow first bid at base price
 	auction.WinningTeamID = nil // Reset winning team
@@ -615,20 +732,25 @@ func (h *Handlers) AssignPlayerToAuction(c *gin.Context) {
 		auction.EndTime = nil // Clear end time
 	}
 
-	if err := h.DB.Save(&auction).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"success": false,
-			"error":   "Failed to assign player to auction",
-		})
+	err = h.Store.Transaction(func(tx repository.Store) error {
+		if err := tx.Auctions().Save(auction); err != nil {
+			return err
+		}
+		return h.recordAudit(tx, c, "auction.player_assigned", "auction", auction.ID, before, auction)
+	})
+	if err != nil {
+		respondError(c, err, "Failed to assign player to auction")
 		return
 	}
 
-	// Broadcast player assignment
-	h.Hub.Broadcast("player_assigned", gin.H{
+	// Announce the assigned player to the auction room
+	h.Hub.Publish(websocket.AuctionRoom(auction.ID.String()), "player_assigned", gin.H{
 		"auction_id":  auction.ID,
-		"player":      player,
+		"player":      publicViewer.playerPtr(player),
 		"current_bid": auction.CurrentBid,
 	})
+	h.publishOverlay(auction.ID)
+	h.alertWatchers(auction.ID, player)
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
@@ -641,9 +763,11 @@ func (h *Handlers) AssignPlayerToAuction(c *gin.Context) {
 
 // GetAvailablePlayers returns all unsold players for manual assignment
 func (h *Handlers) GetAvailablePlayers(c *gin.Context) {
-	var players []models.Player
-
-	if err := h.DB.Where("is_sold = ?", false).Find(&players).Error; err != nil {
+	players, err := h.Store.Players().List(repository.PlayerFilter{
+		Status:             "unsold",
+		RegistrationStatus: models.RegistrationApproved,
+	})
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"success": false,
 			"error":   "Failed to fetch available players",
@@ -657,11 +781,14 @@ func (h *Handlers) GetAvailablePlayers(c *gin.Context) {
 	})
 }
 
-// ApprovePlayer approves a player registration
+// ApprovePlayer records the admin review of a player registration. Rejecting
+// needs a reason, which the player sees, and is refused once the player is on
+// a team or up for bidding.
 func (h *Handlers) ApprovePlayer(c *gin.Context) {
 	var req struct {
 		PlayerID string `json:"player_id" binding:"required"`
 		Approved bool   `json:"approved"`
+		Reason   string `json:"reason"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -672,34 +799,75 @@ func (h *Handlers) ApprovePlayer(c *gin.Context) {
 		return
 	}
 
-	var player models.Player
-	if err := h.DB.First(&player, req.PlayerID).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{
+	playerID, err := uuid.Parse(req.PlayerID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
 			"success": false,
-			"error":   "Player not found",
+			"error":   "Invalid player ID",
 		})
 		return
 	}
 
-	// Update player approval status
-	player.UpdatedAt = time.Now()
-	if err := h.DB.Save(&player).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
+	req.Reason = strings.TrimSpace(req.Reason)
+	if !req.Approved && req.Reason == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
 			"success": false,
-			"error":   "Failed to update player",
+			"error":   "A reason is required to reject a registration",
 		})
 		return
 	}
 
-	// Broadcast player approval
-	h.Hub.Broadcast("player_approved", gin.H{
-		"player_id": player.ID,
-		"approved":  req.Approved,
+	var player *models.Player
+	err = h.Store.Transaction(func(tx repository.Store) error {
+		var err error
+		player, err = tx.Players().GetByID(playerID)
+		if err != nil {
+			return fail(http.StatusNotFound, "Player not found")
+		}
+
+		if !req.Approved {
+			if player.IsSold || player.IsRetained || player.CurrentTeamID != nil {
+				return fail(http.StatusConflict, "Player is already on a team and cannot be rejected")
+			}
+			if auction, err := tx.Auctions().GetActive(); err == nil && auction.CurrentPlayerID != nil && *auction.CurrentPlayerID == player.ID {
+				return fail(http.StatusConflict, "Player is up for bidding and cannot be rejected")
+			}
+		}
+
+		before := *player
+		now := time.Now()
+		player.RegistrationStatus = models.RegistrationRejected
+		action := "player.rejected"
+		if req.Approved {
+			player.RegistrationStatus = models.RegistrationApproved
+			action = "player.approved"
+		}
+		player.ReviewReason = req.Reason
+		player.ReviewedBy = c.GetString("user_id")
+		player.ReviewedAt = &now
+		player.UpdatedAt = now
+
+		if err := tx.Players().Save(player); err != nil {
+			return fail(http.StatusInternalServerError, "Failed to update player")
+		}
+		return h.recordAudit(tx, c, action, "player", player.ID, before, player)
+	})
+	if err != nil {
+		respondError(c, err, "Failed to update player")
+		return
+	}
+
+	// Notify admins of the review
+	h.Hub.Publish(websocket.AdminRoom, "player_approved", gin.H{
+		"player_id":           player.ID,
+		"approved":            req.Approved,
+		"registration_status": player.RegistrationStatus,
+		"reason":              player.ReviewReason,
 	})
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
-		"data":    player,
+		"data":    viewerOf(c).player(*player),
 	})
 }
 
@@ -722,11 +890,14 @@ func (h *Handlers) CreateTeam(c *gin.Context) {
 	team.CreatedAt = time.Now()
 	team.UpdatedAt = time.Now()
 
-	if err := h.DB.Create(&team).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"success": false,
-			"error":   "Failed to create team",
-		})
+	err := h.Store.Transaction(func(tx repository.Store) error {
+		if err := tx.Teams().Create(&team); err != nil {
+			return err
+		}
+		return h.recordAudit(tx, c, "team.created", "team", team.ID, nil, team)
+	})
+	if err != nil {
+		respondError(c, err, "Failed to create team")
 		return
 	}
 
@@ -739,12 +910,20 @@ func (h *Handlers) CreateTeam(c *gin.Context) {
 	})
 }
 
-// UpdateTeamPoints updates team points
+// UpdateTeamPoints sets a team's used points by recording the difference as a
+// ledger adjustment
 func (h *Handlers) UpdateTeamPoints(c *gin.Context) {
-	teamID := c.Param("id")
-	var team models.Team
+	teamID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid team ID",
+		})
+		return
+	}
 
-	if err := h.DB.First(&team, teamID).Error; err != nil {
+	team, err := h.Store.Teams().GetByID(teamID)
+	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{
 			"success": false,
 			"error":   "Team not found",
@@ -753,7 +932,8 @@ func (h *Handlers) UpdateTeamPoints(c *gin.Context) {
 	}
 
 	var req struct {
-		UsedPoints int `json:"used_points"`
+		UsedPoints int    `json:"used_points"`
+		Note       string `json:"note"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -764,10 +944,31 @@ func (h *Handlers) UpdateTeamPoints(c *gin.Context) {
 		return
 	}
 
-	team.UsedPoints = req.UsedPoints
-	team.UpdatedAt = time.Now()
+	before := *team
+	err = h.Store.Transaction(func(tx repository.Store) error {
+		used, err := tx.PointsTransactions().UsedPoints(team.ID)
+		if err != nil {
+			return err
+		}
 
-	if err := h.DB.Save(&team).Error; err != nil {
+		if delta := req.UsedPoints - used; delta != 0 {
+			if err := tx.PointsTransactions().Record(&models.PointsTransaction{
+				TeamID:    team.ID,
+				Type:      ledger.TypeAdjustment,
+				Amount:    delta,
+				Note:      req.Note,
+				CreatedBy: c.GetString("user_id"),
+			}); err != nil {
+				return err
+			}
+		}
+
+		if team, err = tx.Teams().GetByID(team.ID); err != nil {
+			return err
+		}
+		return h.recordAudit(tx, c, "team.points_updated", "team", team.ID, before, team)
+	})
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"success": false,
 			"error":   "Failed to update team points",
@@ -775,8 +976,8 @@ func (h *Handlers) UpdateTeamPoints(c *gin.Context) {
 		return
 	}
 
-	// Broadcast team update
-	h.Hub.Broadcast("team_updated", team)
+	h.Hub.Publish(websocket.TeamRoom(team.ID.String()), "team_updated", team)
+	h.Hub.Publish(websocket.AdminRoom, "team_updated", team)
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
@@ -793,6 +994,7 @@ func (h *Handlers) CreatePlayer(c *gin.Context) {
 		Mobile          string `json:"mobile" binding:"required"`
 		PlayingCategory string `json:"playing_category" binding:"required"`
 		Accomplishments string `json:"accomplishments"`
+		Email           string `json:"email" binding:"omitempty,email"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -813,27 +1015,25 @@ func (h *Handlers) CreatePlayer(c *gin.Context) {
 		return
 	}
 
-	// Create a user for the player
+	// Create a user for the player. Players added by an admin without an email
+	// get a unique placeholder on a reserved domain, so it can never reach anyone.
+	email := req.Email
+	if email == "" {
+		email = fmt.Sprintf("%s.%s@players.invalid",
+			strings.ToLower(strings.ReplaceAll(req.Name, " ", ".")), uuid.NewString()[:8])
+	}
 	user := models.User{
 		Username:  req.Name,
-		Email:     fmt.Sprintf("%s@player.com", strings.ToLower(strings.ReplaceAll(req.Name, " ", "."))),
+		Email:     email,
 		Password:  "player123", // Default password
 		Role:      "player",
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
 
-	if err := h.DB.Create(&user).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"success": false,
-			"error":   "Failed to create user",
-		})
-		return
-	}
-
 	// Create the player
+	now := time.Now()
 	player := models.Player{
-		UserID:          user.ID,
 		Name:            req.Name,
 		Gender:          req.Gender,
 		DateOfBirth:     dob,
@@ -842,15 +1042,31 @@ func (h *Handlers) CreatePlayer(c *gin.Context) {
 		Accomplishments: req.Accomplishments,
 		BasePrice:       200,
 		CurrentPrice:    200,
-		CreatedAt:       time.Now(),
-		UpdatedAt:       time.Now(),
+		// Players entered by an admin need no review
+		RegistrationStatus: models.RegistrationApproved,
+		ReviewedBy:         c.GetString("user_id"),
+		ReviewedAt:         &now,
+		CreatedAt:          now,
+		UpdatedAt:          now,
 	}
 
-	if err := h.DB.Create(&player).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"success": false,
-			"error":   "Failed to create player",
-		})
+	err = h.Store.Transaction(func(tx repository.Store) error {
+		if exists, err := tx.Users().ExistsByEmailOrUsername(user.Email, user.Username); err != nil || exists {
+			return fail(http.StatusConflict, "A user with this name or email already exists")
+		}
+		if err := tx.Users().Create(&user); err != nil {
+			return fail(http.StatusInternalServerError, "Failed to create user")
+		}
+
+		player.UserID = user.ID
+		if err := tx.Players().Create(&player); err != nil {
+			return fail(http.StatusInternalServerError, "Failed to create player")
+		}
+
+		return h.recordAudit(tx, c, "player.created", "player", player.ID, nil, player)
+	})
+	if err != nil {
+		respondError(c, err, "Failed to create player")
 		return
 	}
 
@@ -862,8 +1078,6 @@ func (h *Handlers) CreatePlayer(c *gin.Context) {
 
 // AssignPlayerToTeam assigns a player to a team with specified points
 func (h *Handlers) AssignPlayerToTeam(c *gin.Context) {
-	teamID := c.Param("id")
-
 	var req struct {
 		PlayerID string `json:"player_id" binding:"required"`
 		Points   int    `json:"points" binding:"required,min=1"`
@@ -878,7 +1092,7 @@ func (h *Handlers) AssignPlayerToTeam(c *gin.Context) {
 	}
 
 	// Parse UUIDs
-	parsedTeamID, err := uuid.Parse(teamID)
+	parsedTeamID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"success": false,
@@ -896,95 +1110,94 @@ func (h *Handlers) AssignPlayerToTeam(c *gin.Context) {
 		return
 	}
 
-	// Start a transaction
-	tx := h.DB.Begin()
+	var player *models.Player
+	var team *models.Team
+	err = h.Store.Transaction(func(tx repository.Store) error {
+		// Get the team, locked until the player count and points are updated
+		var err error
+		team, err = tx.Teams().GetByIDForUpdate(parsedTeamID)
+		if err != nil {
+			return fail(http.StatusNotFound, "Team not found")
+		}
 
-	// Get the team
-	var team models.Team
-	if err := tx.First(&team, parsedTeamID).Error; err != nil {
-		tx.Rollback()
-		c.JSON(http.StatusNotFound, gin.H{
-			"success": false,
-			"error":   "Team not found",
-		})
-		return
-	}
+		// Check if team has enough points
+		if team.UsedPoints+req.Points > team.TotalPoints {
+			return fail(http.StatusBadRequest, "Team does not have enough points")
+		}
 
-	// Check if team has enough points
-	if team.UsedPoints+req.Points > team.TotalPoints {
-		tx.Rollback()
-		c.JSON(http.StatusBadRequest, gin.H{
-			"success": false,
-			"error":   "Team does not have enough points",
-		})
-		return
-	}
+		// Get the player
+		player, err = tx.Players().GetByID(parsedPlayerID)
+		if err != nil {
+			return fail(http.StatusNotFound, "Player not found")
+		}
 
-	// Get the player
-	var player models.Player
-	if err := tx.First(&player, parsedPlayerID).Error; err != nil {
-		tx.Rollback()
-		c.JSON(http.StatusNotFound, gin.H{
-			"success": false,
-			"error":   "Player not found",
-		})
-		return
-	}
+		// Check if player is already sold
+		if player.IsSold {
+			return fail(http.StatusBadRequest, "Player is already sold to another team")
+		}
+		if player.RegistrationStatus != models.RegistrationApproved {
+			return fail(http.StatusBadRequest, "Player registration is not approved")
+		}
 
-	// Check if player is already sold
-	if player.IsSold {
-		tx.Rollback()
-		c.JSON(http.StatusBadRequest, gin.H{
-			"success": false,
-			"error":   "Player is already sold to another team",
-		})
-		return
-	}
+		// Update player
+		playerBefore := *player
+		teamBefore := *team
+		player.IsSold = true
+		player.CurrentPrice = req.Points
+		player.CurrentTeamID = &team.ID
+		player.UpdatedAt = time.Now()
 
-	// Update player
-	player.IsSold = true
-	player.CurrentPrice = req.Points
-	player.CurrentTeamID = &team.ID
-	player.UpdatedAt = time.Now()
+		if err := tx.Players().Save(player); err != nil {
+			return fail(http.StatusInternalServerError, "Failed to update player")
+		}
 
-	if err := tx.Save(&player).Error; err != nil {
-		tx.Rollback()
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"success": false,
-			"error":   "Failed to update player",
-		})
-		return
-	}
+		// Update team
+		team.PlayerCount += 1
+		team.UpdatedAt = time.Now()
 
-	// Update team
-	team.UsedPoints += req.Points
-	team.PlayerCount += 1
-	team.UpdatedAt = time.Now()
+		if err := tx.Teams().Save(team); err != nil {
+			return fail(http.StatusInternalServerError, "Failed to update team")
+		}
 
-	if err := tx.Save(&team).Error; err != nil {
-		tx.Rollback()
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"success": false,
-			"error":   "Failed to update team",
-		})
-		return
-	}
+		// Record the purchase in the points ledger
+		if err := tx.PointsTransactions().Record(&models.PointsTransaction{
+			TeamID:    team.ID,
+			PlayerID:  &player.ID,
+			Type:      ledger.TypePurchase,
+			Amount:    req.Points,
+			CreatedBy: c.GetString("user_id"),
+		}); err != nil {
+			return fail(http.StatusInternalServerError, "Failed to update team points")
+		}
+		if team, err = tx.Teams().GetByID(team.ID); err != nil {
+			return fail(http.StatusInternalServerError, "Failed to update team points")
+		}
 
-	// Commit transaction
-	if err := tx.Commit().Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"success": false,
-			"error":   "Failed to commit transaction",
-		})
+		if err := h.recordAudit(tx, c, "team.player_assigned", "player", player.ID, gin.H{
+			"player": playerBefore,
+			"team":   teamBefore,
+		}, gin.H{
+			"player": player,
+			"team":   team,
+		}); err != nil {
+			return fail(http.StatusInternalServerError, "Failed to record audit event")
+		}
+		return nil
+	})
+	if err != nil {
+		respondError(c, err, "Failed to commit transaction")
 		return
 	}
 
-	// Broadcast update via WebSocket
-	h.Hub.Broadcast("player_assigned", gin.H{
+	// Notify the team and admins of the assignment
+	assignment := gin.H{
 		"player_id": player.ID,
 		"team_id":   team.ID,
 		"points":    req.Points,
-	})
+	}
+	h.Hub.Publish(websocket.TeamRoom(team.ID.String()), "player_assigned", assignment)
+	h.Hub.Publish(websocket.AdminRoom, "player_assigned", assignment)
+	h.Hub.Publish(websocket.TeamRoom(team.ID.String()), "team_updated", team)
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
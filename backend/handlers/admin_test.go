@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"bytes"
+	"net/http"
+	"sync"
+	"testing"
+
+	"auction-backend/ledger"
+	"auction-backend/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (s *testServer) nextPlayer(auction *models.Auction) (int, map[string]interface{}) {
+	return s.do(testAdmin, http.MethodPost, "/auctions/:id/next-player", "/auctions/"+auction.ID.String()+"/next-player", s.h.NextPlayer, nil)
+}
+
+func (s *testServer) assignPlayer(team *models.Team, player *models.Player, points int) (int, map[string]interface{}) {
+	return s.do(testAdmin, http.MethodPost, "/teams/:id/assign-player", "/teams/"+team.ID.String()+"/assign-player", s.h.AssignPlayerToTeam, gin.H{
+		"player_id": player.ID,
+		"points":    points,
+	})
+}
+
+func TestNextPlayerSellsToWinningBid(t *testing.T) {
+	s := newTestServer(t)
+	team := s.team("Smashers", 12000)
+	sold := s.player("asha")
+	next := s.player("bina")
+	// Lots within a category go in ID order, so the lower ID is up first
+	if bytes.Compare(sold.ID[:], next.ID[:]) > 0 {
+		sold, next = next, sold
+	}
+	auction := s.activeAuction(sold)
+
+	if status, body := s.bid(teamIdentity(team), auction.ID.String(), 900); status != http.StatusCreated {
+		t.Fatalf("bid: status %d, body %v", status, body)
+	}
+	status, body := s.nextPlayer(auction)
+	if status != http.StatusOK {
+		t.Fatalf("next player: status %d, body %v", status, body)
+	}
+
+	player := s.reloadPlayer(sold.ID)
+	if !player.IsSold || player.CurrentTeamID == nil || *player.CurrentTeamID != team.ID || player.CurrentPrice != 900 {
+		t.Fatalf("sold player: sold %v, team %v, price %d", player.IsSold, player.CurrentTeamID, player.CurrentPrice)
+	}
+	got := s.reloadTeam(team.ID)
+	if got.UsedPoints != 900 || got.PlayerCount != 1 {
+		t.Fatalf("team after sale: used %d, players %d, want 900 and 1", got.UsedPoints, got.PlayerCount)
+	}
+	entries, err := s.store.PointsTransactions().ListByTeam(team.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Type != ledger.TypePurchase || entries[0].Amount != 900 {
+		t.Fatalf("ledger = %+v, want one purchase of 900", entries)
+	}
+
+	lot := s.reloadAuction(auction.ID)
+	if lot.CurrentPlayerID == nil || *lot.CurrentPlayerID != next.ID || lot.CurrentBid != 0 || lot.WinningTeamID != nil {
+		t.Fatalf("next lot: player %v, bid %d, winner %v", lot.CurrentPlayerID, lot.CurrentBid, lot.WinningTeamID)
+	}
+}
+
+func TestNextPlayerSellsLotOnce(t *testing.T) {
+	s := newTestServer(t)
+	team := s.team("Smashers", 12000)
+	auction := s.activeAuction(s.player("asha"))
+	s.player("bina")
+	s.player("chitra")
+
+	if status, body := s.bid(teamIdentity(team), auction.ID.String(), 900); status != http.StatusCreated {
+		t.Fatalf("bid: status %d, body %v", status, body)
+	}
+
+	// Several admins moving on at once must not both sell the lot
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.nextPlayer(auction)
+		}()
+	}
+	wg.Wait()
+
+	got := s.reloadTeam(team.ID)
+	if got.UsedPoints != 900 || got.PlayerCount != 1 {
+		t.Fatalf("team after concurrent next player: used %d, players %d, want 900 and 1", got.UsedPoints, got.PlayerCount)
+	}
+}
+
+func TestNextPlayerWithoutBidsLeavesPlayerUnsold(t *testing.T) {
+	s := newTestServer(t)
+	unsold := s.player("asha")
+	auction := s.activeAuction(unsold)
+
+	status, body := s.nextPlayer(auction)
+	if status != http.StatusOK {
+		t.Fatalf("next player: status %d, body %v", status, body)
+	}
+	if player := s.reloadPlayer(unsold.ID); player.IsSold || player.CurrentTeamID != nil {
+		t.Fatalf("player without bids was sold to %v", player.CurrentTeamID)
+	}
+
+	// asha is the only player and is skipped as the current lot
+	data := body["data"].(map[string]interface{})
+	if data["no_more_players"] != true {
+		t.Fatalf("data = %v, want no_more_players", data)
+	}
+	if lot := s.reloadAuction(auction.ID); lot.CurrentPlayerID != nil {
+		t.Fatalf("current player = %v, want none", *lot.CurrentPlayerID)
+	}
+}
+
+func TestAssignPlayerToTeam(t *testing.T) {
+	s := newTestServer(t)
+	team := s.team("Smashers", 12000)
+	player := s.player("asha")
+
+	status, body := s.assignPlayer(team, player, 1500)
+	if status != http.StatusOK {
+		t.Fatalf("assign: status %d, body %v", status, body)
+	}
+
+	got := s.reloadTeam(team.ID)
+	if got.UsedPoints != 1500 || got.PlayerCount != 1 {
+		t.Fatalf("team after assignment: used %d, players %d, want 1500 and 1", got.UsedPoints, got.PlayerCount)
+	}
+	if p := s.reloadPlayer(player.ID); !p.IsSold || *p.CurrentTeamID != team.ID || p.CurrentPrice != 1500 {
+		t.Fatalf("assigned player: sold %v, team %v, price %d", p.IsSold, p.CurrentTeamID, p.CurrentPrice)
+	}
+	if actions := s.auditActions(); len(actions) != 1 || actions[0] != "team.player_assigned" {
+		t.Fatalf("audit actions = %v, want team.player_assigned", actions)
+	}
+
+	// The same player cannot be assigned twice
+	other := s.team("Drop Shots", 12000)
+	if status, _ := s.assignPlayer(other, player, 200); status != http.StatusBadRequest {
+		t.Fatalf("second assignment: status %d, want 400", status)
+	}
+}
+
+func TestAssignPlayerToTeamRefusesUnaffordablePrice(t *testing.T) {
+	s := newTestServer(t)
+	team := s.team("Smashers", 1000)
+	player := s.player("asha")
+
+	if status, _ := s.assignPlayer(team, player, 1200); status != http.StatusBadRequest {
+		t.Fatalf("unaffordable assignment: status %d, want 400", status)
+	}
+	if got := s.reloadTeam(team.ID); got.UsedPoints != 0 || got.PlayerCount != 0 {
+		t.Fatalf("team changed: used %d, players %d", got.UsedPoints, got.PlayerCount)
+	}
+	if p := s.reloadPlayer(player.ID); p.IsSold {
+		t.Fatal("player was sold by a refused assignment")
+	}
+	if actions := s.auditActions(); len(actions) != 0 {
+		t.Fatalf("audit actions = %v, want none", actions)
+	}
+}
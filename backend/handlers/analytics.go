@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"auction-backend/analytics"
+
+	"github.com/gin-gonic/gin"
+)
+
+// sendAnalytics writes a report, or a 500 naming what failed
+func sendAnalytics(c *gin.Context, data interface{}, err error, what string) {
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   "Failed to fetch " + what,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    data,
+	})
+}
+
+// GetCategoryPrices returns the average, median and highest price per player category
+func (h *Handlers) GetCategoryPrices(c *gin.Context) {
+	prices, err := analytics.CategoryPrices(h.DB)
+	sendAnalytics(c, prices, err, "category prices")
+}
+
+// GetSpendCurves returns each team's running spend over time
+func (h *Handlers) GetSpendCurves(c *gin.Context) {
+	curves, err := analytics.SpendCurves(h.DB)
+	sendAnalytics(c, curves, err, "spend curves")
+}
+
+// GetContestedLots returns the lots with the most teams and bids, 10 by default
+func (h *Handlers) GetContestedLots(c *gin.Context) {
+	limit := 10
+	if l, err := strconv.Atoi(c.Query("limit")); err == nil && l > 0 && l <= 100 {
+		limit = l
+	}
+
+	lots, err := analytics.ContestedLots(h.DB, limit)
+	sendAnalytics(c, lots, err, "contested lots")
+}
+
+// GetLotTimes returns how long each lot took from opening to sale
+func (h *Handlers) GetLotTimes(c *gin.Context) {
+	lots, err := analytics.LotTimes(h.DB)
+	sendAnalytics(c, lots, err, "lot times")
+}
+
+// GetBudgetEfficiency ranks teams by squad market value per point spent
+func (h *Handlers) GetBudgetEfficiency(c *gin.Context) {
+	rankings, err := analytics.BudgetEfficiency(h.DB)
+	sendAnalytics(c, rankings, err, "budget efficiency")
+}
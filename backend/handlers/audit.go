@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"auction-backend/models"
+	"auction-backend/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+// auditActor identifies who made a change and from which request
+type auditActor struct {
+	ID        string
+	Role      string
+	RequestID string
+	IPAddress string
+}
+
+// requestActor returns the authenticated caller of an HTTP request
+func requestActor(c *gin.Context) auditActor {
+	return auditActor{
+		ID:        c.GetString("user_id"),
+		Role:      c.GetString("user_role"),
+		RequestID: c.GetString("request_id"),
+		IPAddress: c.ClientIP(),
+	}
+}
+
+// recordAudit appends an audit event for a state-changing action. Pass the
+// transaction's store when the change is transactional so both commit together.
+func (h *Handlers) recordAudit(store repository.Store, c *gin.Context, action, entityType string, entityID interface{}, before, after interface{}) error {
+	return h.recordAuditBy(store, requestActor(c), action, entityType, entityID, before, after)
+}
+
+// recordAuditBy appends an audit event for a change made outside an HTTP request
+func (h *Handlers) recordAuditBy(store repository.Store, actor auditActor, action, entityType string, entityID interface{}, before, after interface{}) error {
+	event := models.AuditEvent{
+		ActorID:    actor.ID,
+		ActorRole:  actor.Role,
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   fmt.Sprint(entityID),
+		Before:     auditJSON(before),
+		After:      auditJSON(after),
+		RequestID:  actor.RequestID,
+		IPAddress:  actor.IPAddress,
+		CreatedAt:  time.Now(),
+	}
+
+	if err := store.AuditEvents().Create(&event); err != nil {
+		log.Printf("Failed to record audit event %s for %s %s: %v", action, entityType, event.EntityID, err)
+		return err
+	}
+	return nil
+}
+
+// auditJSON serializes an audit snapshot, leaving nil snapshots empty
+func auditJSON(v interface{}) models.JSON {
+	if v == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		log.Printf("Failed to marshal audit snapshot: %v", err)
+		return nil
+	}
+	return models.JSON(data)
+}
+
+// GetAuditEvents returns audit events matching the given filters, newest first
+func (h *Handlers) GetAuditEvents(c *gin.Context) {
+	filter := repository.AuditEventFilter{
+		ActorID:    c.Query("actor_id"),
+		ActorRole:  c.Query("actor_role"),
+		Action:     c.Query("action"),
+		EntityType: c.Query("entity_type"),
+		EntityID:   c.Query("entity_id"),
+		RequestID:  c.Query("request_id"),
+		Limit:      100,
+	}
+
+	if from := c.Query("from"); from != "" {
+		fromTime, err := time.Parse(time.RFC3339, from)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"success": false,
+				"error":   "Invalid from time, expected RFC3339",
+			})
+			return
+		}
+		filter.From = &fromTime
+	}
+
+	if to := c.Query("to"); to != "" {
+		toTime, err := time.Parse(time.RFC3339, to)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"success": false,
+				"error":   "Invalid to time, expected RFC3339",
+			})
+			return
+		}
+		filter.To = &toTime
+	}
+
+	if l, err := strconv.Atoi(c.Query("limit")); err == nil && l > 0 && l <= 1000 {
+		filter.Limit = l
+	}
+	if o, err := strconv.Atoi(c.Query("offset")); err == nil && o > 0 {
+		filter.Offset = o
+	}
+
+	events, total, err := h.Store.AuditEvents().List(filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   "Failed to fetch audit events",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    events,
+		"total":   total,
+	})
+}
@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"auction-backend/models"
+	"auction-backend/repository"
+)
+
+// failingAudit is a store whose transactions cannot write audit events
+type failingAudit struct {
+	repository.Store
+}
+
+func (s failingAudit) AuditEvents() repository.AuditEventRepository {
+	return failingAuditEvents{s.Store.AuditEvents()}
+}
+
+func (s failingAudit) Transaction(fn func(tx repository.Store) error) error {
+	return s.Store.Transaction(func(tx repository.Store) error {
+		return fn(failingAudit{tx})
+	})
+}
+
+type failingAuditEvents struct {
+	repository.AuditEventRepository
+}
+
+func (failingAuditEvents) Create(*models.AuditEvent) error {
+	return errors.New("audit log unavailable")
+}
+
+func TestStartAuctionIsAudited(t *testing.T) {
+	s := newTestServer(t)
+	player := s.player("asha")
+	auction := &models.Auction{Title: "Main", Status: "pending", StartTime: time.Now()}
+	if err := s.store.Auctions().Create(auction); err != nil {
+		t.Fatal(err)
+	}
+
+	status, body := s.do(testAdmin, http.MethodPost, "/auctions/:id/start", "/auctions/"+auction.ID.String()+"/start", s.h.StartAuction, nil)
+	if status != http.StatusOK {
+		t.Fatalf("start: status %d, body %v", status, body)
+	}
+	if got := s.reloadAuction(auction.ID); got.Status != "active" || got.CurrentPlayerID == nil || *got.CurrentPlayerID != player.ID {
+		t.Fatalf("auction after start: status %s, player %v", got.Status, got.CurrentPlayerID)
+	}
+	if actions := s.auditActions(); len(actions) != 1 || actions[0] != "auction.started" {
+		t.Fatalf("audit actions = %v, want auction.started", actions)
+	}
+}
+
+func TestChangeFailsWhenItCannotBeAudited(t *testing.T) {
+	s := newTestServer(t)
+	auction := &models.Auction{Title: "Main", Status: "pending", StartTime: time.Now()}
+	if err := s.store.Auctions().Create(auction); err != nil {
+		t.Fatal(err)
+	}
+	s.player("asha")
+	s.h.Store = failingAudit{s.store}
+
+	status, _ := s.do(testAdmin, http.MethodPost, "/auctions/:id/start", "/auctions/"+auction.ID.String()+"/start", s.h.StartAuction, nil)
+	if status != http.StatusInternalServerError {
+		t.Fatalf("start: status %d, want 500", status)
+	}
+	if got := s.reloadAuction(auction.ID); got.Status != "pending" || got.CurrentPlayerID != nil {
+		t.Fatalf("unaudited start was kept: status %s, player %v", got.Status, got.CurrentPlayerID)
+	}
+
+	status, _ = s.do(testAdmin, http.MethodPost, "/teams/create", "/teams/create", s.h.CreateTeam, map[string]string{"name": "Smashers"})
+	if status != http.StatusInternalServerError {
+		t.Fatalf("create team: status %d, want 500", status)
+	}
+	if teams, err := s.store.Teams().ListWithPlayers(); err != nil || len(teams) != 0 {
+		t.Fatalf("unaudited team was kept: %v %v", teams, err)
+	}
+}
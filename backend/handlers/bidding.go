@@ -0,0 +1,244 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"auction-backend/models"
+	"auction-backend/repository"
+	"auction-backend/websocket"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// Reason codes for rejected bids, returned as "code" over HTTP and "reason" in a WebSocket nack
+const (
+	reasonInvalidRequest     = "invalid_request"
+	reasonNotATeam           = "not_a_team"
+	reasonAuctionNotFound    = "auction_not_found"
+	reasonAuctionNotActive   = "auction_not_active"
+	reasonNoCurrentPlayer    = "no_current_player"
+	reasonBidTooLow          = "bid_too_low"
+	reasonTeamNotFound       = "team_not_found"
+	reasonInsufficientPoints = "insufficient_points"
+	reasonExceedsMaxSafeBid  = "exceeds_max_safe_bid"
+	reasonAlreadyWinning     = "already_winning"
+	reasonNoReplacementSlot  = "no_replacement_slot"
+	reasonInternalError      = "internal_error"
+)
+
+const (
+	// minPlayersRequired is the roster size a team must still be able to afford
+	minPlayersRequired = 12
+	// basePricePerPlayer is the lowest price a player can go for, and the lowest first bid
+	basePricePerPlayer = 200
+)
+
+// bidRequest is a team's bid on an auction's current player
+type bidRequest struct {
+	AuctionID uuid.UUID
+	TeamID    string
+	Amount    int
+	Actor     auditActor
+}
+
+// placeBid validates and records a bid, then announces it to the auction room.
+// The checks run inside the transaction against the locked auction, so
+// concurrent bids are validated one after another. Rejections are
+// requestErrors carrying a reason code.
+func (h *Handlers) placeBid(req bidRequest) (*models.Bid, error) {
+	var bid models.Bid
+	var team *models.Team
+	var auction *models.Auction
+	var budget teamBudget
+
+	err := h.Store.Transaction(func(tx repository.Store) error {
+		var err error
+		auction, err = tx.Auctions().GetByIDForUpdate(req.AuctionID)
+		if err != nil {
+			return failCode(http.StatusNotFound, reasonAuctionNotFound, "Auction not found")
+		}
+
+		teamUUID, err := uuid.Parse(req.TeamID)
+		if err != nil {
+			return failCode(http.StatusBadRequest, reasonTeamNotFound, "Invalid team ID")
+		}
+		team, err = tx.Teams().GetByID(teamUUID)
+		if err != nil {
+			return failCode(http.StatusNotFound, reasonTeamNotFound, "Team not found")
+		}
+
+		budget = budgetOf(team)
+		if err := checkBid(tx, auction, team, req.Amount); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		bid = models.Bid{
+			AuctionID: auction.ID,
+			PlayerID:  *auction.CurrentPlayerID,
+			TeamID:    team.ID,
+			Amount:    req.Amount,
+			IsWinning: true,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+
+		auctionBefore := *auction
+		auction.CurrentBid = req.Amount
+		auction.WinningTeamID = &team.ID
+		auction.UpdatedAt = now
+
+		// Set all previous bids for this auction to not winning
+		if err := tx.Bids().ClearWinning(auction.ID); err != nil {
+			return failCode(http.StatusInternalServerError, reasonInternalError, "Failed to update bids")
+		}
+		if err := tx.Bids().Create(&bid); err != nil {
+			return failCode(http.StatusInternalServerError, reasonInternalError, "Failed to create bid")
+		}
+		if err := tx.Auctions().Save(auction); err != nil {
+			return failCode(http.StatusInternalServerError, reasonInternalError, "Failed to update auction")
+		}
+
+		if err := h.recordAuditBy(tx, req.Actor, "bid.created", "auction", auction.ID, auctionBefore, gin.H{
+			"auction": auction,
+			"bid":     bid,
+		}); err != nil {
+			return failCode(http.StatusInternalServerError, reasonInternalError, "Failed to record audit event")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Announce the bid to the auction room, naming the team without its budget
+	h.Hub.Publish(websocket.AuctionRoom(auction.ID.String()), "new_bid", gin.H{
+		"auction_id": auction.ID,
+		"bid":        bid,
+		"team": gin.H{
+			"id":   team.ID,
+			"name": team.Name,
+		},
+		"current_bid": auction.CurrentBid,
+	})
+
+	h.publishOverlay(auction.ID)
+
+	// Warn the bidding team when this bid leaves less than one base price above
+	// the points it must keep for its remaining minimum roster
+	if remainingPlayersNeeded := budget.playersNeeded(); remainingPlayersNeeded > 0 {
+		headroom := budget.maxBid() - req.Amount
+		if headroom < basePricePerPlayer {
+			h.Hub.Publish(websocket.TeamRoom(team.ID.String()), "budget_warning", gin.H{
+				"auction_id":       auction.ID,
+				"remaining_points": budget.RemainingPoints - req.Amount,
+				"players_needed":   remainingPlayersNeeded,
+				"reserved_points":  budget.reserved(),
+				"headroom":         headroom,
+			})
+		}
+	}
+
+	return &bid, nil
+}
+
+// checkBid applies the rules a team's bid of amount on the auction's current
+// lot must pass, returning a requestError with the reason placeBid reports.
+// The budget plan calls it too, so it shows the same verdict the bid would get.
+func checkBid(store repository.Store, auction *models.Auction, team *models.Team, amount int) error {
+	if auction.Status != "active" {
+		return failCode(http.StatusBadRequest, reasonAuctionNotActive, "Auction is not active")
+	}
+	if auction.CurrentPlayerID == nil {
+		return failCode(http.StatusBadRequest, reasonNoCurrentPlayer, "No player is up for bidding")
+	}
+
+	// The first bid may be at the base price; later bids must beat the current one
+	if amount < minimumBid(auction) {
+		if auction.CurrentBid == 0 {
+			return failCode(http.StatusBadRequest, reasonBidTooLow, "First bid must be at least ₹200")
+		}
+		return failCode(http.StatusBadRequest, reasonBidTooLow, "Bid must be higher than current bid")
+	}
+
+	// The bid must leave enough points to fill the rest of the minimum roster
+	// at base price, not counting the player being bid on
+	if err := budgetOf(team).check(amount); err != nil {
+		return err
+	}
+
+	// Supplementary auctions only sign replacements for released players
+	if auction.Supplementary {
+		open, err := store.Releases().ListOpen(team.ID)
+		if err != nil {
+			return failCode(http.StatusInternalServerError, reasonInternalError, "Failed to load replacement slots")
+		}
+		if len(open) == 0 {
+			return failCode(http.StatusBadRequest, reasonNoReplacementSlot, "Only teams with an open replacement slot can bid in a supplementary auction")
+		}
+	}
+
+	if auction.WinningTeamID != nil && *auction.WinningTeamID == team.ID {
+		return failCode(http.StatusBadRequest, reasonAlreadyWinning, "You are already winning the current bid. Another team must bid first.")
+	}
+	return nil
+}
+
+// handlePlaceBid places a bid for the socket's team:
+// {"type":"place_bid","request_id":"b-17","amount":1200}. auction_id may be
+// given and defaults to the active auction. The ack's result is the bid.
+func (h *Handlers) handlePlaceBid(client *websocket.Client, message json.RawMessage) (interface{}, error) {
+	if client.Role != "team" || client.TeamID == "" {
+		return nil, websocket.Nack(reasonNotATeam, "Only teams can bid")
+	}
+
+	var req struct {
+		RequestID json.RawMessage `json:"request_id"`
+		AuctionID string          `json:"auction_id"`
+		Amount    int             `json:"amount"`
+	}
+	if err := json.Unmarshal(message, &req); err != nil || req.Amount <= 0 {
+		return nil, websocket.Nack(reasonInvalidRequest, "Invalid request data")
+	}
+
+	var auctionID uuid.UUID
+	if req.AuctionID != "" {
+		id, err := uuid.Parse(req.AuctionID)
+		if err != nil {
+			return nil, websocket.Nack(reasonInvalidRequest, "Invalid auction ID")
+		}
+		auctionID = id
+	} else {
+		auction, err := h.Store.Auctions().GetActive()
+		if err != nil {
+			return nil, websocket.Nack(reasonAuctionNotActive, "No auction is active")
+		}
+		auctionID = auction.ID
+	}
+
+	bid, err := h.placeBid(bidRequest{
+		AuctionID: auctionID,
+		TeamID:    client.TeamID,
+		Amount:    req.Amount,
+		Actor: auditActor{
+			ID:        client.UserID,
+			Role:      client.Role,
+			RequestID: string(req.RequestID),
+			IPAddress: client.RemoteAddr(),
+		},
+	})
+	if err != nil {
+		var reqErr *requestError
+		if errors.As(err, &reqErr) {
+			return nil, websocket.Nack(reqErr.code, reqErr.message)
+		}
+		log.Printf("place_bid failed for team %s: %v", client.TeamID, err)
+		return nil, websocket.Nack(reasonInternalError, "Failed to create bid")
+	}
+	return bid, nil
+}
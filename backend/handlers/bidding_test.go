@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"net/http"
+	"testing"
+)
+
+func (s *testServer) bid(who identity, auctionID string, amount int) (int, map[string]interface{}) {
+	return s.do(who, http.MethodPost, "/auctions/:id/bid", "/auctions/"+auctionID+"/bid", s.h.CreateBid, map[string]int{"amount": amount})
+}
+
+func TestCreateBid(t *testing.T) {
+	s := newTestServer(t)
+	teamA := s.team("Smashers", 12000)
+	teamB := s.team("Drop Shots", 12000)
+	auction := s.activeAuction(s.player("asha"))
+
+	status, body := s.bid(teamIdentity(teamA), auction.ID.String(), 500)
+	if status != http.StatusCreated {
+		t.Fatalf("first bid: status %d, body %v", status, body)
+	}
+
+	got := s.reloadAuction(auction.ID)
+	if got.CurrentBid != 500 || got.WinningTeamID == nil || *got.WinningTeamID != teamA.ID {
+		t.Fatalf("auction after bid: current bid %d, winner %v", got.CurrentBid, got.WinningTeamID)
+	}
+
+	status, body = s.bid(teamIdentity(teamB), auction.ID.String(), 600)
+	if status != http.StatusCreated {
+		t.Fatalf("outbid: status %d, body %v", status, body)
+	}
+	if got := s.reloadAuction(auction.ID); *got.WinningTeamID != teamB.ID {
+		t.Fatalf("winner after outbid = %v, want %v", *got.WinningTeamID, teamB.ID)
+	}
+
+	if actions := s.auditActions(); len(actions) != 2 || actions[0] != "bid.created" {
+		t.Fatalf("audit actions = %v, want two bid.created", actions)
+	}
+}
+
+func TestCreateBidRejections(t *testing.T) {
+	s := newTestServer(t)
+	rich := s.team("Smashers", 12000)
+	poor := s.team("Net Rushers", 2500)
+	auction := s.activeAuction(s.player("asha"))
+
+	if status, body := s.bid(teamIdentity(rich), auction.ID.String(), 700); status != http.StatusCreated {
+		t.Fatalf("opening bid: status %d, body %v", status, body)
+	}
+
+	tests := []struct {
+		name   string
+		who    identity
+		amount int
+		status int
+		code   string
+	}{
+		{"not above current bid", teamIdentity(poor), 700, http.StatusBadRequest, reasonBidTooLow},
+		{"already winning", teamIdentity(rich), 800, http.StatusBadRequest, reasonAlreadyWinning},
+		{"more than remaining points", teamIdentity(poor), 2600, http.StatusBadRequest, reasonInsufficientPoints},
+		// 2500 points must keep 200 for each of the 11 other players still needed
+		{"leaves too little for the minimum roster", teamIdentity(poor), 800, http.StatusBadRequest, reasonExceedsMaxSafeBid},
+		{"no team on the token", testAdmin, 900, http.StatusUnauthorized, reasonNotATeam},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, body := s.bid(tt.who, auction.ID.String(), tt.amount)
+			if status != tt.status || body["code"] != tt.code {
+				t.Fatalf("status %d code %v, want %d %s (body %v)", status, body["code"], tt.status, tt.code, body)
+			}
+		})
+	}
+
+	if got := s.reloadAuction(auction.ID); got.CurrentBid != 700 || *got.WinningTeamID != rich.ID {
+		t.Fatalf("rejected bids changed the auction: current bid %d, winner %v", got.CurrentBid, *got.WinningTeamID)
+	}
+}
+
+func TestCreateBidInactiveAuction(t *testing.T) {
+	s := newTestServer(t)
+	team := s.team("Smashers", 12000)
+	auction := s.activeAuction(s.player("asha"))
+	auction.Status = "completed"
+	if err := s.store.Auctions().Save(auction); err != nil {
+		t.Fatal(err)
+	}
+
+	status, body := s.bid(teamIdentity(team), auction.ID.String(), 500)
+	if status != http.StatusBadRequest || body["code"] != reasonAuctionNotActive {
+		t.Fatalf("status %d code %v, want 400 %s", status, body["code"], reasonAuctionNotActive)
+	}
+}
+
+func TestBudgetPlanMatchesSupplementaryBidRule(t *testing.T) {
+	s := newTestServer(t)
+	team := s.team("Smashers", 12000)
+	auction := s.activeAuction(s.player("asha"))
+	auction.Supplementary = true
+	if err := s.store.Auctions().Save(auction); err != nil {
+		t.Fatal(err)
+	}
+
+	// Without a released player the team has no slot to fill, so the plan
+	// must not offer the bid the server would refuse
+	status, body := s.do(teamIdentity(team), http.MethodGet, "/team/budget/plan", "/team/budget/plan", s.h.GetBudgetPlan, nil)
+	if status != http.StatusOK {
+		t.Fatalf("plan: status %d, body %v", status, body)
+	}
+	lot := body["data"].(map[string]interface{})["current_lot"].(map[string]interface{})
+	if lot["can_bid"] != false || lot["reason"] != reasonNoReplacementSlot {
+		t.Fatalf("current lot = %v, want can_bid false with %s", lot, reasonNoReplacementSlot)
+	}
+
+	if status, body := s.bid(teamIdentity(team), auction.ID.String(), 200); status != http.StatusBadRequest || body["code"] != reasonNoReplacementSlot {
+		t.Fatalf("bid: status %d code %v, want 400 %s", status, body["code"], reasonNoReplacementSlot)
+	}
+}
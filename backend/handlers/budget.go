@@ -0,0 +1,210 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"auction-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// teamBudget is what a team can still spend under the bidding rules. placeBid
+// validates bids with it, and the budget plan projects it forward, so a plan
+// never promises a bid that would be refused.
+type teamBudget struct {
+	RemainingPoints int
+	PlayerCount     int
+}
+
+// budgetOf returns the team's current budget
+func budgetOf(team *models.Team) teamBudget {
+	return teamBudget{
+		RemainingPoints: team.TotalPoints - team.UsedPoints,
+		PlayerCount:     team.PlayerCount,
+	}
+}
+
+// playersNeeded counts the minimum roster places still to fill after the
+// player being bid on
+func (b teamBudget) playersNeeded() int {
+	return max(minPlayersRequired-b.PlayerCount-1, 0)
+}
+
+// reserved is the points kept back to fill those places at base price
+func (b teamBudget) reserved() int {
+	return b.playersNeeded() * basePricePerPlayer
+}
+
+// maxBid is the highest bid the team may place
+func (b teamBudget) maxBid() int {
+	return b.RemainingPoints - b.reserved()
+}
+
+// check rejects a bid the team cannot afford, with the reason code placeBid reports
+func (b teamBudget) check(amount int) error {
+	if amount > b.RemainingPoints {
+		return failCode(http.StatusBadRequest, reasonInsufficientPoints, "Insufficient points")
+	}
+	if amount > b.maxBid() {
+		return failCode(http.StatusBadRequest, reasonExceedsMaxSafeBid, fmt.Sprintf(
+			"Bid too high! You need at least %d points for %d more players. Max safe bid: %d",
+			b.reserved(), b.playersNeeded(), b.maxBid()))
+	}
+	return nil
+}
+
+// buy returns the budget after winning a player at price
+func (b teamBudget) buy(price int) teamBudget {
+	return teamBudget{
+		RemainingPoints: b.RemainingPoints - price,
+		PlayerCount:     b.PlayerCount + 1,
+	}
+}
+
+// minimumBid is the lowest bid the auction will accept next
+func minimumBid(auction *models.Auction) int {
+	if auction.CurrentBid == 0 {
+		return basePricePerPlayer
+	}
+	return auction.CurrentBid + 1
+}
+
+// BudgetProjection is the budget after one what-if purchase
+type BudgetProjection struct {
+	Price           int    `json:"price"`
+	Allowed         bool   `json:"allowed"`
+	Reason          string `json:"reason,omitempty"`
+	Error           string `json:"error,omitempty"`
+	RemainingPoints int    `json:"remaining_points"`
+	PlayerCount     int    `json:"player_count"`
+	ReservedPoints  int    `json:"reserved_points"`
+	MaxBid          int    `json:"max_bid"`
+}
+
+// CurrentLot is the team's position on the player now up for bidding
+type CurrentLot struct {
+	AuctionID  uuid.UUID `json:"auction_id"`
+	PlayerID   uuid.UUID `json:"player_id"`
+	CurrentBid int       `json:"current_bid"`
+	MinimumBid int       `json:"minimum_bid"`
+	Winning    bool      `json:"winning"`
+	CanBid     bool      `json:"can_bid"`
+	// Reason is the code a minimum bid would be refused with, when CanBid is false
+	Reason string `json:"reason,omitempty"`
+}
+
+// BudgetPlan is a team's bidding headroom now and after what-if purchases
+type BudgetPlan struct {
+	TotalPoints     int `json:"total_points"`
+	UsedPoints      int `json:"used_points"`
+	RemainingPoints int `json:"remaining_points"`
+	PlayerCount     int `json:"player_count"`
+	MinPlayers      int `json:"min_players"`
+	// UnfilledSlots are the minimum roster places left after the player being bid on
+	UnfilledSlots   int                `json:"unfilled_slots"`
+	ReservedPerSlot int                `json:"reserved_per_slot"`
+	ReservedPoints  int                `json:"reserved_points"`
+	MaxBid          int                `json:"max_bid"`
+	CurrentLot      *CurrentLot        `json:"current_lot"`
+	Projections     []BudgetProjection `json:"projections"`
+}
+
+// GetBudgetPlan returns the team's maximum legal bid and the points reserved
+// for its unfilled roster places. Each what_if price (repeatable, or comma
+// separated) is a purchase applied in order, showing the maximum bid for the
+// next lot; projection stops at the first purchase the bid rules would refuse.
+func (h *Handlers) GetBudgetPlan(c *gin.Context) {
+	teamUUID, err := uuid.Parse(c.GetString("team_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid team ID",
+		})
+		return
+	}
+
+	var prices []int
+	for _, param := range c.QueryArray("what_if") {
+		for _, value := range strings.Split(param, ",") {
+			price, err := strconv.Atoi(strings.TrimSpace(value))
+			if err != nil || price < basePricePerPlayer {
+				c.JSON(http.StatusBadRequest, gin.H{
+					"success": false,
+					"error":   fmt.Sprintf("what_if prices must be whole numbers of at least %d", basePricePerPlayer),
+				})
+				return
+			}
+			prices = append(prices, price)
+		}
+	}
+
+	team, err := h.Store.Teams().GetByID(teamUUID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"success": false,
+			"error":   "Team not found",
+		})
+		return
+	}
+
+	budget := budgetOf(team)
+	plan := BudgetPlan{
+		TotalPoints:     team.TotalPoints,
+		UsedPoints:      team.UsedPoints,
+		RemainingPoints: budget.RemainingPoints,
+		PlayerCount:     team.PlayerCount,
+		MinPlayers:      minPlayersRequired,
+		UnfilledSlots:   budget.playersNeeded(),
+		ReservedPerSlot: basePricePerPlayer,
+		ReservedPoints:  budget.reserved(),
+		MaxBid:          budget.maxBid(),
+		Projections:     []BudgetProjection{},
+	}
+
+	if auction, err := h.Store.Auctions().GetActive(); err == nil && auction.CurrentPlayerID != nil {
+		lot := &CurrentLot{
+			AuctionID:  auction.ID,
+			PlayerID:   *auction.CurrentPlayerID,
+			CurrentBid: auction.CurrentBid,
+			MinimumBid: minimumBid(auction),
+			Winning:    auction.WinningTeamID != nil && *auction.WinningTeamID == team.ID,
+		}
+		var reqErr *requestError
+		if err := checkBid(h.Store, auction, team, lot.MinimumBid); errors.As(err, &reqErr) {
+			lot.Reason = reqErr.code
+		} else {
+			lot.CanBid = err == nil
+		}
+		plan.CurrentLot = lot
+	}
+
+	for _, price := range prices {
+		projection := BudgetProjection{Price: price}
+		if err := budget.check(price); err != nil {
+			reqErr := err.(*requestError)
+			projection.Reason = reqErr.code
+			projection.Error = reqErr.message
+		} else {
+			budget = budget.buy(price)
+			projection.Allowed = true
+		}
+		projection.RemainingPoints = budget.RemainingPoints
+		projection.PlayerCount = budget.PlayerCount
+		projection.ReservedPoints = budget.reserved()
+		projection.MaxBid = budget.maxBid()
+		plan.Projections = append(plan.Projections, projection)
+		if !projection.Allowed {
+			break
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    plan,
+	})
+}
@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"net/http"
+
+	"auction-backend/doctor"
+	"auction-backend/repository"
+	"auction-backend/websocket"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// GetDoctorReport scans the auction data for invariant violations
+func (h *Handlers) GetDoctorReport(c *gin.Context) {
+	issues, err := doctor.Scan(h.DB)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   "Failed to scan auction data",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": gin.H{
+			"healthy": len(issues) == 0,
+			"issues":  issues,
+		},
+	})
+}
+
+// RepairAuctionData fixes invariant violations. It is a dry run unless dry_run=false is passed.
+func (h *Handlers) RepairAuctionData(c *gin.Context) {
+	dryRun := c.DefaultQuery("dry_run", "true") != "false"
+
+	// Audit the repair in the transaction that makes it, so it is logged only if it commits
+	var report *doctor.Report
+	err := h.DB.Transaction(func(tx *gorm.DB) error {
+		var err error
+		if report, err = doctor.Repair(tx, dryRun); err != nil {
+			return err
+		}
+		if dryRun || len(report.Fixed) == 0 {
+			return nil
+		}
+		return h.recordAudit(repository.NewGormStore(tx), c, "doctor.repaired", "system", "auction-doctor", report.Found, report.Remaining)
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   "Failed to repair auction data",
+		})
+		return
+	}
+
+	if !dryRun && len(report.Fixed) > 0 {
+		h.Hub.Publish(websocket.AdminRoom, "doctor_repaired", report)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    report,
+	})
+}
@@ -0,0 +1,425 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"time"
+
+	"auction-backend/export"
+	"auction-backend/ledger"
+	"auction-backend/models"
+	"auction-backend/repository"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// auctionData is what the exports are built from, read in one snapshot
+type auctionData struct {
+	players  []models.Player
+	teams    []models.Team
+	auctions []models.Auction
+	// rounds numbers the auctions in the order they started, from 1
+	rounds map[uuid.UUID]int
+	// sales is each player's latest purchase in the points ledger
+	sales map[uuid.UUID]models.PointsTransaction
+	// bids holds each auction's bids, newest first, when they were asked for
+	bids map[uuid.UUID][]models.Bid
+}
+
+// loadAuctionData reads players, teams, auctions and purchases, and optionally
+// bids, as of one point in time
+func loadAuctionData(store repository.Store, withBids bool) (*auctionData, error) {
+	data := &auctionData{
+		rounds: make(map[uuid.UUID]int),
+		sales:  make(map[uuid.UUID]models.PointsTransaction),
+		bids:   make(map[uuid.UUID][]models.Bid),
+	}
+	err := store.Snapshot(func(tx repository.Store) error {
+		var err error
+		if data.players, err = tx.Players().List(repository.PlayerFilter{}); err != nil {
+			return err
+		}
+		if data.teams, err = tx.Teams().ListWithPlayers(); err != nil {
+			return err
+		}
+		if data.auctions, err = tx.Auctions().List(""); err != nil {
+			return err
+		}
+		if withBids {
+			for _, auction := range data.auctions {
+				if data.bids[auction.ID], err = tx.Bids().ListByAuction(auction.ID); err != nil {
+					return err
+				}
+			}
+		}
+		for _, team := range data.teams {
+			entries, err := tx.PointsTransactions().ListByTeam(team.ID)
+			if err != nil {
+				return err
+			}
+			// Keep each player's latest purchase
+			for _, entry := range entries {
+				if entry.Type != ledger.TypePurchase || entry.PlayerID == nil {
+					continue
+				}
+				if latest, ok := data.sales[*entry.PlayerID]; !ok || entry.CreatedAt.After(latest.CreatedAt) {
+					data.sales[*entry.PlayerID] = entry
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(data.auctions, func(i, j int) bool {
+		a, b := data.auctions[i], data.auctions[j]
+		if !a.StartTime.Equal(b.StartTime) {
+			return a.StartTime.Before(b.StartTime)
+		}
+		return a.CreatedAt.Before(b.CreatedAt)
+	})
+	for i, auction := range data.auctions {
+		data.rounds[auction.ID] = i + 1
+	}
+	return data, nil
+}
+
+// teamNames maps team IDs to names
+func (d *auctionData) teamNames() map[uuid.UUID]string {
+	names := make(map[uuid.UUID]string, len(d.teams))
+	for _, team := range d.teams {
+		names[team.ID] = team.Name
+	}
+	return names
+}
+
+// round returns the round a player was sold in and its auction, or 0 when the
+// player was not sold in an auction
+func (d *auctionData) round(playerID uuid.UUID) (int, *models.Auction) {
+	sale, ok := d.sales[playerID]
+	if !ok || sale.AuctionID == nil {
+		return 0, nil
+	}
+	for i := range d.auctions {
+		if d.auctions[i].ID == *sale.AuctionID {
+			return d.rounds[*sale.AuctionID], &d.auctions[i]
+		}
+	}
+	return 0, nil
+}
+
+// exportFormat reads the format query parameter, csv by default
+func exportFormat(c *gin.Context) (string, bool) {
+	format := c.DefaultQuery("format", export.FormatCSV)
+	if _, ok := export.ContentTypes[format]; !ok {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Format must be csv, xlsx or pdf",
+		})
+		return "", false
+	}
+	return format, true
+}
+
+// sendExport writes the report as a download named name.format
+func sendExport(c *gin.Context, name, format string, report export.Report) {
+	var buf bytes.Buffer
+	if err := export.Write(&buf, format, report); err != nil {
+		log.Printf("Failed to write %s export %s: %v", format, name, err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   "Failed to build export",
+		})
+		return
+	}
+
+	filename := fmt.Sprintf("%s-%s.%s", name, report.GeneratedAt.Format("2006-01-02"), format)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Data(http.StatusOK, export.ContentTypes[format], buf.Bytes())
+}
+
+// auctionFilter reads the optional auction_id query parameter
+func auctionFilter(c *gin.Context) (*uuid.UUID, bool) {
+	value := c.Query("auction_id")
+	if value == "" {
+		return nil, true
+	}
+	auctionID, err := uuid.Parse(value)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid auction ID",
+		})
+		return nil, false
+	}
+	return &auctionID, true
+}
+
+// ExportResults downloads every sold or retained player with their team,
+// price and the round they were sold in. With auction_id, only that
+// auction's sales are included.
+func (h *Handlers) ExportResults(c *gin.Context) {
+	format, ok := exportFormat(c)
+	if !ok {
+		return
+	}
+	auctionID, ok := auctionFilter(c)
+	if !ok {
+		return
+	}
+
+	data, err := loadAuctionData(h.Store, false)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   "Failed to load auction results",
+		})
+		return
+	}
+	teamNames := data.teamNames()
+
+	type result struct {
+		player   models.Player
+		teamID   uuid.UUID
+		round    int
+		auction  string
+		soldAt   time.Time
+		retained bool
+	}
+	var results []result
+	for _, player := range data.players {
+		r := result{player: player}
+		switch {
+		case player.CurrentTeamID != nil:
+			r.teamID = *player.CurrentTeamID
+		case player.RetainedBy != nil:
+			r.teamID = *player.RetainedBy
+		default:
+			continue
+		}
+		r.retained = player.IsRetained
+
+		round, auction := data.round(player.ID)
+		if auctionID != nil && (auction == nil || auction.ID != *auctionID) {
+			continue
+		}
+		if auction != nil {
+			r.round, r.auction = round, auction.Title
+		}
+		if sale, ok := data.sales[player.ID]; ok {
+			r.soldAt = sale.CreatedAt
+		}
+		results = append(results, r)
+	}
+
+	// Retained players first, then sales in the order they happened
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if a.retained != b.retained {
+			return a.retained
+		}
+		if a.round != b.round {
+			return a.round < b.round
+		}
+		return a.soldAt.Before(b.soldAt)
+	})
+
+	table := export.Table{
+		Name:    "Results",
+		Columns: []string{"Player", "Gender", "Category", "Playing Category", "Team", "Price", "Round", "Auction", "Retained", "Sold At"},
+	}
+	for _, r := range results {
+		var round interface{}
+		if r.round > 0 {
+			round = r.round
+		}
+		table.Rows = append(table.Rows, []interface{}{
+			r.player.Name,
+			r.player.Gender,
+			r.player.GetCategoryDisplayName(),
+			r.player.PlayingCategory,
+			teamNames[r.teamID],
+			r.player.CurrentPrice,
+			round,
+			r.auction,
+			r.retained,
+			r.soldAt,
+		})
+	}
+
+	sendExport(c, "auction-results", format, export.Report{
+		Title:       "Auction Results",
+		GeneratedAt: time.Now(),
+		Tables:      []export.Table{table},
+	})
+}
+
+// ExportSquads downloads each team's squad with its budget: one sheet per team
+// in XLSX, one section per team in PDF
+func (h *Handlers) ExportSquads(c *gin.Context) {
+	format, ok := exportFormat(c)
+	if !ok {
+		return
+	}
+
+	data, err := loadAuctionData(h.Store, false)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   "Failed to load squads",
+		})
+		return
+	}
+
+	// A retained player belongs to the retaining team's squad until sold
+	squads := make(map[uuid.UUID][]models.Player)
+	for _, player := range data.players {
+		switch {
+		case player.CurrentTeamID != nil:
+			squads[*player.CurrentTeamID] = append(squads[*player.CurrentTeamID], player)
+		case player.RetainedBy != nil:
+			squads[*player.RetainedBy] = append(squads[*player.RetainedBy], player)
+		}
+	}
+
+	teams := append([]models.Team(nil), data.teams...)
+	sort.SliceStable(teams, func(i, j int) bool { return teams[i].Name < teams[j].Name })
+
+	report := export.Report{
+		Title:       "Team Squads",
+		GeneratedAt: time.Now(),
+		GroupLabel:  "Team",
+	}
+	for _, team := range teams {
+		squad := squads[team.ID]
+		sort.SliceStable(squad, func(i, j int) bool {
+			if squad[i].CurrentPrice != squad[j].CurrentPrice {
+				return squad[i].CurrentPrice > squad[j].CurrentPrice
+			}
+			return squad[i].Name < squad[j].Name
+		})
+
+		table := export.Table{
+			Name: team.Name,
+			Summary: []export.Field{
+				{Label: "Total Budget", Value: team.TotalPoints},
+				{Label: "Spent", Value: team.UsedPoints},
+				{Label: "Remaining Budget", Value: team.TotalPoints - team.UsedPoints},
+				{Label: "Squad Size", Value: fmt.Sprintf("%d (min %d, max %d)", len(squad), team.MinPlayers, team.MaxPlayers)},
+			},
+			Columns: []string{"Player", "Gender", "Category", "Playing Category", "Price", "Round", "Retained"},
+		}
+		for _, player := range squad {
+			var round interface{}
+			if r, _ := data.round(player.ID); r > 0 {
+				round = r
+			}
+			table.Rows = append(table.Rows, []interface{}{
+				player.Name,
+				player.Gender,
+				player.GetCategoryDisplayName(),
+				player.PlayingCategory,
+				player.CurrentPrice,
+				round,
+				player.IsRetained,
+			})
+		}
+		report.Tables = append(report.Tables, table)
+	}
+
+	sendExport(c, "team-squads", format, report)
+}
+
+// ExportBids downloads the bid history of each lot, oldest bid first. With
+// auction_id, only that auction's lots are included.
+func (h *Handlers) ExportBids(c *gin.Context) {
+	format, ok := exportFormat(c)
+	if !ok {
+		return
+	}
+	auctionID, ok := auctionFilter(c)
+	if !ok {
+		return
+	}
+
+	data, err := loadAuctionData(h.Store, true)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   "Failed to load bids",
+		})
+		return
+	}
+	players := make(map[uuid.UUID]models.Player, len(data.players))
+	for _, player := range data.players {
+		players[player.ID] = player
+	}
+	teamNames := data.teamNames()
+
+	report := export.Report{
+		Title:       "Bid History",
+		GeneratedAt: time.Now(),
+		GroupLabel:  "Lot",
+		OneSheet:    true,
+	}
+	for _, auction := range data.auctions {
+		if auctionID != nil && auction.ID != *auctionID {
+			continue
+		}
+		bids := data.bids[auction.ID]
+
+		// A lot is one player's bidding within an auction, in the order bidding opened
+		var order []uuid.UUID
+		lots := make(map[uuid.UUID][]models.Bid)
+		for i := len(bids) - 1; i >= 0; i-- {
+			bid := bids[i]
+			if _, ok := lots[bid.PlayerID]; !ok {
+				order = append(order, bid.PlayerID)
+			}
+			lots[bid.PlayerID] = append(lots[bid.PlayerID], bid)
+		}
+
+		for n, playerID := range order {
+			player := players[playerID]
+			name := player.Name
+			if name == "" {
+				name = "Deleted player"
+			}
+
+			var price interface{}
+			result := "Unsold"
+			if sale, ok := data.sales[playerID]; ok && sale.AuctionID != nil && *sale.AuctionID == auction.ID {
+				result, price = "Sold to "+teamNames[sale.TeamID], sale.Amount
+			} else if auction.Status == "active" && auction.CurrentPlayerID != nil && *auction.CurrentPlayerID == playerID {
+				result = "Bidding open"
+			}
+
+			table := export.Table{
+				Name: fmt.Sprintf("Round %d, lot %d", data.rounds[auction.ID], n+1),
+				Summary: []export.Field{
+					{Label: "Auction", Value: auction.Title},
+					{Label: "Player", Value: name},
+					{Label: "Result", Value: result},
+					{Label: "Final Price", Value: price},
+				},
+				Columns: []string{"Bid", "Time", "Team", "Amount", "Winning"},
+			}
+			for i, bid := range lots[playerID] {
+				team := bid.Team.Name
+				if team == "" {
+					team = teamNames[bid.TeamID]
+				}
+				table.Rows = append(table.Rows, []interface{}{i + 1, bid.CreatedAt, team, bid.Amount, bid.IsWinning})
+			}
+			report.Tables = append(report.Tables, table)
+		}
+	}
+
+	sendExport(c, "bid-history", format, report)
+}
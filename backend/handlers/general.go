@@ -1,12 +1,11 @@
 package handlers
 
 import (
-	"fmt"
-	"log"
 	"net/http"
 	"time"
 
 	"auction-backend/models"
+	"auction-backend/repository"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -23,7 +22,7 @@ type RegisterRequest struct {
 	Username string `json:"username" binding:"required"`
 	Email    string `json:"email" binding:"required,email"`
 	Password string `json:"password" binding:"required"`
-	Role     string `json:"role" binding:"required"`
+	Role     string `json:"role"` // optional; only "player" may sign up
 }
 
 // Login handles user authentication
@@ -37,8 +36,8 @@ func (h *Handlers) Login(c *gin.Context) {
 		return
 	}
 
-	var user models.User
-	if err := h.DB.Where("email = ?", req.Email).First(&user).Error; err != nil {
+	user, err := h.Store.Users().GetByEmail(req.Email)
+	if err != nil {
 		c.JSON(http.StatusUnauthorized, gin.H{
 			"success": false,
 			"error":   "Invalid credentials",
@@ -58,10 +57,13 @@ func (h *Handlers) Login(c *gin.Context) {
 
 	// Generate token based on user role
 	var token string
-	if user.Role == "team" && user.TeamID != nil {
+	switch {
+	case user.Role == "team" && user.TeamID != nil:
 		// For team users, use the format expected by middleware
 		token = "mock-jwt-token-team" + user.TeamID.String()
-	} else {
+	case user.Role == "player":
+		token = "mock-jwt-token-player" + user.ID.String()
+	default:
 		// For admin users, use user ID
 		token = "mock-jwt-token-" + user.ID.String()
 	}
@@ -85,10 +87,16 @@ func (h *Handlers) Register(c *gin.Context) {
 		})
 		return
 	}
+	if req.Role != "" && req.Role != "player" {
+		c.JSON(http.StatusForbidden, gin.H{
+			"success": false,
+			"error":   "Only players can register",
+		})
+		return
+	}
 
 	// Check if user already exists
-	var existingUser models.User
-	if err := h.DB.Where("email = ? OR username = ?", req.Email, req.Username).First(&existingUser).Error; err == nil {
+	if exists, err := h.Store.Users().ExistsByEmailOrUsername(req.Email, req.Username); err != nil || exists {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"success": false,
 			"error":   "User already exists",
@@ -101,16 +109,19 @@ func (h *Handlers) Register(c *gin.Context) {
 		Username:  req.Username,
 		Email:     req.Email,
 		Password:  req.Password, // Should be hashed
-		Role:      req.Role,
+		Role:      "player",
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
 
-	if err := h.DB.Create(&user).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"success": false,
-			"error":   "Failed to create user",
-		})
+	err := h.Store.Transaction(func(tx repository.Store) error {
+		if err := tx.Users().Create(&user); err != nil {
+			return err
+		}
+		return h.recordAudit(tx, c, "user.registered", "user", user.ID, nil, user)
+	})
+	if err != nil {
+		respondError(c, err, "Failed to create user")
 		return
 	}
 
@@ -122,36 +133,22 @@ func (h *Handlers) Register(c *gin.Context) {
 
 // GetPlayers returns all players with filtering
 func (h *Handlers) GetPlayers(c *gin.Context) {
-	var players []models.Player
-
-	query := h.DB.Preload("User")
-
-	// Add filters
-	if status := c.Query("status"); status != "" {
-		if status == "sold" {
-			query = query.Where("is_sold = ?", true)
-		} else if status == "unsold" {
-			query = query.Where("is_sold = ?", false)
-		}
-	}
-
-	if category := c.Query("category"); category != "" {
-		query = query.Where("playing_category = ?", category)
-	}
-
-	// Add player category filter (women, men_under_35, men_35_plus)
-	if playerCategory := c.Query("player_category"); playerCategory != "" {
-		switch playerCategory {
-		case "women":
-			query = query.Where("gender = ?", "female")
-		case "men_under_35":
-			query = query.Where("gender = ? AND EXTRACT(YEAR FROM AGE(date_of_birth)) < ?", "male", 35)
-		case "men_35_plus":
-			query = query.Where("gender = ? AND EXTRACT(YEAR FROM AGE(date_of_birth)) >= ?", "male", 35)
-		}
-	}
-
-	if err := query.Find(&players).Error; err != nil {
+	// Filters: status (sold, unsold), category (playing category) and
+	// player_category (women, men_under_35, men_35_plus). Only admins see
+	// registrations that are not approved, filtered by registration_status.
+	view := viewerOf(c)
+	registrationStatus := models.RegistrationApproved
+	if view.role == "admin" {
+		registrationStatus = c.Query("registration_status")
+	}
+
+	players, err := h.Store.Players().List(repository.PlayerFilter{
+		Status:             c.Query("status"),
+		PlayingCategory:    c.Query("category"),
+		PlayerCategory:     c.Query("player_category"),
+		RegistrationStatus: registrationStatus,
+	})
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"success": false,
 			"error":   "Failed to fetch players",
@@ -161,16 +158,23 @@ func (h *Handlers) GetPlayers(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
-		"data":    players,
+		"data":    view.players(players),
 	})
 }
 
 // GetAuction returns a specific auction
 func (h *Handlers) GetAuction(c *gin.Context) {
-	auctionID := c.Param("id")
+	auctionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid auction ID",
+		})
+		return
+	}
 
-	var auction models.Auction
-	if err := h.DB.First(&auction, auctionID).Error; err != nil {
+	auction, err := h.Store.Auctions().GetByID(auctionID)
+	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{
 			"success": false,
 			"error":   "Auction not found",
@@ -186,10 +190,17 @@ func (h *Handlers) GetAuction(c *gin.Context) {
 
 // GetPlayer returns a specific player
 func (h *Handlers) GetPlayer(c *gin.Context) {
-	playerID := c.Param("id")
+	playerID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid player ID",
+		})
+		return
+	}
 
-	var player models.Player
-	if err := h.DB.Preload("User").First(&player, playerID).Error; err != nil {
+	player, err := h.Store.Players().GetByID(playerID)
+	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{
 			"success": false,
 			"error":   "Player not found",
@@ -199,16 +210,23 @@ func (h *Handlers) GetPlayer(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
-		"data":    player,
+		"data":    viewerOf(c).player(*player),
 	})
 }
 
 // UpdatePlayer updates a player
 func (h *Handlers) UpdatePlayer(c *gin.Context) {
-	playerID := c.Param("id")
-	var player models.Player
+	playerID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid player ID",
+		})
+		return
+	}
 
-	if err := h.DB.First(&player, playerID).Error; err != nil {
+	player, err := h.Store.Players().GetByID(playerID)
+	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{
 			"success": false,
 			"error":   "Player not found",
@@ -225,28 +243,48 @@ func (h *Handlers) UpdatePlayer(c *gin.Context) {
 		return
 	}
 
+	// Registration review goes through ApprovePlayer
+	updateData.RegistrationStatus = ""
+	updateData.ReviewReason = ""
+	updateData.ReviewedBy = ""
+	updateData.ReviewedAt = nil
+	// Photos are set through UploadPlayerPhoto
+	updateData.PhotoThumbURL = ""
+	updateData.PhotoCardURL = ""
+
 	updateData.UpdatedAt = time.Now()
+	before := *player
 
-	if err := h.DB.Model(&player).Updates(updateData).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"success": false,
-			"error":   "Failed to update player",
-		})
+	err = h.Store.Transaction(func(tx repository.Store) error {
+		if err := tx.Players().Updates(player, &updateData); err != nil {
+			return err
+		}
+		return h.recordAudit(tx, c, "player.updated", "player", player.ID, before, player)
+	})
+	if err != nil {
+		respondError(c, err, "Failed to update player")
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
-		"data":    player,
+		"data":    viewerOf(c).player(*player),
 	})
 }
 
 // DeletePlayer deletes a player
 func (h *Handlers) DeletePlayer(c *gin.Context) {
-	playerID := c.Param("id")
-	var player models.Player
+	playerID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid player ID",
+		})
+		return
+	}
 
-	if err := h.DB.First(&player, playerID).Error; err != nil {
+	player, err := h.Store.Players().GetByID(playerID)
+	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{
 			"success": false,
 			"error":   "Player not found",
@@ -254,11 +292,14 @@ func (h *Handlers) DeletePlayer(c *gin.Context) {
 		return
 	}
 
-	if err := h.DB.Delete(&player).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"success": false,
-			"error":   "Failed to delete player",
-		})
+	err = h.Store.Transaction(func(tx repository.Store) error {
+		if err := tx.Players().Delete(player.ID); err != nil {
+			return err
+		}
+		return h.recordAudit(tx, c, "player.deleted", "player", player.ID, player, nil)
+	})
+	if err != nil {
+		respondError(c, err, "Failed to delete player")
 		return
 	}
 
@@ -270,9 +311,8 @@ func (h *Handlers) DeletePlayer(c *gin.Context) {
 
 // GetTeams returns all teams
 func (h *Handlers) GetTeams(c *gin.Context) {
-	var teams []models.Team
-
-	if err := h.DB.Preload("Players").Find(&teams).Error; err != nil {
+	teams, err := h.Store.Teams().ListWithPlayers()
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"success": false,
 			"error":   "Failed to fetch teams",
@@ -282,16 +322,23 @@ func (h *Handlers) GetTeams(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
-		"data":    teams,
+		"data":    viewerOf(c).teams(teams),
 	})
 }
 
 // GetTeam returns a specific team
 func (h *Handlers) GetTeam(c *gin.Context) {
-	teamID := c.Param("id")
+	teamID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid team ID",
+		})
+		return
+	}
 
-	var team models.Team
-	if err := h.DB.Preload("Players").First(&team, teamID).Error; err != nil {
+	team, err := h.Store.Teams().GetByID(teamID)
+	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{
 			"success": false,
 			"error":   "Team not found",
@@ -299,18 +346,33 @@ func (h *Handlers) GetTeam(c *gin.Context) {
 		return
 	}
 
+	if team.Players, err = h.Store.Players().ListByTeam(team.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   "Failed to fetch team players",
+		})
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
-		"data":    team,
+		"data":    viewerOf(c).team(*team),
 	})
 }
 
 // UpdateTeam updates a team
 func (h *Handlers) UpdateTeam(c *gin.Context) {
-	teamID := c.Param("id")
-	var team models.Team
+	teamID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid team ID",
+		})
+		return
+	}
 
-	if err := h.DB.First(&team, teamID).Error; err != nil {
+	team, err := h.Store.Teams().GetByID(teamID)
+	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{
 			"success": false,
 			"error":   "Team not found",
@@ -328,12 +390,16 @@ func (h *Handlers) UpdateTeam(c *gin.Context) {
 	}
 
 	updateData.UpdatedAt = time.Now()
+	before := *team
 
-	if err := h.DB.Model(&team).Updates(updateData).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"success": false,
-			"error":   "Failed to update team",
-		})
+	err = h.Store.Transaction(func(tx repository.Store) error {
+		if err := tx.Teams().Updates(team, &updateData); err != nil {
+			return err
+		}
+		return h.recordAudit(tx, c, "team.updated", "team", team.ID, before, team)
+	})
+	if err != nil {
+		respondError(c, err, "Failed to update team")
 		return
 	}
 
@@ -345,10 +411,17 @@ func (h *Handlers) UpdateTeam(c *gin.Context) {
 
 // GetTeamPlayers returns players for a specific team
 func (h *Handlers) GetTeamPlayers(c *gin.Context) {
-	teamID := c.Param("id")
+	teamID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid team ID",
+		})
+		return
+	}
 
-	var players []models.Player
-	if err := h.DB.Where("current_team_id = ?", teamID).Preload("User").Find(&players).Error; err != nil {
+	players, err := h.Store.Players().ListByTeam(teamID)
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"success": false,
 			"error":   "Failed to fetch team players",
@@ -358,16 +431,23 @@ func (h *Handlers) GetTeamPlayers(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
-		"data":    players,
+		"data":    viewerOf(c).players(players),
 	})
 }
 
 // GetTeamPoints returns points for a specific team
 func (h *Handlers) GetTeamPoints(c *gin.Context) {
-	teamID := c.Param("id")
+	teamID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid team ID",
+		})
+		return
+	}
 
-	var team models.Team
-	if err := h.DB.First(&team, teamID).Error; err != nil {
+	team, err := h.Store.Teams().GetByID(teamID)
+	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{
 			"success": false,
 			"error":   "Team not found",
@@ -390,9 +470,8 @@ func (h *Handlers) GetTeamPoints(c *gin.Context) {
 
 // GetCategories returns all tournament categories
 func (h *Handlers) GetCategories(c *gin.Context) {
-	var categories []models.Category
-
-	if err := h.DB.Find(&categories).Error; err != nil {
+	categories, err := h.Store.Categories().List()
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"success": false,
 			"error":   "Failed to fetch categories",
@@ -411,6 +490,19 @@ func (h *Handlers) HandleWebSocket(c *gin.Context) {
 	h.Hub.HandleWebSocket(c)
 }
 
+// StreamAuctionEvents streams an auction's events over Server-Sent Events
+func (h *Handlers) StreamAuctionEvents(c *gin.Context) {
+	h.Hub.HandleEvents(c)
+}
+
+// GetWebSocketStats returns connection counters and per-client metrics for the WebSocket hub
+func (h *Handlers) GetWebSocketStats(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    h.Hub.Stats(),
+	})
+}
+
 // CreateBid creates a new bid for a player in an auction
 func (h *Handlers) CreateBid(c *gin.Context) {
 	auctionID := c.Param("id")
@@ -422,6 +514,7 @@ func (h *Handlers) CreateBid(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"success": false,
 			"error":   "Invalid request data",
+			"code":    reasonInvalidRequest,
 		})
 		return
 	}
@@ -432,6 +525,7 @@ func (h *Handlers) CreateBid(c *gin.Context) {
 		c.JSON(http.StatusUnauthorized, gin.H{
 			"success": false,
 			"error":   "Team not found",
+			"code":    reasonNotATeam,
 		})
 		return
 	}
@@ -442,181 +536,22 @@ func (h *Handlers) CreateBid(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"success": false,
 			"error":   "Invalid auction ID",
+			"code":    reasonInvalidRequest,
 		})
 		return
 	}
 
-	// Get auction
-	var auction models.Auction
-	if err := h.DB.First(&auction, auctionUUID).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{
-			"success": false,
-			"error":   "Auction not found",
-		})
-		return
-	}
-
-	// Check if auction is active
-	if auction.Status != "active" {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"success": false,
-			"error":   "Auction is not active",
-		})
-		return
-	}
-
-	// Debug logging
-	log.Printf("CreateBid: CurrentBid=%d, RequestedAmount=%d", auction.CurrentBid, req.Amount)
-
-	// Check if bid is valid
-	// For the first bid (CurrentBid = 0), allow bidding at base price (200) or higher
-	// For subsequent bids, require bid to be higher than current bid
-	if auction.CurrentBid == 0 {
-		// First bid - must be at least base price (200)
-		if req.Amount < 200 {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"success": false,
-				"error":   "First bid must be at least ₹200",
-			})
-			return
-		}
-	} else {
-		// Subsequent bids - must be higher than current bid
-		if req.Amount <= auction.CurrentBid {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"success": false,
-				"error":   "Bid must be higher than current bid",
-			})
-			return
-		}
-	}
-
-	// Parse team ID to UUID
-	teamUUID, err := uuid.Parse(teamID.(string))
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"success": false,
-			"error":   "Invalid team ID",
-		})
-		return
-	}
-
-	// Get team to check available points
-	var team models.Team
-	if err := h.DB.First(&team, teamUUID).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{
-			"success": false,
-			"error":   "Team not found",
-		})
-		return
-	}
-
-	// Check if team has enough points
-	remainingPoints := team.TotalPoints - team.UsedPoints
-	if req.Amount > remainingPoints {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"success": false,
-			"error":   "Insufficient points",
-		})
-		return
-	}
-
-	// Smart bidding validation: Check if bid would leave team without enough points for minimum players
-	const minPlayersRequired = 12
-	const basePricePerPlayer = 200
-
-	// Calculate how many more players the team needs
-	playersAcquired := team.PlayerCount
-	remainingPlayersNeeded := minPlayersRequired - playersAcquired - 1 // -1 for current player being bid on
-
-	if remainingPlayersNeeded > 0 {
-		// Calculate minimum points needed for remaining players
-		minPointsForRemainingPlayers := remainingPlayersNeeded * basePricePerPlayer
-
-		// Calculate points that would remain after this bid
-		pointsAfterBid := remainingPoints - req.Amount
-
-		// Check if bidding would leave team without enough points for minimum players
-		if pointsAfterBid < minPointsForRemainingPlayers {
-			maxSafeBid := remainingPoints - minPointsForRemainingPlayers
-			c.JSON(http.StatusBadRequest, gin.H{
-				"success": false,
-				"error":   "Bid too high! You need at least " + fmt.Sprintf("%d", minPointsForRemainingPlayers) + " points for " + fmt.Sprintf("%d", remainingPlayersNeeded) + " more players. Max safe bid: " + fmt.Sprintf("%d", maxSafeBid),
-			})
-			return
-		}
-	}
-
-	// Check if the same team is already winning the current bid
-	if auction.WinningTeamID != nil && *auction.WinningTeamID == team.ID {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"success": false,
-			"error":   "You are already winning the current bid. Another team must bid first.",
-		})
-		return
-	}
-
-	// Create bid
-	bid := models.Bid{
-		AuctionID: auction.ID,
-		PlayerID:  *auction.CurrentPlayerID,
-		TeamID:    teamUUID,
+	bid, err := h.placeBid(bidRequest{
+		AuctionID: auctionUUID,
+		TeamID:    teamID.(string),
 		Amount:    req.Amount,
-		IsWinning: true,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
-	}
-
-	// Start transaction
-	tx := h.DB.Begin()
-
-	// Set all previous bids for this auction to not winning
-	if err := tx.Model(&models.Bid{}).
-		Where("auction_id = ?", auctionID).
-		Update("is_winning", false).Error; err != nil {
-		tx.Rollback()
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"success": false,
-			"error":   "Failed to update bids",
-		})
-		return
-	}
-
-	// Create new bid
-	if err := tx.Create(&bid).Error; err != nil {
-		tx.Rollback()
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"success": false,
-			"error":   "Failed to create bid",
-		})
-		return
-	}
-
-	// Update auction current bid
-	auction.CurrentBid = req.Amount
-	auction.WinningTeamID = &team.ID
-	auction.UpdatedAt = time.Now()
-
-	if err := tx.Save(&auction).Error; err != nil {
-		tx.Rollback()
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"success": false,
-			"error":   "Failed to update auction",
-		})
+		Actor:     requestActor(c),
+	})
+	if err != nil {
+		respondError(c, err, "Failed to create bid")
 		return
 	}
 
-	// Commit transaction
-	tx.Commit()
-
-	// Broadcast new bid
-	h.Hub.Broadcast("new_bid", gin.H{
-		"auction_id":  auction.ID,
-		"bid":         bid,
-		"team":        team,
-		"current_bid": auction.CurrentBid,
-	})
-
 	c.JSON(http.StatusCreated, gin.H{
 		"success": true,
 		"data":    bid,
@@ -625,13 +560,17 @@ func (h *Handlers) CreateBid(c *gin.Context) {
 
 // GetAuctionBids gets all bids for an auction
 func (h *Handlers) GetAuctionBids(c *gin.Context) {
-	auctionID := c.Param("id")
+	auctionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid auction ID",
+		})
+		return
+	}
 
-	var bids []models.Bid
-	if err := h.DB.Where("auction_id = ?", auctionID).
-		Preload("Team").
-		Order("created_at DESC").
-		Find(&bids).Error; err != nil {
+	bids, err := h.Store.Bids().ListByAuction(auctionID)
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"success": false,
 			"error":   "Failed to fetch bids",
@@ -647,12 +586,17 @@ func (h *Handlers) GetAuctionBids(c *gin.Context) {
 
 // GetCurrentBid gets the current winning bid for an auction
 func (h *Handlers) GetCurrentBid(c *gin.Context) {
-	auctionID := c.Param("id")
+	auctionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid auction ID",
+		})
+		return
+	}
 
-	var bid models.Bid
-	if err := h.DB.Where("auction_id = ? AND is_winning = ?", auctionID, true).
-		Preload("Team").
-		First(&bid).Error; err != nil {
+	bid, err := h.Store.Bids().GetWinning(auctionID)
+	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{
 			"success": false,
 			"error":   "No winning bid found",
@@ -668,20 +612,12 @@ func (h *Handlers) GetCurrentBid(c *gin.Context) {
 
 // GetPlayersByCategory returns players grouped by category
 func (h *Handlers) GetPlayersByCategory(c *gin.Context) {
-	var players []models.Player
-
-	query := h.DB.Preload("User")
-
 	// Add status filter if provided
-	if status := c.Query("status"); status != "" {
-		if status == "sold" {
-			query = query.Where("is_sold = ?", true)
-		} else if status == "unsold" {
-			query = query.Where("is_sold = ?", false)
-		}
-	}
-
-	if err := query.Find(&players).Error; err != nil {
+	players, err := h.Store.Players().List(repository.PlayerFilter{
+		Status:             c.Query("status"),
+		RegistrationStatus: models.RegistrationApproved,
+	})
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"success": false,
 			"error":   "Failed to fetch players",
@@ -690,7 +626,8 @@ func (h *Handlers) GetPlayersByCategory(c *gin.Context) {
 	}
 
 	// Group players by category
-	categorizedPlayers := map[string][]models.Player{
+	view := viewerOf(c)
+	categorizedPlayers := map[string][]PlayerView{
 		"women":        {},
 		"men_under_35": {},
 		"men_35_plus":  {},
@@ -699,7 +636,7 @@ func (h *Handlers) GetPlayersByCategory(c *gin.Context) {
 	for _, player := range players {
 		category := player.GetPlayerCategory()
 		if category != "unknown" {
-			categorizedPlayers[category] = append(categorizedPlayers[category], player)
+			categorizedPlayers[category] = append(categorizedPlayers[category], view.player(player))
 		}
 	}
 
@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRegisterCreatesOnlyPlayers(t *testing.T) {
+	s := newTestServer(t)
+	register := func(username, role string) (int, map[string]interface{}) {
+		return s.do(identity{}, http.MethodPost, "/auth/register", "/auth/register", s.h.Register, gin.H{
+			"username": username,
+			"email":    username + "@example.com",
+			"password": "secret",
+			"role":     role,
+		})
+	}
+
+	for _, role := range []string{"admin", "team"} {
+		if status, body := register("mallory-"+role, role); status != http.StatusForbidden {
+			t.Fatalf("register as %s: status %d, body %v, want 403", role, status, body)
+		}
+		if _, err := s.store.Users().GetByEmail("mallory-" + role + "@example.com"); err == nil {
+			t.Fatalf("register as %s created a user", role)
+		}
+	}
+
+	status, body := register("asha", "player")
+	if status != http.StatusCreated {
+		t.Fatalf("register: status %d, body %v", status, body)
+	}
+	if user, err := s.store.Users().GetByEmail("asha@example.com"); err != nil || user.Role != "player" {
+		t.Fatalf("registered user = %+v (%v), want a player", user, err)
+	}
+}
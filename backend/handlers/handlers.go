@@ -1,24 +1,91 @@
 package handlers
 
 import (
+	"errors"
+	"net/http"
+
+	"auction-backend/repository"
+	"auction-backend/storage"
 	"auction-backend/websocket"
 
+	"github.com/gin-gonic/gin"
 	"github.com/go-redis/redis/v8"
 	"gorm.io/gorm"
 )
 
-// Handlers struct holds all handler dependencies
+// Handlers struct holds all handler dependencies. Store serves entity reads and
+// writes; DB is kept for reporting queries that aggregate across tables.
 type Handlers struct {
 	DB          *gorm.DB
+	Store       repository.Store
 	RedisClient *redis.Client
 	Hub         *websocket.Hub
+	// Storage holds uploaded photos and documents; uploads fail while it is nil
+	Storage storage.Storage
+
+	// PresenceRule decides whether lots open while teams are offline: off, warn or enforce
+	PresenceRule string
+	// ReleaseRefundPercent is the share of a released player's price returned
+	// to the team, unless the admin approving the release sets another
+	ReleaseRefundPercent int
 }
 
-// NewHandlers creates a new Handlers instance
-func NewHandlers(db *gorm.DB, redisClient *redis.Client, hub *websocket.Hub) *Handlers {
-	return &Handlers{
+// NewHandlers creates a new Handlers instance serving entities from store. db
+// may be nil where the reporting endpoints are not used, as in tests.
+func NewHandlers(store repository.Store, db *gorm.DB, redisClient *redis.Client, hub *websocket.Hub) *Handlers {
+	h := &Handlers{
 		DB:          db,
+		Store:       store,
 		RedisClient: redisClient,
 		Hub:         hub,
+
+		ReleaseRefundPercent: defaultReleaseRefundPercent,
+	}
+
+	hub.HandleCommand("place_bid", h.handlePlaceBid)
+	return h
+}
+
+// requestError is returned from inside a transaction to choose the response sent
+// once it has rolled back
+type requestError struct {
+	status  int
+	code    string // machine-readable reason, when the caller may act on it
+	message string
+}
+
+func (e *requestError) Error() string {
+	return e.message
+}
+
+// fail builds a requestError
+func fail(status int, message string) error {
+	return &requestError{status: status, message: message}
+}
+
+// failCode builds a requestError with a reason code
+func failCode(status int, code, message string) error {
+	return &requestError{status: status, code: code, message: message}
+}
+
+// respondError writes err as the JSON error response, using the status and
+// message of a requestError or a 500 with fallback otherwise
+func respondError(c *gin.Context, err error, fallback string) {
+	var reqErr *requestError
+	if errors.As(err, &reqErr) {
+		response := gin.H{
+			"success": false,
+			"error":   reqErr.message,
+		}
+		if reqErr.code != "" {
+			response["code"] = reqErr.code
+		}
+		c.JSON(reqErr.status, response)
+		return
 	}
+
+	c.JSON(http.StatusInternalServerError, gin.H{
+		"success": false,
+		"error":   fallback,
+	})
 }
@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"auction-backend/models"
+	"auction-backend/repository"
+	"auction-backend/websocket"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+// testServer runs handlers on an in-memory store, with no database or Redis
+type testServer struct {
+	t     *testing.T
+	h     *Handlers
+	store *repository.MemoryStore
+}
+
+func newTestServer(t *testing.T) *testServer {
+	t.Helper()
+
+	hub := websocket.NewHub(websocket.NewMemoryBroker(10))
+	go hub.Run()
+
+	store := repository.NewMemoryStore()
+	return &testServer{t: t, h: NewHandlers(store, nil, nil, hub), store: store}
+}
+
+// identity is the caller the auth middleware would have set
+type identity struct {
+	role   string
+	userID string
+	teamID string
+}
+
+var testAdmin = identity{role: "admin", userID: "admin-1"}
+
+func teamIdentity(team *models.Team) identity {
+	return identity{role: "team", userID: "user-" + team.ID.String(), teamID: team.ID.String()}
+}
+
+// do serves one request to handler, mounted at route, as who
+func (s *testServer) do(who identity, method, route, path string, handler gin.HandlerFunc, body interface{}) (int, map[string]interface{}) {
+	s.t.Helper()
+
+	router := gin.New()
+	router.Handle(method, route, func(c *gin.Context) {
+		c.Set("user_id", who.userID)
+		c.Set("user_role", who.role)
+		if who.teamID != "" {
+			c.Set("team_id", who.teamID)
+		}
+	}, handler)
+
+	var payload bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&payload).Encode(body); err != nil {
+			s.t.Fatal(err)
+		}
+	}
+	req := httptest.NewRequest(method, path, &payload)
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	var response map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		s.t.Fatalf("%s %s: invalid JSON response %q", method, path, rec.Body.String())
+	}
+	return rec.Code, response
+}
+
+func (s *testServer) team(name string, totalPoints int) *models.Team {
+	s.t.Helper()
+
+	team := &models.Team{Name: name, TotalPoints: totalPoints, MinPlayers: 12, MaxPlayers: 20}
+	if err := s.store.Teams().Create(team); err != nil {
+		s.t.Fatal(err)
+	}
+	return team
+}
+
+func (s *testServer) player(name string) *models.Player {
+	s.t.Helper()
+
+	user := &models.User{Email: name + "@example.com", Username: name, Role: "player"}
+	if err := s.store.Users().Create(user); err != nil {
+		s.t.Fatal(err)
+	}
+	player := &models.Player{
+		UserID:          user.ID,
+		Name:            name,
+		Gender:          "male",
+		DateOfBirth:     time.Now().AddDate(-25, 0, 0),
+		PlayingCategory: "singles",
+		BasePrice:       200,
+		CurrentPrice:    200,
+	}
+	if err := s.store.Players().Create(player); err != nil {
+		s.t.Fatal(err)
+	}
+	return player
+}
+
+// activeAuction creates a running auction with player up for bidding
+func (s *testServer) activeAuction(player *models.Player) *models.Auction {
+	s.t.Helper()
+
+	auction := &models.Auction{Title: "Test Auction", Status: "active", StartTime: time.Now(), CurrentPlayerID: &player.ID}
+	if err := s.store.Auctions().Create(auction); err != nil {
+		s.t.Fatal(err)
+	}
+	return auction
+}
+
+func (s *testServer) reloadTeam(id uuid.UUID) *models.Team {
+	s.t.Helper()
+
+	team, err := s.store.Teams().GetByID(id)
+	if err != nil {
+		s.t.Fatal(err)
+	}
+	return team
+}
+
+func (s *testServer) reloadPlayer(id uuid.UUID) *models.Player {
+	s.t.Helper()
+
+	player, err := s.store.Players().GetByID(id)
+	if err != nil {
+		s.t.Fatal(err)
+	}
+	return player
+}
+
+func (s *testServer) reloadAuction(id uuid.UUID) *models.Auction {
+	s.t.Helper()
+
+	auction, err := s.store.Auctions().GetByID(id)
+	if err != nil {
+		s.t.Fatal(err)
+	}
+	return auction
+}
+
+// auditActions lists the recorded audit actions, newest first
+func (s *testServer) auditActions() []string {
+	s.t.Helper()
+
+	events, _, err := s.store.AuditEvents().List(repository.AuditEventFilter{})
+	if err != nil {
+		s.t.Fatal(err)
+	}
+	actions := []string{}
+	for _, event := range events {
+		actions = append(actions, event.Action)
+	}
+	return actions
+}
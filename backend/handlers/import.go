@@ -0,0 +1,242 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"auction-backend/importer"
+	"auction-backend/models"
+	"auction-backend/repository"
+	"auction-backend/websocket"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// maxImportBytes is the largest player file accepted
+const maxImportBytes = 5 << 20
+
+// errImportRollback rolls back an import that is a dry run or has errors,
+// once every row has been checked
+var errImportRollback = errors.New("import rolled back")
+
+// ImportReport is the outcome of a player import. Nothing is imported unless
+// every row is valid.
+type ImportReport struct {
+	DryRun    bool                `json:"dry_run"`
+	TotalRows int                 `json:"total_rows"`
+	ValidRows int                 `json:"valid_rows"`
+	Imported  int                 `json:"imported"`
+	Errors    []importer.RowError `json:"errors"`
+	Players   []PlayerView        `json:"players"`
+}
+
+// ImportPlayers loads players from a CSV or XLSX file in the multipart field
+// "file". Each row is validated and checked for duplicates, within the file
+// and against existing players, by mobile number or by name and date of
+// birth. All rows are imported together, or none if any row has an error.
+// It is a dry run unless dry_run=false is passed.
+func (h *Handlers) ImportPlayers(c *gin.Context) {
+	dryRun := c.DefaultQuery("dry_run", "true") != "false"
+
+	data, _, err := readUpload(c, "file", maxImportBytes)
+	if err != nil {
+		respondError(c, err, "Failed to read file")
+		return
+	}
+
+	table, err := importer.ReadTable(data)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	now := time.Now()
+	rows, rowErrs := importer.ParsePlayers(table, now)
+	report := ImportReport{
+		DryRun:    dryRun,
+		TotalRows: len(rows) + countRows(rowErrs),
+		Errors:    rowErrs,
+		Players:   []PlayerView{},
+	}
+
+	var imported []models.Player
+	err = h.Store.Transaction(func(tx repository.Store) error {
+		existing, err := tx.Players().List(repository.PlayerFilter{})
+		if err != nil {
+			return fail(http.StatusInternalServerError, "Failed to check existing players")
+		}
+		byMobile := make(map[string]models.Player)
+		byNameDOB := make(map[string]models.Player)
+		for _, player := range existing {
+			mobileKey, nameKey := importer.DuplicateKeys(player.Name, player.DateOfBirth, player.Mobile)
+			if mobileKey != "" {
+				byMobile[mobileKey] = player
+			}
+			byNameDOB[nameKey] = player
+		}
+
+		for _, row := range rows {
+			mobileKey, nameKey := importer.DuplicateKeys(row.Name, row.DateOfBirth, row.Mobile)
+			if player, ok := byMobile[mobileKey]; ok {
+				report.Errors = append(report.Errors, importer.RowError{Row: row.Row, Field: "mobile",
+					Message: fmt.Sprintf("player %s already has this mobile", player.Name)})
+				continue
+			}
+			if player, ok := byNameDOB[nameKey]; ok {
+				report.Errors = append(report.Errors, importer.RowError{Row: row.Row, Field: "name",
+					Message: fmt.Sprintf("player %s already exists with this date of birth", player.Name)})
+				continue
+			}
+
+			// Each row runs in a savepoint, so a failed insert rolls back only
+			// that row and later rows still report their own problems
+			var player *models.Player
+			var rowErr *importer.RowError
+			err := tx.Transaction(func(rowTx repository.Store) error {
+				if player, rowErr = h.importPlayer(rowTx, c, row, now); rowErr != nil {
+					return errImportRollback
+				}
+				return nil
+			})
+			if rowErr == nil && err != nil {
+				log.Printf("Failed to import row %d: %v", row.Row, err)
+				rowErr = &importer.RowError{Row: row.Row, Message: "failed to import the row"}
+			}
+			if rowErr != nil {
+				report.Errors = append(report.Errors, *rowErr)
+				continue
+			}
+			imported = append(imported, *player)
+		}
+
+		report.ValidRows = len(imported)
+		if dryRun || len(report.Errors) > 0 {
+			return errImportRollback
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errImportRollback) {
+		respondError(c, err, "Failed to import players")
+		return
+	}
+
+	sortRowErrors(report.Errors)
+	if report.Errors == nil {
+		report.Errors = []importer.RowError{}
+	}
+
+	if len(report.Errors) > 0 {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{
+			"success": false,
+			"error":   fmt.Sprintf("%d problem(s) found; nothing was imported", len(report.Errors)),
+			"data":    report,
+		})
+		return
+	}
+
+	view := viewerOf(c)
+	for _, player := range imported {
+		report.Players = append(report.Players, view.player(player))
+	}
+	if dryRun {
+		c.JSON(http.StatusOK, gin.H{
+			"success": true,
+			"data":    report,
+		})
+		return
+	}
+
+	report.Imported = len(imported)
+	h.Hub.Publish(websocket.AdminRoom, "players_imported", gin.H{
+		"count": report.Imported,
+	})
+	log.Printf("Imported %d players", report.Imported)
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    report,
+	})
+}
+
+// importPlayer creates the login and the approved player for a row, as
+// CreatePlayer does. A clash with an existing login is reported against the row.
+func (h *Handlers) importPlayer(tx repository.Store, c *gin.Context, row importer.PlayerRow, now time.Time) (*models.Player, *importer.RowError) {
+	email := row.Email
+	if email == "" {
+		email = fmt.Sprintf("%s.%s@players.invalid",
+			strings.ToLower(strings.ReplaceAll(row.Name, " ", ".")), uuid.NewString()[:8])
+	} else if exists, err := tx.Users().ExistsByEmailOrUsername(email, ""); err != nil || exists {
+		return nil, &importer.RowError{Row: row.Row, Field: "email", Message: "a user with this email already exists"}
+	}
+
+	// Players may share a name, but logins may not
+	username := row.Name
+	if exists, err := tx.Users().ExistsByEmailOrUsername("", username); err != nil || exists {
+		username = fmt.Sprintf("%s.%s", row.Name, uuid.NewString()[:8])
+	}
+
+	user := models.User{
+		Username:  username,
+		Email:     email,
+		Password:  "player123", // Default password
+		Role:      "player",
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := tx.Users().Create(&user); err != nil {
+		log.Printf("Failed to create user for import row %d: %v", row.Row, err)
+		return nil, &importer.RowError{Row: row.Row, Message: "failed to create the player's login"}
+	}
+
+	player := models.Player{
+		UserID:             user.ID,
+		Name:               row.Name,
+		Gender:             row.Gender,
+		DateOfBirth:        row.DateOfBirth,
+		Mobile:             row.Mobile,
+		PlayingCategory:    row.PlayingCategory,
+		Accomplishments:    row.Accomplishments,
+		BasePrice:          basePricePerPlayer,
+		CurrentPrice:       basePricePerPlayer,
+		RegistrationStatus: models.RegistrationApproved,
+		ReviewedBy:         c.GetString("user_id"),
+		ReviewedAt:         &now,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	}
+	if err := tx.Players().Create(&player); err != nil {
+		log.Printf("Failed to create player for import row %d: %v", row.Row, err)
+		return nil, &importer.RowError{Row: row.Row, Message: "failed to create the player"}
+	}
+	player.User = user
+
+	if err := h.recordAudit(tx, c, "player.imported", "player", player.ID, nil, player); err != nil {
+		return nil, &importer.RowError{Row: row.Row, Message: "failed to record the import"}
+	}
+	return &player, nil
+}
+
+// countRows counts the distinct data rows named in errs
+func countRows(errs []importer.RowError) int {
+	rows := make(map[int]bool)
+	for _, e := range errs {
+		if e.Row > 1 {
+			rows[e.Row] = true
+		}
+	}
+	return len(rows)
+}
+
+// sortRowErrors orders a report's errors by row, keeping each row's errors in order
+func sortRowErrors(errs []importer.RowError) {
+	sort.SliceStable(errs, func(i, j int) bool { return errs[i].Row < errs[j].Row })
+}
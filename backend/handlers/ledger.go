@@ -0,0 +1,195 @@
+package handlers
+
+import (
+	"net/http"
+
+	"auction-backend/ledger"
+	"auction-backend/models"
+	"auction-backend/repository"
+	"auction-backend/websocket"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// RecordTeamTransaction records a manual ledger entry (adjustment, penalty, refund or retention) for a team
+func (h *Handlers) RecordTeamTransaction(c *gin.Context) {
+	teamID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid team ID",
+		})
+		return
+	}
+
+	var req struct {
+		Type     string `json:"type" binding:"required"`
+		Amount   int    `json:"amount" binding:"required"`
+		PlayerID string `json:"player_id"`
+		Note     string `json:"note"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid request data",
+		})
+		return
+	}
+
+	// Purchases are only recorded by the auction itself, and transfers by trades
+	if !ledger.ManualType(req.Type) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid transaction type",
+		})
+		return
+	}
+
+	team, err := h.Store.Teams().GetByID(teamID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"success": false,
+			"error":   "Team not found",
+		})
+		return
+	}
+
+	entry := models.PointsTransaction{
+		TeamID:    team.ID,
+		Type:      req.Type,
+		Amount:    req.Amount,
+		Note:      req.Note,
+		CreatedBy: c.GetString("user_id"),
+	}
+
+	if req.PlayerID != "" {
+		playerID, err := uuid.Parse(req.PlayerID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"success": false,
+				"error":   "Invalid player ID",
+			})
+			return
+		}
+		entry.PlayerID = &playerID
+	}
+
+	before := *team
+	err = h.Store.Transaction(func(tx repository.Store) error {
+		if err := tx.PointsTransactions().Record(&entry); err != nil {
+			return err
+		}
+		if team, err = tx.Teams().GetByID(team.ID); err != nil {
+			return err
+		}
+		return h.recordAudit(tx, c, "team.points_transaction", "team", team.ID, before, gin.H{
+			"team":        team,
+			"transaction": entry,
+		})
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   "Failed to record transaction",
+		})
+		return
+	}
+
+	h.Hub.Publish(websocket.TeamRoom(team.ID.String()), "team_updated", team)
+	h.Hub.Publish(websocket.AdminRoom, "team_updated", team)
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    entry,
+	})
+}
+
+// GetTeamTransactions returns the points ledger for a team
+func (h *Handlers) GetTeamTransactions(c *gin.Context) {
+	h.respondTeamTransactions(c, c.Param("id"))
+}
+
+// GetMyTeamTransactions returns the points ledger for the authenticated team
+func (h *Handlers) GetMyTeamTransactions(c *gin.Context) {
+	teamID, exists := c.Get("team_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"success": false,
+			"error":   "Team not found",
+		})
+		return
+	}
+
+	h.respondTeamTransactions(c, teamID.(string))
+}
+
+func (h *Handlers) respondTeamTransactions(c *gin.Context, teamID string) {
+	teamUUID, err := uuid.Parse(teamID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid team ID",
+		})
+		return
+	}
+
+	team, err := h.Store.Teams().GetByID(teamUUID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"success": false,
+			"error":   "Team not found",
+		})
+		return
+	}
+
+	transactions, err := h.Store.PointsTransactions().ListByTeam(team.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   "Failed to fetch transactions",
+		})
+		return
+	}
+
+	used, err := h.Store.PointsTransactions().UsedPoints(team.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   "Failed to fetch transactions",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": gin.H{
+			"team_id":          team.ID,
+			"total_points":     team.TotalPoints,
+			"used_points":      used,
+			"remaining_points": team.TotalPoints - used,
+			"transactions":     transactions,
+		},
+	})
+}
+
+// ReconcilePoints reports teams whose ledger, used points and roster prices disagree
+func (h *Handlers) ReconcilePoints(c *gin.Context) {
+	drifts, err := ledger.Reconcile(h.DB)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   "Failed to reconcile points",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": gin.H{
+			"consistent": len(drifts) == 0,
+			"drifts":     drifts,
+		},
+	})
+}
@@ -0,0 +1,388 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime/multipart"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"auction-backend/media"
+	"auction-backend/models"
+	"auction-backend/repository"
+	"auction-backend/storage"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// maxDocumentBytes is the largest document upload accepted
+const maxDocumentBytes = 10 << 20
+
+// storageTimeout bounds each call to the file storage
+const storageTimeout = 30 * time.Second
+
+// documentKinds are the documents a player can upload
+var documentKinds = map[string]bool{"id_proof": true, "age_proof": true, "other": true}
+
+// documentTypes maps the accepted document content types to file extensions
+var documentTypes = map[string]string{
+	"application/pdf": ".pdf",
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+}
+
+// playerForUpload loads the player named in the path and checks the caller is
+// an admin or the player. It writes the error response and returns nil otherwise.
+func (h *Handlers) playerForUpload(c *gin.Context) *models.Player {
+	if h.Storage == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"success": false,
+			"error":   "File storage is not configured",
+		})
+		return nil
+	}
+
+	playerID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid player ID",
+		})
+		return nil
+	}
+
+	player, err := h.Store.Players().GetByID(playerID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"success": false,
+			"error":   "Player not found",
+		})
+		return nil
+	}
+
+	if view := viewerOf(c); view.role != "admin" && !view.isSelf(*player) {
+		c.JSON(http.StatusForbidden, gin.H{
+			"success": false,
+			"error":   "Insufficient permissions",
+		})
+		return nil
+	}
+	return player
+}
+
+// readUpload reads a multipart file field, refusing files over limit
+func readUpload(c *gin.Context, field string, limit int64) ([]byte, *multipart.FileHeader, error) {
+	// Leave room for the multipart headers around the file
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limit+64<<10)
+
+	file, header, err := c.Request.FormFile(field)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return nil, nil, fail(http.StatusRequestEntityTooLarge, fmt.Sprintf("File must be at most %d MB", limit>>20))
+		}
+		return nil, nil, fail(http.StatusBadRequest, fmt.Sprintf("Missing %s file", field))
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, limit+1))
+	if err != nil {
+		return nil, nil, fail(http.StatusBadRequest, "Failed to read upload")
+	}
+	if int64(len(data)) > limit {
+		return nil, nil, fail(http.StatusRequestEntityTooLarge, fmt.Sprintf("File must be at most %d MB", limit>>20))
+	}
+	return data, header, nil
+}
+
+// deleteStored removes files in the background, logging failures; orphaned
+// files waste space but break nothing
+func (h *Handlers) deleteStored(keys ...string) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), storageTimeout)
+		defer cancel()
+		for _, key := range keys {
+			if err := h.Storage.Delete(ctx, key); err != nil {
+				log.Printf("Failed to delete stored file %s: %v", key, err)
+			}
+		}
+	}()
+}
+
+// photoKeys returns the storage keys of a photo's renditions
+func photoKeys(prefix string) (thumb, card string) {
+	return prefix + "-" + media.Thumb.Name + ".jpg", prefix + "-" + media.Card.Name + ".jpg"
+}
+
+// UploadPlayerPhoto stores a player's photo, resized to thumbnail and card
+// sizes. Admins can set any player's photo; players their own.
+func (h *Handlers) UploadPlayerPhoto(c *gin.Context) {
+	player := h.playerForUpload(c)
+	if player == nil {
+		return
+	}
+
+	data, _, err := readUpload(c, "photo", media.MaxPhotoBytes)
+	if err != nil {
+		respondError(c, err, "Failed to read photo")
+		return
+	}
+
+	renditions, err := media.ProcessPhoto(data, media.Thumb, media.Card)
+	if err != nil {
+		status := http.StatusBadRequest
+		if !errors.Is(err, media.ErrUnsupportedPhoto) && !errors.Is(err, media.ErrPhotoTooSmall) && !errors.Is(err, media.ErrPhotoTooLarge) {
+			log.Printf("Failed to process photo for player %s: %v", player.ID, err)
+			status = http.StatusInternalServerError
+		}
+		c.JSON(status, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	// Each upload gets new keys, so caches never serve an old photo
+	prefix := fmt.Sprintf("%splayers/%s/photo-%d", storage.PublicPrefix, player.ID, time.Now().UnixNano())
+	thumbKey, cardKey := photoKeys(prefix)
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), storageTimeout)
+	defer cancel()
+	for key, body := range map[string][]byte{thumbKey: renditions[media.Thumb.Name], cardKey: renditions[media.Card.Name]} {
+		if err := h.Storage.Put(ctx, key, body, "image/jpeg"); err != nil {
+			log.Printf("Failed to store photo %s: %v", key, err)
+			h.deleteStored(thumbKey, cardKey)
+			c.JSON(http.StatusBadGateway, gin.H{
+				"success": false,
+				"error":   "Failed to store photo",
+			})
+			return
+		}
+	}
+
+	oldKey := ""
+	err = h.Store.Transaction(func(tx repository.Store) error {
+		current, err := tx.Players().GetByIDForUpdate(player.ID)
+		if err != nil {
+			return fail(http.StatusNotFound, "Player not found")
+		}
+		before := *current
+		oldKey = current.PhotoKey
+
+		current.PhotoKey = prefix
+		current.PhotoThumbURL = h.Storage.URL(thumbKey)
+		current.PhotoCardURL = h.Storage.URL(cardKey)
+		current.UpdatedAt = time.Now()
+		if err := tx.Players().Save(current); err != nil {
+			return fail(http.StatusInternalServerError, "Failed to update player")
+		}
+		player = current
+		return h.recordAudit(tx, c, "player.photo_updated", "player", current.ID, before, current)
+	})
+	if err != nil {
+		h.deleteStored(thumbKey, cardKey)
+		respondError(c, err, "Failed to update player")
+		return
+	}
+	if oldKey != "" {
+		h.deleteStored(photoKeys(oldKey))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    viewerOf(c).player(*player),
+	})
+}
+
+// DeletePlayerPhoto removes a player's photo
+func (h *Handlers) DeletePlayerPhoto(c *gin.Context) {
+	player := h.playerForUpload(c)
+	if player == nil {
+		return
+	}
+
+	oldKey := ""
+	err := h.Store.Transaction(func(tx repository.Store) error {
+		// Re-read the player so a sale or edit since playerForUpload is kept
+		current, err := tx.Players().GetByIDForUpdate(player.ID)
+		if err != nil {
+			return fail(http.StatusNotFound, "Player not found")
+		}
+		if current.PhotoKey == "" {
+			return fail(http.StatusNotFound, "Player has no photo")
+		}
+		before := *current
+		oldKey = current.PhotoKey
+
+		current.PhotoKey = ""
+		current.PhotoThumbURL = ""
+		current.PhotoCardURL = ""
+		current.UpdatedAt = time.Now()
+		if err := tx.Players().Save(current); err != nil {
+			return fail(http.StatusInternalServerError, "Failed to update player")
+		}
+		player = current
+		return h.recordAudit(tx, c, "player.photo_deleted", "player", current.ID, before, current)
+	})
+	if err != nil {
+		respondError(c, err, "Failed to update player")
+		return
+	}
+	h.deleteStored(photoKeys(oldKey))
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    viewerOf(c).player(*player),
+	})
+}
+
+// UploadPlayerDocument stores a private document for a player's registration:
+// multipart field "document" (PDF, JPEG or PNG) and form field "kind"
+func (h *Handlers) UploadPlayerDocument(c *gin.Context) {
+	player := h.playerForUpload(c)
+	if player == nil {
+		return
+	}
+
+	data, header, err := readUpload(c, "document", maxDocumentBytes)
+	if err != nil {
+		respondError(c, err, "Failed to read document")
+		return
+	}
+
+	kind := c.DefaultPostForm("kind", "other")
+	if !documentKinds[kind] {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Document kind must be id_proof, age_proof or other",
+		})
+		return
+	}
+
+	// Trust the content, not the name or header the browser sent
+	contentType := http.DetectContentType(data)
+	ext, ok := documentTypes[contentType]
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Document must be a PDF, JPEG or PNG file",
+		})
+		return
+	}
+
+	document := models.PlayerDocument{
+		ID:          uuid.New(),
+		PlayerID:    player.ID,
+		Kind:        kind,
+		FileName:    path.Base(strings.ReplaceAll(header.Filename, "\\", "/")),
+		ContentType: contentType,
+		Size:        len(data),
+		UploadedBy:  c.GetString("user_id"),
+		CreatedAt:   time.Now(),
+	}
+	document.StorageKey = fmt.Sprintf("players/%s/documents/%s%s", player.ID, document.ID, ext)
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), storageTimeout)
+	defer cancel()
+	if err := h.Storage.Put(ctx, document.StorageKey, data, contentType); err != nil {
+		log.Printf("Failed to store document %s: %v", document.StorageKey, err)
+		c.JSON(http.StatusBadGateway, gin.H{
+			"success": false,
+			"error":   "Failed to store document",
+		})
+		return
+	}
+
+	err = h.Store.Transaction(func(tx repository.Store) error {
+		if err := tx.PlayerDocuments().Create(&document); err != nil {
+			return fail(http.StatusInternalServerError, "Failed to save document")
+		}
+		return h.recordAudit(tx, c, "player.document_uploaded", "player", player.ID, nil, document)
+	})
+	if err != nil {
+		h.deleteStored(document.StorageKey)
+		respondError(c, err, "Failed to save document")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    document,
+	})
+}
+
+// GetPlayerDocuments lists a player's documents
+func (h *Handlers) GetPlayerDocuments(c *gin.Context) {
+	player := h.playerForUpload(c)
+	if player == nil {
+		return
+	}
+
+	documents, err := h.Store.PlayerDocuments().ListByPlayer(player.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   "Failed to fetch documents",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    documents,
+	})
+}
+
+// DownloadPlayerDocument streams one of a player's documents
+func (h *Handlers) DownloadPlayerDocument(c *gin.Context) {
+	player := h.playerForUpload(c)
+	if player == nil {
+		return
+	}
+
+	documentID, err := uuid.Parse(c.Param("documentId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid document ID",
+		})
+		return
+	}
+
+	document, err := h.Store.PlayerDocuments().GetByID(documentID)
+	if err != nil || document.PlayerID != player.ID {
+		c.JSON(http.StatusNotFound, gin.H{
+			"success": false,
+			"error":   "Document not found",
+		})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), storageTimeout)
+	defer cancel()
+	body, err := h.Storage.Get(ctx, document.StorageKey)
+	if err != nil {
+		log.Printf("Failed to read document %s: %v", document.StorageKey, err)
+		status := http.StatusBadGateway
+		if errors.Is(err, storage.ErrNotFound) {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, gin.H{
+			"success": false,
+			"error":   "Failed to read document",
+		})
+		return
+	}
+	defer body.Close()
+
+	c.Header("Content-Disposition", "attachment; filename="+strconv.Quote(document.FileName))
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.DataFromReader(http.StatusOK, int64(document.Size), document.ContentType, body, nil)
+}
@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"net/http"
+	"testing"
+
+	"auction-backend/models"
+	"auction-backend/repository"
+	"auction-backend/storage"
+)
+
+// saleFirst sells a player just before each transaction starts, as an admin
+// selling them between a handler's first read and its write would
+type saleFirst struct {
+	repository.Store
+	player *models.Player
+	team   *models.Team
+}
+
+func (s saleFirst) Transaction(fn func(tx repository.Store) error) error {
+	player, err := s.Store.Players().GetByID(s.player.ID)
+	if err != nil {
+		return err
+	}
+	player.IsSold = true
+	player.CurrentTeamID = &s.team.ID
+	player.CurrentPrice = 700
+	if err := s.Store.Players().Save(player); err != nil {
+		return err
+	}
+	return s.Store.Transaction(fn)
+}
+
+func TestDeletePlayerPhotoKeepsConcurrentSale(t *testing.T) {
+	s := newTestServer(t)
+	files, err := storage.NewLocal(t.TempDir(), "http://localhost/files")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.h.Storage = files
+
+	team := s.team("Smashers", 12000)
+	player := s.player("asha")
+	player.PhotoKey = "public/players/asha/photo-1"
+	player.PhotoThumbURL = "http://localhost/files/players/asha/photo-1-thumb.jpg"
+	player.PhotoCardURL = "http://localhost/files/players/asha/photo-1-card.jpg"
+	if err := s.store.Players().Save(player); err != nil {
+		t.Fatal(err)
+	}
+	s.h.Store = saleFirst{Store: s.store, player: player, team: team}
+
+	status, body := s.do(testAdmin, http.MethodDelete, "/players/:id/photo", "/players/"+player.ID.String()+"/photo", s.h.DeletePlayerPhoto, nil)
+	if status != http.StatusOK {
+		t.Fatalf("delete photo: status %d, body %v", status, body)
+	}
+
+	got := s.reloadPlayer(player.ID)
+	if got.PhotoKey != "" || got.PhotoThumbURL != "" || got.PhotoCardURL != "" {
+		t.Fatalf("photo left on player: %q %q %q", got.PhotoKey, got.PhotoThumbURL, got.PhotoCardURL)
+	}
+	if !got.IsSold || got.CurrentTeamID == nil || *got.CurrentTeamID != team.ID || got.CurrentPrice != 700 {
+		t.Fatalf("sale overwritten: sold %v, team %v, price %d", got.IsSold, got.CurrentTeamID, got.CurrentPrice)
+	}
+}
@@ -0,0 +1,180 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"auction-backend/models"
+	"auction-backend/repository"
+	"auction-backend/websocket"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// overlayRecentSales is how many sales the overlay lists
+const overlayRecentSales = 5
+
+// OverlayPlayer is the public card for a player. It never carries contact
+// details, date of birth or the player's login.
+type OverlayPlayer struct {
+	ID              uuid.UUID `json:"id"`
+	Name            string    `json:"name"`
+	PlayerCategory  string    `json:"player_category"`
+	PlayingCategory string    `json:"playing_category"`
+	Accomplishments string    `json:"accomplishments"`
+	BasePrice       int       `json:"base_price"`
+	PhotoThumbURL   string    `json:"photo_thumb_url"`
+	PhotoCardURL    string    `json:"photo_card_url"`
+}
+
+// OverlayTeam is a team as shown on the overlay, without its budget
+type OverlayTeam struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+}
+
+// OverlaySale is a player sold earlier in the auction
+type OverlaySale struct {
+	Player OverlayPlayer `json:"player"`
+	Team   OverlayTeam   `json:"team"`
+	Price  int           `json:"price"`
+	SoldAt time.Time     `json:"sold_at"`
+}
+
+// Overlay is the public view of an auction for stream overlays. AsOf orders
+// overlays: a client keeps the newest it has received.
+type Overlay struct {
+	AuctionID     uuid.UUID      `json:"auction_id"`
+	Title         string         `json:"title"`
+	Status        string         `json:"status"`
+	CurrentPlayer *OverlayPlayer `json:"current_player"`
+	CurrentBid    int            `json:"current_bid"`
+	LeadingTeam   *OverlayTeam   `json:"leading_team"`
+	RecentSales   []OverlaySale  `json:"recent_sales"`
+	AsOf          time.Time      `json:"as_of"`
+}
+
+func newOverlayPlayer(player models.Player) OverlayPlayer {
+	return OverlayPlayer{
+		ID:              player.ID,
+		Name:            player.Name,
+		PlayerCategory:  player.GetPlayerCategory(),
+		PlayingCategory: player.PlayingCategory,
+		Accomplishments: player.Accomplishments,
+		BasePrice:       player.BasePrice,
+		PhotoThumbURL:   player.PhotoThumbURL,
+		PhotoCardURL:    player.PhotoCardURL,
+	}
+}
+
+// buildOverlay reads an auction's overlay as of one point in time
+func (h *Handlers) buildOverlay(auctionID uuid.UUID) (Overlay, error) {
+	var overlay Overlay
+	err := h.Store.Snapshot(func(tx repository.Store) error {
+		auction, err := tx.Auctions().GetByID(auctionID)
+		if err != nil {
+			return fail(http.StatusNotFound, "Auction not found")
+		}
+
+		overlay = Overlay{
+			AuctionID:   auction.ID,
+			Title:       auction.Title,
+			Status:      auction.Status,
+			CurrentBid:  auction.CurrentBid,
+			RecentSales: []OverlaySale{},
+			AsOf:        time.Now(),
+		}
+
+		if auction.CurrentPlayerID != nil {
+			player, err := tx.Players().GetByID(*auction.CurrentPlayerID)
+			if err != nil && !errors.Is(err, repository.ErrNotFound) {
+				return err
+			}
+			if player != nil {
+				card := newOverlayPlayer(*player)
+				overlay.CurrentPlayer = &card
+			}
+		}
+
+		if auction.CurrentPlayerID != nil && auction.WinningTeamID != nil {
+			team, err := tx.Teams().GetByID(*auction.WinningTeamID)
+			if err != nil && !errors.Is(err, repository.ErrNotFound) {
+				return err
+			}
+			if team != nil {
+				overlay.LeadingTeam = &OverlayTeam{ID: team.ID, Name: team.Name}
+			}
+		}
+
+		purchases, err := tx.PointsTransactions().ListRecentPurchases(auction.ID, overlayRecentSales)
+		if err != nil {
+			return err
+		}
+		for _, purchase := range purchases {
+			if purchase.PlayerID == nil {
+				continue
+			}
+			player, err := tx.Players().GetByID(*purchase.PlayerID)
+			if err != nil {
+				continue
+			}
+			team, err := tx.Teams().GetByID(purchase.TeamID)
+			if err != nil {
+				continue
+			}
+			overlay.RecentSales = append(overlay.RecentSales, OverlaySale{
+				Player: newOverlayPlayer(*player),
+				Team:   OverlayTeam{ID: team.ID, Name: team.Name},
+				Price:  purchase.Amount,
+				SoldAt: purchase.CreatedAt,
+			})
+		}
+		return nil
+	})
+	return overlay, err
+}
+
+// publishOverlay sends the auction's current overlay to its spectators
+func (h *Handlers) publishOverlay(auctionID uuid.UUID) {
+	overlay, err := h.buildOverlay(auctionID)
+	if err != nil {
+		log.Printf("Failed to build overlay for auction %s: %v", auctionID, err)
+		return
+	}
+	h.Hub.Publish(websocket.OverlayRoom(auctionID.String()), "overlay", overlay)
+}
+
+// GetOverlay returns the public overlay for an auction: the current player's
+// card, live bid, leading team and recent sales
+func (h *Handlers) GetOverlay(c *gin.Context) {
+	auctionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid auction ID",
+		})
+		return
+	}
+
+	overlay, err := h.buildOverlay(auctionID)
+	if err != nil {
+		respondError(c, err, "Failed to fetch overlay")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    overlay,
+	})
+}
+
+// SpectateOverlay streams an auction's overlay over a read-only WebSocket
+// that needs no login
+func (h *Handlers) SpectateOverlay(c *gin.Context) {
+	h.Hub.HandleSpectator(c, func(auctionID uuid.UUID) (interface{}, error) {
+		return h.buildOverlay(auctionID)
+	})
+}
@@ -0,0 +1,273 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"auction-backend/models"
+	"auction-backend/repository"
+	"auction-backend/websocket"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// Auction outcomes a player can see for themselves
+const (
+	OutcomePending      = "pending"        // registration awaiting review
+	OutcomeRejected     = "rejected"       // registration rejected
+	OutcomeInPool       = "in_pool"        // approved and waiting to be auctioned
+	OutcomeUpForBidding = "up_for_bidding" // the current lot of the active auction
+	OutcomeSold         = "sold"
+	OutcomeRetained     = "retained"
+)
+
+// PlayerProfileRequest is the profile a player submits for review
+type PlayerProfileRequest struct {
+	Name            string `json:"name" binding:"required"`
+	Gender          string `json:"gender" binding:"required,oneof=male female"`
+	DateOfBirth     string `json:"date_of_birth" binding:"required"`
+	Mobile          string `json:"mobile" binding:"required"`
+	PlayingCategory string `json:"playing_category" binding:"required,oneof=singles doubles both"`
+	Accomplishments string `json:"accomplishments"`
+}
+
+// PlayerOutcome is where a player stands in the auction
+type PlayerOutcome struct {
+	Status             string       `json:"status"`
+	RegistrationStatus string       `json:"registration_status"`
+	ReviewReason       string       `json:"review_reason,omitempty"`
+	Team               *OverlayTeam `json:"team"`
+	Price              int          `json:"price"`
+}
+
+// currentUserID returns the logged-in user's ID
+func currentUserID(c *gin.Context) (uuid.UUID, bool) {
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	return userID, err == nil
+}
+
+// GetMyPlayerProfile returns the logged-in player's profile and review status
+func (h *Handlers) GetMyPlayerProfile(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"success": false,
+			"error":   "Invalid user",
+		})
+		return
+	}
+
+	player, err := h.Store.Players().GetByUserID(userID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"success": false,
+			"error":   "No profile submitted yet",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    viewerOf(c).player(*player),
+	})
+}
+
+// SubmitPlayerProfile creates or updates the logged-in player's profile and
+// puts it up for review. A rejected profile can be corrected and resubmitted;
+// an approved one can only be changed by an admin.
+func (h *Handlers) SubmitPlayerProfile(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"success": false,
+			"error":   "Invalid user",
+		})
+		return
+	}
+
+	var req PlayerProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid profile data",
+		})
+		return
+	}
+
+	dob, err := time.Parse("2006-01-02", req.DateOfBirth)
+	if err != nil || !dob.Before(time.Now()) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid date format",
+		})
+		return
+	}
+
+	var player *models.Player
+	created := false
+	err = h.Store.Transaction(func(tx repository.Store) error {
+		if _, err := tx.Users().GetByID(userID); err != nil {
+			return fail(http.StatusUnauthorized, "User not found")
+		}
+
+		var before interface{}
+		existing, err := tx.Players().GetByUserID(userID)
+		switch {
+		case errors.Is(err, repository.ErrNotFound):
+			created = true
+			player = &models.Player{
+				UserID:       userID,
+				BasePrice:    basePricePerPlayer,
+				CurrentPrice: basePricePerPlayer,
+			}
+		case err != nil:
+			return err
+		case existing.RegistrationStatus == models.RegistrationApproved:
+			return fail(http.StatusConflict, "Profile is already approved; ask an admin to change it")
+		default:
+			before = *existing
+			player = existing
+		}
+
+		player.Name = strings.TrimSpace(req.Name)
+		player.Gender = req.Gender
+		player.DateOfBirth = dob
+		player.Mobile = strings.TrimSpace(req.Mobile)
+		player.PlayingCategory = req.PlayingCategory
+		player.Accomplishments = req.Accomplishments
+		player.RegistrationStatus = models.RegistrationPending
+		player.ReviewReason = ""
+		player.ReviewedBy = ""
+		player.ReviewedAt = nil
+		player.UpdatedAt = time.Now()
+
+		if created {
+			player.CreatedAt = player.UpdatedAt
+			err = tx.Players().Create(player)
+		} else {
+			err = tx.Players().Save(player)
+		}
+		if err != nil {
+			return fail(http.StatusInternalServerError, "Failed to save profile")
+		}
+		return h.recordAudit(tx, c, "player.registration_submitted", "player", player.ID, before, player)
+	})
+	if err != nil {
+		respondError(c, err, "Failed to save profile")
+		return
+	}
+
+	// Let admins know there is a registration to review
+	h.Hub.Publish(websocket.AdminRoom, "player_registered", gin.H{
+		"player_id": player.ID,
+		"name":      player.Name,
+	})
+
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+	c.JSON(status, gin.H{
+		"success": true,
+		"data":    viewerOf(c).player(*player),
+	})
+}
+
+// GetMyAuctionOutcome tells the logged-in player where they stand: under
+// review, in the pool, up for bidding, or sold or retained and to which team
+func (h *Handlers) GetMyAuctionOutcome(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"success": false,
+			"error":   "Invalid user",
+		})
+		return
+	}
+
+	var outcome PlayerOutcome
+	err := h.Store.Snapshot(func(tx repository.Store) error {
+		player, err := tx.Players().GetByUserID(userID)
+		if err != nil {
+			return fail(http.StatusNotFound, "No profile submitted yet")
+		}
+
+		outcome = PlayerOutcome{
+			RegistrationStatus: player.RegistrationStatus,
+			ReviewReason:       player.ReviewReason,
+		}
+
+		teamID := player.CurrentTeamID
+		switch {
+		case player.RegistrationStatus == models.RegistrationPending:
+			outcome.Status = OutcomePending
+		case player.RegistrationStatus == models.RegistrationRejected:
+			outcome.Status = OutcomeRejected
+		case player.IsRetained:
+			outcome.Status = OutcomeRetained
+			teamID = player.RetainedBy
+			outcome.Price = player.CurrentPrice
+		case player.IsSold:
+			outcome.Status = OutcomeSold
+			outcome.Price = player.CurrentPrice
+		default:
+			outcome.Status = OutcomeInPool
+			auction, err := tx.Auctions().GetActive()
+			if err == nil && auction.CurrentPlayerID != nil && *auction.CurrentPlayerID == player.ID {
+				outcome.Status = OutcomeUpForBidding
+			}
+		}
+
+		if teamID != nil && (outcome.Status == OutcomeSold || outcome.Status == OutcomeRetained) {
+			team, err := tx.Teams().GetByID(*teamID)
+			if err != nil && !errors.Is(err, repository.ErrNotFound) {
+				return err
+			}
+			if team != nil {
+				outcome.Team = &OverlayTeam{ID: team.ID, Name: team.Name}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		respondError(c, err, "Failed to fetch auction outcome")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    outcome,
+	})
+}
+
+// GetPlayerRegistrations lists registrations for review, pending by default
+// or with ?status=approved|rejected
+func (h *Handlers) GetPlayerRegistrations(c *gin.Context) {
+	status := c.DefaultQuery("status", models.RegistrationPending)
+	switch status {
+	case models.RegistrationPending, models.RegistrationApproved, models.RegistrationRejected:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid registration status",
+		})
+		return
+	}
+
+	players, err := h.Store.Players().List(repository.PlayerFilter{RegistrationStatus: status})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   "Failed to fetch registrations",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    viewerOf(c).players(players),
+	})
+}
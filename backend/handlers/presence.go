@@ -0,0 +1,167 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"auction-backend/repository"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// Presence rules for opening a lot, chosen with PRESENCE_RULE
+const (
+	PresenceOff     = "off"     // open lots regardless of who is connected
+	PresenceWarn    = "warn"    // open lots, listing the teams that are not connected
+	PresenceEnforce = "enforce" // refuse unless every team is connected or the admin overrides
+)
+
+// TeamPresence is whether a team has a live connection to the auction
+type TeamPresence struct {
+	TeamID      uuid.UUID `json:"team_id"`
+	Name        string    `json:"name"`
+	Online      bool      `json:"online"`
+	Connections int       `json:"connections"`
+}
+
+// teamPresence returns every team's presence and the teams that are offline
+func (h *Handlers) teamPresence() (teams, missing []TeamPresence, err error) {
+	connections, err := h.Hub.Presence()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	all, err := h.Store.Teams().ListWithPlayers()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	teams = make([]TeamPresence, 0, len(all))
+	missing = []TeamPresence{}
+	for _, team := range all {
+		presence := TeamPresence{
+			TeamID:      team.ID,
+			Name:        team.Name,
+			Connections: connections[team.ID.String()],
+		}
+		presence.Online = presence.Connections > 0
+		teams = append(teams, presence)
+		if !presence.Online {
+			missing = append(missing, presence)
+		}
+	}
+	return teams, missing, nil
+}
+
+// GetAuctionPresence reports which teams are connected before a lot is opened
+func (h *Handlers) GetAuctionPresence(c *gin.Context) {
+	auctionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid auction ID",
+		})
+		return
+	}
+
+	if _, err := h.Store.Auctions().GetByID(auctionID); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"success": false,
+			"error":   "Auction not found",
+		})
+		return
+	}
+
+	teams, missing, err := h.teamPresence()
+	if err != nil {
+		log.Printf("Failed to read presence: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   "Failed to fetch team presence",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": gin.H{
+			"auction_id":    auctionID,
+			"teams":         teams,
+			"online_count":  len(teams) - len(missing),
+			"team_count":    len(teams),
+			"all_present":   len(missing) == 0,
+			"missing_teams": missing,
+			"rule":          h.presenceRule(),
+		},
+	})
+}
+
+// presenceRule returns the configured rule, defaulting to off
+func (h *Handlers) presenceRule() string {
+	switch h.PresenceRule {
+	case PresenceWarn, PresenceEnforce:
+		return h.PresenceRule
+	}
+	return PresenceOff
+}
+
+// checkPresence applies the presence rule before a lot opens. Under enforce it
+// writes a 409 and returns false when teams are missing, unless the request
+// has override=true; the teams overridden are returned for the caller to audit
+// with recordPresenceOverride. Otherwise it returns a warning to add to the
+// response, or nil when every team is present or the rule is off.
+func (h *Handlers) checkPresence(c *gin.Context) (warning gin.H, overridden []TeamPresence, ok bool) {
+	rule := h.presenceRule()
+	if rule == PresenceOff {
+		return nil, nil, true
+	}
+	override := c.Query("override") == "true"
+
+	_, missing, err := h.teamPresence()
+	if err != nil {
+		log.Printf("Failed to read presence: %v", err)
+		if rule == PresenceEnforce && !override {
+			c.JSON(http.StatusServiceUnavailable, gin.H{
+				"success": false,
+				"error":   "Failed to check team presence. Retry, or pass override=true to continue anyway.",
+				"code":    "presence_unavailable",
+			})
+			return nil, nil, false
+		}
+		return gin.H{"error": "Team presence could not be checked"}, nil, true
+	}
+	if len(missing) == 0 {
+		return nil, nil, true
+	}
+
+	if rule == PresenceEnforce {
+		if !override {
+			c.JSON(http.StatusConflict, gin.H{
+				"success": false,
+				"error":   "Not all teams are connected. Pass override=true to continue anyway.",
+				"code":    "teams_not_present",
+				"data":    gin.H{"missing_teams": missing},
+			})
+			return nil, nil, false
+		}
+		overridden = missing
+	}
+
+	return gin.H{
+		"missing_teams": missing,
+		"overridden":    overridden != nil,
+	}, overridden, true
+}
+
+// recordPresenceOverride audits opening a lot with the overridden teams
+// missing, in the transaction that opens it. It does nothing when none were.
+func (h *Handlers) recordPresenceOverride(tx repository.Store, c *gin.Context, auctionID uuid.UUID, action string, overridden []TeamPresence) error {
+	if len(overridden) == 0 {
+		return nil
+	}
+	return h.recordAudit(tx, c, "presence.overridden", "auction", auctionID, nil, gin.H{
+		"action":        action,
+		"missing_teams": overridden,
+	})
+}
@@ -0,0 +1,588 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"auction-backend/ledger"
+	"auction-backend/models"
+	"auction-backend/repository"
+	"auction-backend/websocket"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// defaultReleaseRefundPercent is the share of a released player's price
+// returned to the team when RELEASE_REFUND_PERCENT is not set
+const defaultReleaseRefundPercent = 50
+
+// ReleaseView is a release with the names of its team, player and replacement
+type ReleaseView struct {
+	models.Release
+	TeamName        string `json:"team_name"`
+	PlayerName      string `json:"player_name"`
+	ReplacementName string `json:"replacement_name,omitempty"`
+}
+
+// releaseViews names the team and players of each release
+func releaseViews(store repository.Store, releases []models.Release) []ReleaseView {
+	teamNames := map[uuid.UUID]string{}
+	playerName := func(id uuid.UUID) string {
+		if player, err := store.Players().GetByID(id); err == nil {
+			return player.Name
+		}
+		return ""
+	}
+
+	views := make([]ReleaseView, 0, len(releases))
+	for _, release := range releases {
+		if _, ok := teamNames[release.TeamID]; !ok {
+			if team, err := store.Teams().GetByID(release.TeamID); err == nil {
+				teamNames[release.TeamID] = team.Name
+			}
+		}
+		view := ReleaseView{
+			Release:    release,
+			TeamName:   teamNames[release.TeamID],
+			PlayerName: playerName(release.PlayerID),
+		}
+		if release.ReplacementPlayerID != nil {
+			view.ReplacementName = playerName(*release.ReplacementPlayerID)
+		}
+		views = append(views, view)
+	}
+	return views
+}
+
+// publishRelease tells the team and the admins that a release changed, and
+// sends the team's new budget when points moved
+func (h *Handlers) publishRelease(release *models.Release, pointsMoved bool) {
+	view := releaseViews(h.Store, []models.Release{*release})[0]
+	h.Hub.Publish(websocket.TeamRoom(release.TeamID.String()), "release_updated", view)
+	h.Hub.Publish(websocket.AdminRoom, "release_updated", view)
+
+	if pointsMoved {
+		if team, err := h.Store.Teams().GetByID(release.TeamID); err == nil {
+			h.Hub.Publish(websocket.TeamRoom(team.ID.String()), "team_updated", team)
+			h.Hub.Publish(websocket.AdminRoom, "team_updated", team)
+		}
+	}
+}
+
+// replacementAvailable reports whether the player is in the replacement pool:
+// approved, unsold and not on or retained by any team
+func replacementAvailable(player *models.Player) bool {
+	return player.RegistrationStatus == models.RegistrationApproved &&
+		!player.IsSold && !player.IsRetained && player.CurrentTeamID == nil
+}
+
+// signReplacement records player as the replacement filling the release's slot, in tx
+func signReplacement(tx repository.Store, release *models.Release, player *models.Player, price int) error {
+	now := time.Now()
+	release.ReplacementPlayerID = &player.ID
+	release.ReplacementPrice = price
+	release.ReplacedAt = &now
+	if err := tx.Releases().Save(release); err != nil {
+		return fail(http.StatusInternalServerError, "Failed to update release")
+	}
+	return nil
+}
+
+// fillReplacementSlot signs a player just bought in a supplementary auction
+// into the team's oldest open replacement slot. It returns nil when the team
+// has no open slot.
+func fillReplacementSlot(tx repository.Store, teamID uuid.UUID, player *models.Player, price int) (*models.Release, error) {
+	open, err := tx.Releases().ListOpen(teamID)
+	if err != nil {
+		return nil, fail(http.StatusInternalServerError, "Failed to load replacement slots")
+	}
+	if len(open) == 0 {
+		return nil, nil
+	}
+	release := open[0]
+	if err := signReplacement(tx, &release, player, price); err != nil {
+		return nil, err
+	}
+	return &release, nil
+}
+
+// RequestRelease asks the admins to release a player from the team's squad:
+// {"player_id": "...", "reason": "Injured"}
+func (h *Handlers) RequestRelease(c *gin.Context) {
+	teamUUID, err := uuid.Parse(c.GetString("team_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid team ID",
+		})
+		return
+	}
+
+	var req struct {
+		PlayerID string `json:"player_id" binding:"required"`
+		Reason   string `json:"reason"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid request data",
+		})
+		return
+	}
+
+	playerUUID, err := uuid.Parse(req.PlayerID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid player ID",
+		})
+		return
+	}
+
+	req.Reason = strings.TrimSpace(req.Reason)
+	if req.Reason == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "A reason is required to release a player",
+		})
+		return
+	}
+
+	release := models.Release{
+		TeamID:      teamUUID,
+		PlayerID:    playerUUID,
+		Status:      models.ReleaseRequested,
+		Reason:      req.Reason,
+		RequestedBy: c.GetString("user_id"),
+	}
+	// Check the player with their team and them locked, as trades and
+	// reviews lock them, so none can slip in between the checks and the insert
+	err = h.Store.Transaction(func(tx repository.Store) error {
+		if _, err := tx.Teams().GetByIDForUpdate(teamUUID); err != nil {
+			return fail(http.StatusNotFound, "Team not found")
+		}
+		player, err := tx.Players().GetByIDForUpdate(playerUUID)
+		if err != nil || !player.IsSold || player.CurrentTeamID == nil || *player.CurrentTeamID != teamUUID {
+			return fail(http.StatusNotFound, "Player is not on your team")
+		}
+		pending, err := hasPendingRelease(tx, teamUUID, player.ID)
+		if err != nil {
+			return fail(http.StatusInternalServerError, "Failed to request release")
+		}
+		if pending {
+			return fail(http.StatusConflict, "A release of this player is already awaiting review")
+		}
+		if err := checkNoOpenTrade(tx, player); err != nil {
+			return err
+		}
+
+		release.Price = player.CurrentPrice
+		if err := tx.Releases().Create(&release); err != nil {
+			return fail(http.StatusInternalServerError, "Failed to request release")
+		}
+		return h.recordAudit(tx, c, "release.requested", "release", release.ID, nil, release)
+	})
+	if err != nil {
+		respondError(c, err, "Failed to request release")
+		return
+	}
+
+	h.publishRelease(&release, false)
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    releaseViews(h.Store, []models.Release{release})[0],
+	})
+}
+
+// GetMyReleases returns the team's releases, newest first. Approved releases
+// with no replacement are the team's open replacement slots.
+func (h *Handlers) GetMyReleases(c *gin.Context) {
+	teamUUID, err := uuid.Parse(c.GetString("team_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid team ID",
+		})
+		return
+	}
+
+	releases, err := h.Store.Releases().List(repository.ReleaseFilter{TeamID: &teamUUID, Status: c.Query("status")})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   "Failed to fetch releases",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    releaseViews(h.Store, releases),
+	})
+}
+
+// GetReplacementPool returns the players a team can sign as replacements
+func (h *Handlers) GetReplacementPool(c *gin.Context) {
+	players, err := h.Store.Players().List(repository.PlayerFilter{
+		Status:             "unsold",
+		RegistrationStatus: models.RegistrationApproved,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   "Failed to fetch replacement pool",
+		})
+		return
+	}
+
+	pool := []models.Player{}
+	for i := range players {
+		if replacementAvailable(&players[i]) {
+			pool = append(pool, players[i])
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    viewerOf(c).players(pool),
+	})
+}
+
+// PickReplacement signs a player from the replacement pool into one of the
+// team's open replacement slots at the player's base price: {"player_id": "..."}
+func (h *Handlers) PickReplacement(c *gin.Context) {
+	teamUUID, err := uuid.Parse(c.GetString("team_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid team ID",
+		})
+		return
+	}
+	releaseUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid release ID",
+		})
+		return
+	}
+
+	var req struct {
+		PlayerID string `json:"player_id" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid request data",
+		})
+		return
+	}
+	playerUUID, err := uuid.Parse(req.PlayerID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid player ID",
+		})
+		return
+	}
+
+	var release *models.Release
+	var player *models.Player
+	err = h.Store.Transaction(func(tx repository.Store) error {
+		if _, err := tx.Auctions().GetActive(); err == nil {
+			return fail(http.StatusConflict, "Replacements cannot be picked while an auction is running")
+		}
+
+		team, err := tx.Teams().GetByIDForUpdate(teamUUID)
+		if err != nil {
+			return fail(http.StatusNotFound, "Team not found")
+		}
+
+		release, err = tx.Releases().GetByIDForUpdate(releaseUUID)
+		if err != nil || release.TeamID != team.ID {
+			return fail(http.StatusNotFound, "Release not found")
+		}
+		if release.Status != models.ReleaseApproved {
+			return fail(http.StatusConflict, "Only an approved release opens a replacement slot")
+		}
+		if release.ReplacementPlayerID != nil {
+			return fail(http.StatusConflict, "This replacement slot is already filled")
+		}
+
+		player, err = tx.Players().GetByID(playerUUID)
+		if err != nil || !replacementAvailable(player) {
+			return fail(http.StatusConflict, "Player is not in the replacement pool")
+		}
+		if player.ID == release.PlayerID {
+			return fail(http.StatusConflict, "A team cannot sign the player it released as their replacement")
+		}
+
+		price := max(player.BasePrice, basePricePerPlayer)
+		if team.PlayerCount >= team.MaxPlayers {
+			return fail(http.StatusConflict, fmt.Sprintf("%s already has its maximum of %d players", team.Name, team.MaxPlayers))
+		}
+		if price > team.TotalPoints-team.UsedPoints {
+			return failCode(http.StatusBadRequest, reasonInsufficientPoints, "Insufficient points")
+		}
+
+		playerBefore := *player
+		player.IsSold = true
+		player.CurrentTeamID = &team.ID
+		player.CurrentPrice = price
+		player.UpdatedAt = time.Now()
+		if err := tx.Players().Save(player); err != nil {
+			return fail(http.StatusInternalServerError, "Failed to update player")
+		}
+
+		if err := tx.PointsTransactions().Record(&models.PointsTransaction{
+			TeamID:    team.ID,
+			PlayerID:  &player.ID,
+			Type:      ledger.TypePurchase,
+			Amount:    price,
+			Note:      fmt.Sprintf("Replacement pick for release %s", release.ID),
+			CreatedBy: c.GetString("user_id"),
+		}); err != nil {
+			return fail(http.StatusInternalServerError, "Failed to update team points")
+		}
+		if team, err = tx.Teams().GetByID(team.ID); err != nil {
+			return fail(http.StatusInternalServerError, "Failed to update team points")
+		}
+		team.PlayerCount += 1
+		if err := tx.Teams().Save(team); err != nil {
+			return fail(http.StatusInternalServerError, "Failed to update team")
+		}
+
+		releaseBefore := *release
+		if err := signReplacement(tx, release, player, price); err != nil {
+			return err
+		}
+		return h.recordAudit(tx, c, "release.replacement_picked", "release", release.ID, gin.H{
+			"release": releaseBefore,
+			"player":  playerBefore,
+		}, gin.H{
+			"release": release,
+			"player":  player,
+			"team":    team,
+		})
+	})
+	if err != nil {
+		respondError(c, err, "Failed to pick replacement")
+		return
+	}
+
+	h.publishRelease(release, true)
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    releaseViews(h.Store, []models.Release{*release})[0],
+	})
+}
+
+// GetReleases returns every release, newest first, optionally filtered by status
+func (h *Handlers) GetReleases(c *gin.Context) {
+	releases, err := h.Store.Releases().List(repository.ReleaseFilter{Status: c.Query("status")})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   "Failed to fetch releases",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    releaseViews(h.Store, releases),
+	})
+}
+
+// ReviewRelease approves or rejects a release request: {"approved": true,
+// "refund_percent": 50} or {"approved": false, "reason": "..."}. Approval
+// refunds refund_percent of the player's price (the configured share when
+// omitted), returns the player to the pool and opens a replacement slot, all
+// in one transaction.
+func (h *Handlers) ReviewRelease(c *gin.Context) {
+	releaseUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid release ID",
+		})
+		return
+	}
+
+	var req struct {
+		Approved      bool   `json:"approved"`
+		Reason        string `json:"reason"`
+		RefundPercent *int   `json:"refund_percent"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid request data",
+		})
+		return
+	}
+
+	req.Reason = strings.TrimSpace(req.Reason)
+	percent := h.ReleaseRefundPercent
+	if req.RefundPercent != nil {
+		percent = *req.RefundPercent
+	}
+	var invalid string
+	switch {
+	case !req.Approved && req.Reason == "":
+		invalid = "A reason is required to reject a release"
+	case percent < 0 || percent > 100:
+		invalid = "Refund percent must be between 0 and 100"
+	}
+	if invalid != "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   invalid,
+		})
+		return
+	}
+
+	var release *models.Release
+	err = h.Store.Transaction(func(tx repository.Store) error {
+		var err error
+		release, err = tx.Releases().GetByIDForUpdate(releaseUUID)
+		if err != nil {
+			return fail(http.StatusNotFound, "Release not found")
+		}
+		if release.Status != models.ReleaseRequested {
+			return fail(http.StatusConflict, "Release has already been reviewed")
+		}
+
+		before := *release
+		now := time.Now()
+		release.ReviewedBy = c.GetString("user_id")
+		release.ReviewReason = req.Reason
+		release.ReviewedAt = &now
+		release.Status = models.ReleaseRejected
+		action := "release.rejected"
+		if req.Approved {
+			if err := h.settleRelease(tx, c, release, percent); err != nil {
+				return err
+			}
+			release.Status = models.ReleaseApproved
+			action = "release.approved"
+		}
+
+		if err := tx.Releases().Save(release); err != nil {
+			return fail(http.StatusInternalServerError, "Failed to update release")
+		}
+		return h.recordAudit(tx, c, action, "release", release.ID, before, release)
+	})
+	if err != nil {
+		respondError(c, err, "Failed to review release")
+		return
+	}
+
+	h.publishRelease(release, release.Status == models.ReleaseApproved)
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    releaseViews(h.Store, []models.Release{*release})[0],
+	})
+}
+
+// hasPendingRelease reports whether the team has asked to release the player
+// and the request awaits review
+func hasPendingRelease(store repository.Store, teamID, playerID uuid.UUID) (bool, error) {
+	pending, err := store.Releases().List(repository.ReleaseFilter{TeamID: &teamID, Status: models.ReleaseRequested})
+	if err != nil {
+		return false, err
+	}
+	for _, release := range pending {
+		if release.PlayerID == playerID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// checkNoOpenTrade refuses to release a player that a proposed or accepted
+// trade of their team would move, since settling it would then fail
+func checkNoOpenTrade(store repository.Store, player *models.Player) error {
+	for _, status := range []string{models.TradeProposed, models.TradeAccepted} {
+		trades, err := store.Trades().List(repository.TradeFilter{TeamID: player.CurrentTeamID, Status: status})
+		if err != nil {
+			return fail(http.StatusInternalServerError, "Failed to check open trades")
+		}
+		for _, trade := range trades {
+			for _, item := range trade.Items {
+				if item.PlayerID == player.ID {
+					return fail(http.StatusConflict, fmt.Sprintf(
+						"%s is part of an open trade; it must be cancelled, declined or rejected first", player.Name))
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// settleRelease takes the player off the team and back into the pool at
+// their base price, and refunds percent of the price they were bought at, in tx
+func (h *Handlers) settleRelease(tx repository.Store, c *gin.Context, release *models.Release, percent int) error {
+	if _, err := tx.Auctions().GetActive(); err == nil {
+		return fail(http.StatusConflict, "Releases cannot be approved while an auction is running")
+	}
+
+	team, err := tx.Teams().GetByIDForUpdate(release.TeamID)
+	if err != nil {
+		return fail(http.StatusNotFound, "Team not found")
+	}
+	player, err := tx.Players().GetByID(release.PlayerID)
+	if err != nil {
+		return fail(http.StatusNotFound, "Player not found")
+	}
+	if !player.IsSold || player.CurrentTeamID == nil || *player.CurrentTeamID != team.ID {
+		return fail(http.StatusConflict, fmt.Sprintf("%s is no longer on %s", player.Name, team.Name))
+	}
+	if err := checkNoOpenTrade(tx, player); err != nil {
+		return err
+	}
+
+	release.Price = player.CurrentPrice
+	release.RefundPercent = percent
+	release.RefundAmount = release.Price * percent / 100
+
+	player.IsSold = false
+	player.CurrentTeamID = nil
+	player.IsRetained = false
+	player.RetainedBy = nil
+	player.CurrentPrice = player.BasePrice
+	player.UpdatedAt = time.Now()
+	if err := tx.Players().Save(player); err != nil {
+		return fail(http.StatusInternalServerError, "Failed to update player")
+	}
+
+	if release.RefundAmount > 0 {
+		if err := tx.PointsTransactions().Record(&models.PointsTransaction{
+			TeamID:    team.ID,
+			PlayerID:  &player.ID,
+			Type:      ledger.TypeRelease,
+			Amount:    -release.RefundAmount,
+			Note:      fmt.Sprintf("Release %s: %d%% of %d", release.ID, percent, release.Price),
+			CreatedBy: c.GetString("user_id"),
+		}); err != nil {
+			return fail(http.StatusInternalServerError, "Failed to update team points")
+		}
+		if team, err = tx.Teams().GetByID(team.ID); err != nil {
+			return fail(http.StatusInternalServerError, "Failed to update team points")
+		}
+	}
+
+	team.PlayerCount -= 1
+	team.UpdatedAt = time.Now()
+	if err := tx.Teams().Save(team); err != nil {
+		return fail(http.StatusInternalServerError, "Failed to update team")
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"net/http"
+	"testing"
+
+	"auction-backend/models"
+	"auction-backend/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (s *testServer) requestRelease(team *models.Team, player *models.Player) (int, map[string]interface{}) {
+	return s.do(teamIdentity(team), http.MethodPost, "/team/releases", "/team/releases", s.h.RequestRelease, gin.H{
+		"player_id": player.ID,
+		"reason":    "injury",
+	})
+}
+
+func (s *testServer) proposeTrade(from, to *models.Team, player *models.Player) (int, map[string]interface{}) {
+	return s.do(teamIdentity(from), http.MethodPost, "/team/trades", "/team/trades", s.h.ProposeTrade, gin.H{
+		"receiver_team_id":     to.ID,
+		"requested_player_ids": []string{player.ID.String()},
+	})
+}
+
+func TestReleasesAndTradesExcludeEachOther(t *testing.T) {
+	s := newTestServer(t)
+	owner := s.team("Smashers", 12000)
+	owner.MinPlayers = 0
+	if err := s.store.Teams().Save(owner); err != nil {
+		t.Fatal(err)
+	}
+	buyer := s.team("Drop Shots", 12000)
+	traded := s.player("asha")
+	released := s.player("bina")
+	for _, player := range []*models.Player{traded, released} {
+		if status, body := s.assignPlayer(owner, player, 500); status != http.StatusOK {
+			t.Fatalf("assign: status %d, body %v", status, body)
+		}
+	}
+	s.auction("completed", false)
+
+	// A player in an open trade cannot be released
+	if status, body := s.proposeTrade(buyer, owner, traded); status != http.StatusCreated {
+		t.Fatalf("propose: status %d, body %v", status, body)
+	}
+	if status, body := s.requestRelease(owner, traded); status != http.StatusConflict {
+		t.Fatalf("release of traded player: status %d, body %v, want 409", status, body)
+	}
+
+	// A player with a pending release cannot be traded
+	if status, body := s.requestRelease(owner, released); status != http.StatusCreated {
+		t.Fatalf("release: status %d, body %v", status, body)
+	}
+	if status, body := s.proposeTrade(buyer, owner, released); status != http.StatusConflict {
+		t.Fatalf("trade of player pending release: status %d, body %v, want 409", status, body)
+	}
+}
+
+func TestRequestReleaseOnlyOncePerPlayer(t *testing.T) {
+	s := newTestServer(t)
+	team := s.team("Smashers", 12000)
+	player := s.player("asha")
+	if status, body := s.assignPlayer(team, player, 500); status != http.StatusOK {
+		t.Fatalf("assign: status %d, body %v", status, body)
+	}
+	s.auction("completed", false)
+
+	if status, body := s.requestRelease(team, player); status != http.StatusCreated {
+		t.Fatalf("release: status %d, body %v", status, body)
+	}
+	if status, body := s.requestRelease(team, player); status != http.StatusConflict {
+		t.Fatalf("second release: status %d, body %v, want 409", status, body)
+	}
+	releases, err := s.store.Releases().List(repository.ReleaseFilter{TeamID: &team.ID})
+	if err != nil || len(releases) != 1 || releases[0].Price != 500 {
+		t.Fatalf("releases = %+v (%v), want one at 500", releases, err)
+	}
+}
+
+func TestReleasedPlayersReturnOnlyInReplacementAuctions(t *testing.T) {
+	s := newTestServer(t)
+	team := s.team("Smashers", 12000)
+	player := s.player("asha")
+	if status, body := s.assignPlayer(team, player, 500); status != http.StatusOK {
+		t.Fatalf("assign: status %d, body %v", status, body)
+	}
+	s.auction("completed", false)
+
+	status, body := s.requestRelease(team, player)
+	if status != http.StatusCreated {
+		t.Fatalf("release: status %d, body %v", status, body)
+	}
+	id := body["data"].(map[string]interface{})["id"].(string)
+	status, body = s.do(testAdmin, http.MethodPost, "/admin/releases/:id/review", "/admin/releases/"+id+"/review", s.h.ReviewRelease, gin.H{"approved": true})
+	if status != http.StatusOK {
+		t.Fatalf("approve: status %d, body %v", status, body)
+	}
+
+	start := func(auction *models.Auction) (int, map[string]interface{}) {
+		return s.do(testAdmin, http.MethodPost, "/auctions/:id/start", "/auctions/"+auction.ID.String()+"/start", s.h.StartAuction, nil)
+	}
+
+	// The main auction does not put the released player up again
+	main := s.auction("pending", false)
+	if status, body := start(main); status != http.StatusBadRequest {
+		t.Fatalf("start main auction: status %d, body %v, want 400", status, body)
+	}
+
+	replacement := s.auction("pending", true)
+	if status, body := start(replacement); status != http.StatusOK {
+		t.Fatalf("start replacement auction: status %d, body %v", status, body)
+	}
+	if got := s.reloadAuction(replacement.ID); got.CurrentPlayerID == nil || *got.CurrentPlayerID != player.ID {
+		t.Fatalf("replacement auction is up with %v, want %v", got.CurrentPlayerID, player.ID)
+	}
+}
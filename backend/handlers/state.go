@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"auction-backend/models"
+	"auction-backend/repository"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// SquadPlayer is a player on a team's squad in an auction snapshot
+type SquadPlayer struct {
+	ID              uuid.UUID `json:"id"`
+	Name            string    `json:"name"`
+	PlayerCategory  string    `json:"player_category"`
+	PlayingCategory string    `json:"playing_category"`
+	Price           int       `json:"price"`
+	IsRetained      bool      `json:"is_retained"`
+}
+
+// TeamSummary is a team's budget and squad in an auction snapshot
+type TeamSummary struct {
+	ID              uuid.UUID      `json:"id"`
+	Name            string         `json:"name"`
+	TotalPoints     int            `json:"total_points"`
+	UsedPoints      int            `json:"used_points"`
+	RemainingPoints int            `json:"remaining_points"`
+	PlayerCount     int            `json:"player_count"`
+	MinPlayers      int            `json:"min_players"`
+	MaxPlayers      int            `json:"max_players"`
+	CategoryCounts  map[string]int `json:"category_counts"`
+	Squad           []SquadPlayer  `json:"squad"`
+}
+
+// AuctionState is everything a client needs to render an auction. Seq is the
+// latest event sequence sent to the auction's room when the snapshot was taken.
+type AuctionState struct {
+	Auction       models.Auction `json:"auction"`
+	CurrentPlayer *PlayerView    `json:"current_player"`
+	LeadingBid    *models.Bid    `json:"leading_bid"`
+	LeadingTeam   *TeamSummary   `json:"leading_team"`
+	Teams         []TeamSummary  `json:"teams"`
+	Seq           uint64         `json:"seq"`
+	AsOf          time.Time      `json:"as_of"`
+}
+
+// newTeamSummary summarizes a team whose Players are loaded
+func newTeamSummary(team models.Team) TeamSummary {
+	summary := TeamSummary{
+		ID:              team.ID,
+		Name:            team.Name,
+		TotalPoints:     team.TotalPoints,
+		UsedPoints:      team.UsedPoints,
+		RemainingPoints: team.TotalPoints - team.UsedPoints,
+		PlayerCount:     len(team.Players),
+		MinPlayers:      team.MinPlayers,
+		MaxPlayers:      team.MaxPlayers,
+		CategoryCounts:  map[string]int{},
+		Squad:           []SquadPlayer{},
+	}
+
+	for _, player := range team.Players {
+		category := player.GetPlayerCategory()
+		summary.CategoryCounts[category]++
+		summary.Squad = append(summary.Squad, SquadPlayer{
+			ID:              player.ID,
+			Name:            player.Name,
+			PlayerCategory:  category,
+			PlayingCategory: player.PlayingCategory,
+			Price:           player.CurrentPrice,
+			IsRetained:      player.IsRetained,
+		})
+	}
+	return summary
+}
+
+// GetAuctionState returns a snapshot of an auction: the current player, leading
+// bid and team, and every team's budget and squad, all read as of one point in time
+func (h *Handlers) GetAuctionState(c *gin.Context) {
+	auctionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid auction ID",
+		})
+		return
+	}
+
+	// Read the sequence before the data. Events are published after their
+	// changes commit, so every event after Seq is either in the snapshot already
+	// or still to be delivered; clients replaying from Seq never miss a change.
+	state := AuctionState{
+		Seq: h.Hub.CurrentSeq(auctionID.String()),
+	}
+
+	err = h.Store.Snapshot(func(tx repository.Store) error {
+		auction, err := tx.Auctions().GetByID(auctionID)
+		if err != nil {
+			return fail(http.StatusNotFound, "Auction not found")
+		}
+		state.Auction = *auction
+		state.AsOf = time.Now()
+
+		if auction.CurrentPlayerID != nil {
+			player, err := tx.Players().GetByID(*auction.CurrentPlayerID)
+			if err != nil && !errors.Is(err, repository.ErrNotFound) {
+				return err
+			}
+			state.CurrentPlayer = viewerOf(c).playerPtr(player)
+		}
+
+		// Earlier lots keep their winning bid, so only a bid on the current player leads
+		if auction.CurrentPlayerID != nil && auction.WinningTeamID != nil {
+			bid, err := tx.Bids().GetWinning(auction.ID)
+			if err != nil && !errors.Is(err, repository.ErrNotFound) {
+				return err
+			}
+			if bid != nil && bid.PlayerID == *auction.CurrentPlayerID {
+				state.LeadingBid = bid
+			}
+		}
+
+		teams, err := tx.Teams().ListWithPlayers()
+		if err != nil {
+			return err
+		}
+		state.Teams = make([]TeamSummary, 0, len(teams))
+		for _, team := range teams {
+			summary := newTeamSummary(team)
+			state.Teams = append(state.Teams, summary)
+			if state.LeadingBid != nil && team.ID == state.LeadingBid.TeamID {
+				leading := summary
+				state.LeadingTeam = &leading
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		respondError(c, err, "Failed to fetch auction state")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    state,
+	})
+}
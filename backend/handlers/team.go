@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"auction-backend/models"
+	"auction-backend/repository"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -20,8 +21,9 @@ type TeamDashboard struct {
 	PlayerCount     int             `json:"player_count"`
 	MinPlayers      int             `json:"min_players"`
 	MaxPlayers      int             `json:"max_players"`
-	Players         []models.Player `json:"players"`
+	Players         []PlayerView    `json:"players"`
 	RecentBids      []models.Bid    `json:"recent_bids"`
+	Watchlist       []WatchlistItem `json:"watchlist"`
 }
 
 // GetTeamDashboard returns team dashboard data
@@ -46,8 +48,8 @@ func (h *Handlers) GetTeamDashboard(c *gin.Context) {
 		return
 	}
 
-	var team models.Team
-	if err := h.DB.First(&team, teamUUID).Error; err != nil {
+	team, err := h.Store.Teams().GetByID(teamUUID)
+	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{
 			"success": false,
 			"error":   "Team not found",
@@ -56,15 +58,15 @@ func (h *Handlers) GetTeamDashboard(c *gin.Context) {
 	}
 
 	// Get team players
-	var players []models.Player
-	h.DB.Where("current_team_id = ?", team.ID).Find(&players)
+	players, _ := h.Store.Players().ListByTeam(team.ID)
 
 	// Get recent bids
-	var recentBids []models.Bid
-	h.DB.Where("team_id = ?", team.ID).
-		Order("created_at DESC").
-		Limit(5).
-		Find(&recentBids)
+	recentBids, _ := h.Store.Bids().ListRecentByTeam(team.ID, 5)
+
+	watchlist, err := watchlistItems(h.Store, viewerOf(c), team.ID)
+	if err != nil {
+		watchlist = []WatchlistItem{}
+	}
 
 	dashboard := TeamDashboard{
 		TeamID:          team.ID.String(),
@@ -75,8 +77,9 @@ func (h *Handlers) GetTeamDashboard(c *gin.Context) {
 		PlayerCount:     len(players),
 		MinPlayers:      team.MinPlayers,
 		MaxPlayers:      team.MaxPlayers,
-		Players:         players,
+		Players:         viewerOf(c).players(players),
 		RecentBids:      recentBids,
+		Watchlist:       watchlist,
 	}
 
 	c.JSON(http.StatusOK, gin.H{
@@ -96,8 +99,18 @@ func (h *Handlers) GetTeamRoster(c *gin.Context) {
 		return
 	}
 
-	var players []models.Player
-	if err := h.DB.Where("current_team_id = ?", teamID).Find(&players).Error; err != nil {
+	// Parse team ID as UUID
+	teamUUID, err := uuid.Parse(teamID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid team ID",
+		})
+		return
+	}
+
+	players, err := h.Store.Players().ListByTeam(teamUUID)
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"success": false,
 			"error":   "Failed to fetch roster",
@@ -107,7 +120,7 @@ func (h *Handlers) GetTeamRoster(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
-		"data":    players,
+		"data":    viewerOf(c).players(players),
 	})
 }
 
@@ -132,8 +145,8 @@ func (h *Handlers) GetTeamBudget(c *gin.Context) {
 		return
 	}
 
-	var team models.Team
-	if err := h.DB.First(&team, teamUUID).Error; err != nil {
+	team, err := h.Store.Teams().GetByID(teamUUID)
+	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{
 			"success": false,
 			"error":   "Team not found",
@@ -189,8 +202,8 @@ func (h *Handlers) RetainPlayer(c *gin.Context) {
 	}
 
 	// Get player
-	var player models.Player
-	if err := h.DB.First(&player, playerUUID).Error; err != nil {
+	player, err := h.Store.Players().GetByID(playerUUID)
+	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{
 			"success": false,
 			"error":   "Player not found",
@@ -207,6 +220,14 @@ func (h *Handlers) RetainPlayer(c *gin.Context) {
 		return
 	}
 
+	if player.RegistrationStatus != models.RegistrationApproved {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Player registration is not approved",
+		})
+		return
+	}
+
 	// Parse team ID to UUID
 	teamUUID, err := uuid.Parse(teamID.(string))
 	if err != nil {
@@ -218,20 +239,24 @@ func (h *Handlers) RetainPlayer(c *gin.Context) {
 	}
 
 	// Update player as retained
+	before := *player
 	player.IsRetained = true
 	player.RetainedBy = &teamUUID
 	player.UpdatedAt = time.Now()
 
-	if err := h.DB.Save(&player).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"success": false,
-			"error":   "Failed to retain player",
-		})
+	err = h.Store.Transaction(func(tx repository.Store) error {
+		if err := tx.Players().Save(player); err != nil {
+			return err
+		}
+		return h.recordAudit(tx, c, "player.retained", "player", player.ID, before, player)
+	})
+	if err != nil {
+		respondError(c, err, "Failed to retain player")
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
-		"data":    player,
+		"data":    viewerOf(c).player(*player),
 	})
 }
@@ -0,0 +1,622 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"auction-backend/ledger"
+	"auction-backend/models"
+	"auction-backend/repository"
+	"auction-backend/websocket"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// maxTradeNote is the longest note a team may attach to a trade
+const maxTradeNote = 1000
+
+// errTradePreview rolls back a trial settlement once it has passed its checks
+var errTradePreview = errors.New("trade preview rolled back")
+
+// TradeItemView is a traded player, with their name and the price that moves
+// with them: the settled price once the trade completes, their current price before
+type TradeItemView struct {
+	models.TradeItem
+	PlayerName string `json:"player_name"`
+}
+
+// TradeView is a trade with its team and player names
+type TradeView struct {
+	models.Trade
+	ProposerTeamName string          `json:"proposer_team_name"`
+	ReceiverTeamName string          `json:"receiver_team_name"`
+	Items            []TradeItemView `json:"items"`
+}
+
+// tradeViews names the teams and players of each trade
+func tradeViews(store repository.Store, trades []models.Trade) []TradeView {
+	teamNames := map[uuid.UUID]string{}
+	teamName := func(id uuid.UUID) string {
+		if name, ok := teamNames[id]; ok {
+			return name
+		}
+		if team, err := store.Teams().GetByID(id); err == nil {
+			teamNames[id] = team.Name
+		}
+		return teamNames[id]
+	}
+
+	views := make([]TradeView, 0, len(trades))
+	for _, trade := range trades {
+		view := TradeView{
+			Trade:            trade,
+			ProposerTeamName: teamName(trade.ProposerTeamID),
+			ReceiverTeamName: teamName(trade.ReceiverTeamID),
+			Items:            make([]TradeItemView, 0, len(trade.Items)),
+		}
+		for _, item := range trade.Items {
+			itemView := TradeItemView{TradeItem: item}
+			if player, err := store.Players().GetByID(item.PlayerID); err == nil {
+				itemView.PlayerName = player.Name
+				if trade.Status != models.TradeCompleted {
+					itemView.Price = player.CurrentPrice
+				}
+			}
+			view.Items = append(view.Items, itemView)
+		}
+		views = append(views, view)
+	}
+	return views
+}
+
+// checkTradingWindow allows trades once the main auction has completed, except
+// while an auction is running or a supplementary auction is yet to start
+func checkTradingWindow(store repository.Store) error {
+	if _, err := store.Auctions().GetActive(); err == nil {
+		return fail(http.StatusConflict, "Trades cannot be settled while an auction is running")
+	}
+
+	pending, err := store.Auctions().List("pending")
+	if err != nil {
+		return fail(http.StatusInternalServerError, "Failed to check the auction schedule")
+	}
+	for _, auction := range pending {
+		if auction.Supplementary {
+			return fail(http.StatusConflict, "Trades cannot be settled while a replacement auction is in progress")
+		}
+	}
+
+	completed, err := store.Auctions().List("completed")
+	if err != nil {
+		return fail(http.StatusInternalServerError, "Failed to check the auction schedule")
+	}
+	for _, auction := range completed {
+		if !auction.Supplementary {
+			return nil
+		}
+	}
+	return fail(http.StatusConflict, "Trades open once the auction has completed")
+}
+
+// settleTrade moves the trade's players and points between its teams, in tx.
+// Each player's price is charged to the team they join and credited to the
+// team they leave; the trade's points are a payment between the teams. It
+// fails outside the trading window, if a player has since changed team or has
+// a release awaiting review, or if either team would end up outside its roster
+// limits or over its points.
+func (h *Handlers) settleTrade(tx repository.Store, c *gin.Context, trade *models.Trade) error {
+	if err := checkTradingWindow(tx); err != nil {
+		return err
+	}
+
+	// Lock both teams, in a fixed order so concurrent trades cannot deadlock
+	teamIDs := []uuid.UUID{trade.ProposerTeamID, trade.ReceiverTeamID}
+	sort.Slice(teamIDs, func(i, j int) bool { return bytes.Compare(teamIDs[i][:], teamIDs[j][:]) < 0 })
+	for _, id := range teamIDs {
+		if _, err := tx.Teams().GetByIDForUpdate(id); err != nil {
+			return fail(http.StatusNotFound, "Team not found")
+		}
+	}
+
+	note := fmt.Sprintf("Trade %s", trade.ID)
+	actor := c.GetString("user_id")
+	record := func(entry models.PointsTransaction) error {
+		entry.Note = note
+		entry.CreatedBy = actor
+		if err := tx.PointsTransactions().Record(&entry); err != nil {
+			return fail(http.StatusInternalServerError, "Failed to update team points")
+		}
+		return nil
+	}
+
+	joined := map[uuid.UUID]int{}
+	for i := range trade.Items {
+		item := &trade.Items[i]
+		player, err := tx.Players().GetByID(item.PlayerID)
+		if err != nil {
+			return fail(http.StatusNotFound, "Player not found")
+		}
+		if !player.IsSold || player.CurrentTeamID == nil || *player.CurrentTeamID != item.FromTeamID {
+			return fail(http.StatusConflict, fmt.Sprintf("%s is no longer on the team trading them", player.Name))
+		}
+		// A player the team has asked to release cannot be traded until the request is reviewed
+		if pending, err := hasPendingRelease(tx, item.FromTeamID, player.ID); err != nil {
+			return fail(http.StatusInternalServerError, "Failed to check pending releases")
+		} else if pending {
+			return fail(http.StatusConflict, fmt.Sprintf("%s has a release awaiting review", player.Name))
+		}
+
+		toTeamID := item.ToTeamID
+		player.CurrentTeamID = &toTeamID
+		player.UpdatedAt = time.Now()
+		if err := tx.Players().Save(player); err != nil {
+			return fail(http.StatusInternalServerError, "Failed to update player")
+		}
+
+		item.Price = player.CurrentPrice
+		if err := record(models.PointsTransaction{
+			TeamID:   item.FromTeamID,
+			PlayerID: &player.ID,
+			Type:     ledger.TypeTransferOut,
+			Amount:   -item.Price,
+		}); err != nil {
+			return err
+		}
+		if err := record(models.PointsTransaction{
+			TeamID:   item.ToTeamID,
+			PlayerID: &player.ID,
+			Type:     ledger.TypeTransferIn,
+			Amount:   item.Price,
+		}); err != nil {
+			return err
+		}
+		joined[item.FromTeamID]--
+		joined[item.ToTeamID]++
+	}
+
+	if trade.Points != 0 {
+		if err := record(models.PointsTransaction{
+			TeamID: trade.ProposerTeamID,
+			Type:   ledger.TypeTradePayment,
+			Amount: trade.Points,
+		}); err != nil {
+			return err
+		}
+		if err := record(models.PointsTransaction{
+			TeamID: trade.ReceiverTeamID,
+			Type:   ledger.TypeTradePayment,
+			Amount: -trade.Points,
+		}); err != nil {
+			return err
+		}
+	}
+
+	// The ledger has re-derived used points; read the teams back before saving
+	for _, id := range teamIDs {
+		team, err := tx.Teams().GetByID(id)
+		if err != nil {
+			return fail(http.StatusNotFound, "Team not found")
+		}
+
+		team.PlayerCount += joined[id]
+		switch {
+		case team.PlayerCount > team.MaxPlayers:
+			return fail(http.StatusConflict, fmt.Sprintf("%s would have %d players, more than its maximum of %d",
+				team.Name, team.PlayerCount, team.MaxPlayers))
+		case joined[id] < 0 && team.PlayerCount < team.MinPlayers:
+			return fail(http.StatusConflict, fmt.Sprintf("%s would have %d players, fewer than its minimum of %d",
+				team.Name, team.PlayerCount, team.MinPlayers))
+		case team.UsedPoints > team.TotalPoints:
+			return fail(http.StatusConflict, fmt.Sprintf("%s cannot afford this trade: it would need %d points but has %d",
+				team.Name, team.UsedPoints, team.TotalPoints))
+		}
+
+		team.UpdatedAt = time.Now()
+		if err := tx.Teams().Save(team); err != nil {
+			return fail(http.StatusInternalServerError, "Failed to update team")
+		}
+	}
+	return nil
+}
+
+// previewTrade checks that the trade would settle now, without keeping any of it
+func (h *Handlers) previewTrade(c *gin.Context, trade models.Trade) error {
+	trade.Items = append([]models.TradeItem{}, trade.Items...)
+	err := h.Store.Transaction(func(tx repository.Store) error {
+		if err := h.settleTrade(tx, c, &trade); err != nil {
+			return err
+		}
+		return errTradePreview
+	})
+	if errors.Is(err, errTradePreview) {
+		return nil
+	}
+	return err
+}
+
+// publishTrade tells both teams and the admins that a trade changed
+func (h *Handlers) publishTrade(trade *models.Trade) {
+	view := tradeViews(h.Store, []models.Trade{*trade})[0]
+	h.Hub.Publish(websocket.TeamRoom(trade.ProposerTeamID.String()), "trade_updated", view)
+	h.Hub.Publish(websocket.TeamRoom(trade.ReceiverTeamID.String()), "trade_updated", view)
+	h.Hub.Publish(websocket.AdminRoom, "trade_updated", view)
+}
+
+// ProposeTrade offers a trade to another team: {"receiver_team_id": "...",
+// "offered_player_ids": [...], "requested_player_ids": [...], "points": 500,
+// "note": "..."}. Points are paid by the proposing team, or by the receiving
+// team when negative. The trade must be able to settle as proposed.
+func (h *Handlers) ProposeTrade(c *gin.Context) {
+	teamUUID, err := uuid.Parse(c.GetString("team_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid team ID",
+		})
+		return
+	}
+
+	var req struct {
+		ReceiverTeamID     string   `json:"receiver_team_id" binding:"required"`
+		OfferedPlayerIDs   []string `json:"offered_player_ids"`
+		RequestedPlayerIDs []string `json:"requested_player_ids"`
+		Points             int      `json:"points"`
+		Note               string   `json:"note"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid request data",
+		})
+		return
+	}
+
+	receiverUUID, err := uuid.Parse(req.ReceiverTeamID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid receiver team ID",
+		})
+		return
+	}
+
+	req.Note = strings.TrimSpace(req.Note)
+	var invalid string
+	switch {
+	case receiverUUID == teamUUID:
+		invalid = "A team cannot trade with itself"
+	case len(req.OfferedPlayerIDs) == 0 && len(req.RequestedPlayerIDs) == 0:
+		invalid = "A trade must move at least one player"
+	case len([]rune(req.Note)) > maxTradeNote:
+		invalid = "Note is too long"
+	}
+	if invalid != "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   invalid,
+		})
+		return
+	}
+
+	if _, err := h.Store.Teams().GetByID(receiverUUID); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"success": false,
+			"error":   "Team not found",
+		})
+		return
+	}
+
+	trade := models.Trade{
+		ProposerTeamID: teamUUID,
+		ReceiverTeamID: receiverUUID,
+		Status:         models.TradeProposed,
+		Points:         req.Points,
+		Note:           req.Note,
+		ProposedBy:     c.GetString("user_id"),
+	}
+	seen := map[uuid.UUID]bool{}
+	addItems := func(ids []string, from, to uuid.UUID) error {
+		for _, id := range ids {
+			playerUUID, err := uuid.Parse(id)
+			if err != nil {
+				return fail(http.StatusBadRequest, "Invalid player ID")
+			}
+			if seen[playerUUID] {
+				return fail(http.StatusBadRequest, "A player can only appear once in a trade")
+			}
+			seen[playerUUID] = true
+
+			player, err := h.Store.Players().GetByID(playerUUID)
+			if err != nil || !player.IsSold || player.CurrentTeamID == nil || *player.CurrentTeamID != from {
+				return fail(http.StatusBadRequest, "Players offered must be on your team, and players requested on theirs")
+			}
+			trade.Items = append(trade.Items, models.TradeItem{PlayerID: player.ID, FromTeamID: from, ToTeamID: to})
+		}
+		return nil
+	}
+	if err := addItems(req.OfferedPlayerIDs, teamUUID, receiverUUID); err != nil {
+		respondError(c, err, "Failed to propose trade")
+		return
+	}
+	if err := addItems(req.RequestedPlayerIDs, receiverUUID, teamUUID); err != nil {
+		respondError(c, err, "Failed to propose trade")
+		return
+	}
+
+	if err := h.previewTrade(c, trade); err != nil {
+		respondError(c, err, "Failed to propose trade")
+		return
+	}
+
+	err = h.Store.Transaction(func(tx repository.Store) error {
+		if err := tx.Trades().Create(&trade); err != nil {
+			return fail(http.StatusInternalServerError, "Failed to propose trade")
+		}
+		return h.recordAudit(tx, c, "trade.proposed", "trade", trade.ID, nil, trade)
+	})
+	if err != nil {
+		respondError(c, err, "Failed to propose trade")
+		return
+	}
+
+	h.publishTrade(&trade)
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    tradeViews(h.Store, []models.Trade{trade})[0],
+	})
+}
+
+// GetMyTrades returns the trades the team proposed or received, newest first
+func (h *Handlers) GetMyTrades(c *gin.Context) {
+	teamUUID, err := uuid.Parse(c.GetString("team_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid team ID",
+		})
+		return
+	}
+
+	trades, err := h.Store.Trades().List(repository.TradeFilter{TeamID: &teamUUID, Status: c.Query("status")})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   "Failed to fetch trades",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    tradeViews(h.Store, trades),
+	})
+}
+
+// respondToTrade moves a trade the team is party to from one status to
+// another. change checks the trade and applies the new status, returning an
+// error to leave it unchanged.
+func (h *Handlers) respondToTrade(c *gin.Context, action string, change func(trade *models.Trade, teamID uuid.UUID) error) {
+	teamUUID, err := uuid.Parse(c.GetString("team_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid team ID",
+		})
+		return
+	}
+	tradeUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid trade ID",
+		})
+		return
+	}
+
+	var trade *models.Trade
+	err = h.Store.Transaction(func(tx repository.Store) error {
+		var err error
+		trade, err = tx.Trades().GetByIDForUpdate(tradeUUID)
+		if err != nil || (trade.ProposerTeamID != teamUUID && trade.ReceiverTeamID != teamUUID) {
+			return fail(http.StatusNotFound, "Trade not found")
+		}
+
+		before := copyTradeForAudit(trade)
+		if err := change(trade, teamUUID); err != nil {
+			return err
+		}
+		if err := tx.Trades().Save(trade); err != nil {
+			return fail(http.StatusInternalServerError, "Failed to update trade")
+		}
+		return h.recordAudit(tx, c, action, "trade", trade.ID, before, trade)
+	})
+	if err != nil {
+		respondError(c, err, "Failed to update trade")
+		return
+	}
+
+	h.publishTrade(trade)
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    tradeViews(h.Store, []models.Trade{*trade})[0],
+	})
+}
+
+// copyTradeForAudit snapshots a trade, items included, before it changes
+func copyTradeForAudit(trade *models.Trade) models.Trade {
+	before := *trade
+	before.Items = append([]models.TradeItem{}, trade.Items...)
+	return before
+}
+
+// AcceptTrade accepts a trade proposed to the team, passing it to the admins
+// for approval. The trade must still be able to settle.
+func (h *Handlers) AcceptTrade(c *gin.Context) {
+	// Trial the settlement first; respondToTrade checks the status again as it changes
+	if tradeUUID, err := uuid.Parse(c.Param("id")); err == nil {
+		trade, err := h.Store.Trades().GetByID(tradeUUID)
+		if err == nil && trade.Status == models.TradeProposed && trade.ReceiverTeamID.String() == c.GetString("team_id") {
+			if err := h.previewTrade(c, *trade); err != nil {
+				respondError(c, err, "Failed to accept trade")
+				return
+			}
+		}
+	}
+
+	h.respondToTrade(c, "trade.accepted", func(trade *models.Trade, teamID uuid.UUID) error {
+		if trade.ReceiverTeamID != teamID {
+			return fail(http.StatusForbidden, "Only the team a trade was proposed to can accept it")
+		}
+		if trade.Status != models.TradeProposed {
+			return fail(http.StatusConflict, "Trade is "+trade.Status+" and can no longer be accepted")
+		}
+
+		now := time.Now()
+		trade.Status = models.TradeAccepted
+		trade.RespondedAt = &now
+		return nil
+	})
+}
+
+// DeclineTrade declines a trade proposed to the team
+func (h *Handlers) DeclineTrade(c *gin.Context) {
+	h.respondToTrade(c, "trade.declined", func(trade *models.Trade, teamID uuid.UUID) error {
+		if trade.ReceiverTeamID != teamID {
+			return fail(http.StatusForbidden, "Only the team a trade was proposed to can decline it")
+		}
+		if trade.Status != models.TradeProposed {
+			return fail(http.StatusConflict, "Trade is "+trade.Status+" and can no longer be declined")
+		}
+
+		now := time.Now()
+		trade.Status = models.TradeDeclined
+		trade.RespondedAt = &now
+		return nil
+	})
+}
+
+// CancelTrade withdraws a trade the team proposed, until an admin has reviewed it
+func (h *Handlers) CancelTrade(c *gin.Context) {
+	h.respondToTrade(c, "trade.cancelled", func(trade *models.Trade, teamID uuid.UUID) error {
+		if trade.ProposerTeamID != teamID {
+			return fail(http.StatusForbidden, "Only the team that proposed a trade can cancel it")
+		}
+		if trade.Status != models.TradeProposed && trade.Status != models.TradeAccepted {
+			return fail(http.StatusConflict, "Trade is "+trade.Status+" and can no longer be cancelled")
+		}
+
+		trade.Status = models.TradeCancelled
+		return nil
+	})
+}
+
+// GetTrades returns every trade, newest first, optionally filtered by status
+func (h *Handlers) GetTrades(c *gin.Context) {
+	trades, err := h.Store.Trades().List(repository.TradeFilter{Status: c.Query("status")})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   "Failed to fetch trades",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    tradeViews(h.Store, trades),
+	})
+}
+
+// ReviewTrade approves or rejects a trade both teams have agreed:
+// {"approved": true} or {"approved": false, "reason": "..."}. Approval settles
+// the trade in one transaction, so either every player and point moves or none do.
+func (h *Handlers) ReviewTrade(c *gin.Context) {
+	tradeUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid trade ID",
+		})
+		return
+	}
+
+	var req struct {
+		Approved bool   `json:"approved"`
+		Reason   string `json:"reason"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid request data",
+		})
+		return
+	}
+
+	req.Reason = strings.TrimSpace(req.Reason)
+	if !req.Approved && req.Reason == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "A reason is required to reject a trade",
+		})
+		return
+	}
+
+	var trade *models.Trade
+	err = h.Store.Transaction(func(tx repository.Store) error {
+		var err error
+		trade, err = tx.Trades().GetByIDForUpdate(tradeUUID)
+		if err != nil {
+			return fail(http.StatusNotFound, "Trade not found")
+		}
+		if trade.Status != models.TradeAccepted {
+			return fail(http.StatusConflict, "Only trades accepted by both teams can be reviewed")
+		}
+
+		before := copyTradeForAudit(trade)
+		now := time.Now()
+		trade.ReviewedBy = c.GetString("user_id")
+		trade.ReviewReason = req.Reason
+		trade.Status = models.TradeRejected
+		action := "trade.rejected"
+		if req.Approved {
+			if err := h.settleTrade(tx, c, trade); err != nil {
+				return err
+			}
+			trade.Status = models.TradeCompleted
+			trade.CompletedAt = &now
+			action = "trade.completed"
+		}
+
+		if err := tx.Trades().Save(trade); err != nil {
+			return fail(http.StatusInternalServerError, "Failed to update trade")
+		}
+		return h.recordAudit(tx, c, action, "trade", trade.ID, before, trade)
+	})
+	if err != nil {
+		respondError(c, err, "Failed to review trade")
+		return
+	}
+
+	if trade.Status == models.TradeCompleted {
+		for _, id := range []uuid.UUID{trade.ProposerTeamID, trade.ReceiverTeamID} {
+			if team, err := h.Store.Teams().GetByID(id); err == nil {
+				h.Hub.Publish(websocket.TeamRoom(team.ID.String()), "team_updated", team)
+				h.Hub.Publish(websocket.AdminRoom, "team_updated", team)
+			}
+		}
+	}
+	h.publishTrade(trade)
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    tradeViews(h.Store, []models.Trade{*trade})[0],
+	})
+}
@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"auction-backend/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (s *testServer) auction(status string, supplementary bool) *models.Auction {
+	s.t.Helper()
+
+	auction := &models.Auction{Title: "Round", Status: status, StartTime: time.Now(), Supplementary: supplementary}
+	if err := s.store.Auctions().Create(auction); err != nil {
+		s.t.Fatal(err)
+	}
+	return auction
+}
+
+func TestTradesWaitForTheAuctionToComplete(t *testing.T) {
+	s := newTestServer(t)
+	buyer := s.team("Smashers", 12000)
+	seller := s.team("Drop Shots", 12000)
+	seller.MinPlayers = 0
+	if err := s.store.Teams().Save(seller); err != nil {
+		t.Fatal(err)
+	}
+	player := s.player("asha")
+	if status, body := s.assignPlayer(seller, player, 500); status != http.StatusOK {
+		t.Fatalf("assign: status %d, body %v", status, body)
+	}
+
+	propose := func() (int, map[string]interface{}) {
+		return s.do(teamIdentity(buyer), http.MethodPost, "/team/trades", "/team/trades", s.h.ProposeTrade, gin.H{
+			"receiver_team_id":     seller.ID,
+			"requested_player_ids": []string{player.ID.String()},
+			"points":               100,
+		})
+	}
+
+	if status, body := propose(); status != http.StatusConflict {
+		t.Fatalf("before any auction: status %d, body %v, want 409", status, body)
+	}
+
+	s.auction("completed", false)
+	replacement := s.auction("pending", true)
+	if status, body := propose(); status != http.StatusConflict {
+		t.Fatalf("replacement auction pending: status %d, body %v, want 409", status, body)
+	}
+
+	replacement.Status = "completed"
+	if err := s.store.Auctions().Save(replacement); err != nil {
+		t.Fatal(err)
+	}
+	if status, body := propose(); status != http.StatusCreated {
+		t.Fatalf("after the auctions: status %d, body %v, want 201", status, body)
+	}
+}
@@ -0,0 +1,174 @@
+package handlers
+
+import (
+	"time"
+
+	"auction-backend/middleware"
+	"auction-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// viewer is the audience a response is built for: an admin, a team, a
+// player, or the public when role is empty
+type viewer struct {
+	role   string
+	teamID string
+	userID string
+}
+
+// publicViewer sees no personal data; it is used for anything sent to a mixed audience
+var publicViewer = viewer{}
+
+// viewerOf returns the caller of a request. Public routes run without the
+// Auth middleware, so a token sent to them is checked here.
+func viewerOf(c *gin.Context) viewer {
+	if role := c.GetString("user_role"); role != "" {
+		return viewer{role: role, teamID: c.GetString("team_id"), userID: c.GetString("user_id")}
+	}
+
+	header := c.GetHeader("Authorization")
+	if header == "" {
+		return publicViewer
+	}
+	identity, err := middleware.Authenticate(middleware.BearerToken(header))
+	if err != nil {
+		return publicViewer
+	}
+	return viewer{role: identity.Role, teamID: identity.TeamID, userID: identity.UserID}
+}
+
+// seesPersonal reports whether the viewer may see a player's mobile number
+// and date of birth: admins always, teams only for their own players, and
+// players only for themselves
+func (v viewer) seesPersonal(player models.Player) bool {
+	switch v.role {
+	case "admin":
+		return true
+	case "team":
+		owns := func(teamID *uuid.UUID) bool { return teamID != nil && teamID.String() == v.teamID }
+		return owns(player.CurrentTeamID) || owns(player.RetainedBy)
+	case "player":
+		return v.isSelf(player)
+	}
+	return false
+}
+
+// isSelf reports whether the viewer is the player
+func (v viewer) isSelf(player models.Player) bool {
+	return v.role == "player" && player.UserID.String() == v.userID
+}
+
+// PlayerView is a player as shown to one viewer. Everyone sees the age
+// category; age, date of birth and mobile number are left out unless the
+// viewer may see them. The player's login is shown only to admins, and the
+// registration review only to admins and the player.
+type PlayerView struct {
+	ID              uuid.UUID  `json:"id"`
+	Name            string     `json:"name"`
+	Gender          string     `json:"gender"`
+	PlayerCategory  string     `json:"player_category"`
+	PlayingCategory string     `json:"playing_category"`
+	Accomplishments string     `json:"accomplishments"`
+	IsRetained      bool       `json:"is_retained"`
+	RetainedBy      *uuid.UUID `json:"retained_by"`
+	CurrentTeamID   *uuid.UUID `json:"current_team_id"`
+	BasePrice       int        `json:"base_price"`
+	CurrentPrice    int        `json:"current_price"`
+	IsSold          bool       `json:"is_sold"`
+	PhotoThumbURL   string     `json:"photo_thumb_url"`
+	PhotoCardURL    string     `json:"photo_card_url"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+
+	Age         *int         `json:"age,omitempty"`
+	DateOfBirth *time.Time   `json:"date_of_birth,omitempty"`
+	Mobile      string       `json:"mobile,omitempty"`
+	UserID      *uuid.UUID   `json:"user_id,omitempty"`
+	User        *models.User `json:"user,omitempty"`
+
+	RegistrationStatus string     `json:"registration_status,omitempty"`
+	ReviewReason       string     `json:"review_reason,omitempty"`
+	ReviewedAt         *time.Time `json:"reviewed_at,omitempty"`
+}
+
+// player builds the view of a player for v
+func (v viewer) player(player models.Player) PlayerView {
+	view := PlayerView{
+		ID:              player.ID,
+		Name:            player.Name,
+		Gender:          player.Gender,
+		PlayerCategory:  player.GetPlayerCategory(),
+		PlayingCategory: player.PlayingCategory,
+		Accomplishments: player.Accomplishments,
+		IsRetained:      player.IsRetained,
+		RetainedBy:      player.RetainedBy,
+		CurrentTeamID:   player.CurrentTeamID,
+		BasePrice:       player.BasePrice,
+		CurrentPrice:    player.CurrentPrice,
+		IsSold:          player.IsSold,
+		PhotoThumbURL:   player.PhotoThumbURL,
+		PhotoCardURL:    player.PhotoCardURL,
+		CreatedAt:       player.CreatedAt,
+		UpdatedAt:       player.UpdatedAt,
+	}
+
+	if v.seesPersonal(player) {
+		age, dob := player.Age, player.DateOfBirth
+		view.Age = &age
+		view.DateOfBirth = &dob
+		view.Mobile = player.Mobile
+	}
+	if v.role == "admin" || v.isSelf(player) {
+		view.RegistrationStatus = player.RegistrationStatus
+		view.ReviewReason = player.ReviewReason
+		view.ReviewedAt = player.ReviewedAt
+	}
+	if v.role == "admin" {
+		userID, user := player.UserID, player.User
+		view.UserID = &userID
+		if user.ID != uuid.Nil {
+			view.User = &user
+		}
+	}
+	return view
+}
+
+// players builds the views of several players for v
+func (v viewer) players(players []models.Player) []PlayerView {
+	views := make([]PlayerView, 0, len(players))
+	for _, player := range players {
+		views = append(views, v.player(player))
+	}
+	return views
+}
+
+// playerPtr builds the view of a player that may be missing
+func (v viewer) playerPtr(player *models.Player) *PlayerView {
+	if player == nil {
+		return nil
+	}
+	view := v.player(*player)
+	return &view
+}
+
+// TeamView is a team with its roster as shown to one viewer
+type TeamView struct {
+	models.Team
+	Players []PlayerView `json:"players"`
+}
+
+// team builds the view of a team whose Players are loaded
+func (v viewer) team(team models.Team) TeamView {
+	return TeamView{Team: team, Players: v.players(team.Players)}
+}
+
+// teams builds the views of several teams for v
+func (v viewer) teams(teams []models.Team) []TeamView {
+	views := make([]TeamView, 0, len(teams))
+	for _, team := range teams {
+		views = append(views, v.team(team))
+	}
+	return views
+}
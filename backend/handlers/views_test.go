@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"auction-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func TestPlayerViewAudience(t *testing.T) {
+	owner, retainer, other := uuid.New(), uuid.New(), uuid.New()
+	userID := uuid.New()
+	player := models.Player{
+		ID:            uuid.New(),
+		UserID:        userID,
+		User:          models.User{ID: userID, Email: "asha@example.com"},
+		Name:          "asha",
+		Age:           25,
+		DateOfBirth:   time.Date(2001, 3, 14, 0, 0, 0, 0, time.UTC),
+		Mobile:        "9800000000",
+		CurrentTeamID: &owner,
+		RetainedBy:    &retainer,
+	}
+
+	tests := []struct {
+		name     string
+		viewer   viewer
+		personal bool
+		login    bool
+	}{
+		{"public", publicViewer, false, false},
+		{"admin", viewer{role: "admin"}, true, true},
+		{"owning team", viewer{role: "team", teamID: owner.String()}, true, false},
+		{"retaining team", viewer{role: "team", teamID: retainer.String()}, true, false},
+		{"other team", viewer{role: "team", teamID: other.String()}, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			view := tt.viewer.player(player)
+			if view.Name != player.Name || view.PlayerCategory != player.GetPlayerCategory() {
+				t.Fatalf("public fields = %q %q", view.Name, view.PlayerCategory)
+			}
+			if personal := view.DateOfBirth != nil && view.Age != nil && view.Mobile != ""; personal != tt.personal {
+				t.Fatalf("personal data shown = %v, want %v", personal, tt.personal)
+			}
+			if login := view.UserID != nil && view.User != nil; login != tt.login {
+				t.Fatalf("login shown = %v, want %v", login, tt.login)
+			}
+		})
+	}
+}
+
+func TestPlayerViewOfMissingPlayer(t *testing.T) {
+	if view := publicViewer.playerPtr(nil); view != nil {
+		t.Fatalf("view of nil player = %+v, want nil", view)
+	}
+}
+
+func TestViewerOf(t *testing.T) {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest("GET", "/players", nil)
+	if v := viewerOf(c); v.role != "" {
+		t.Fatalf("anonymous caller has role %q, want public", v.role)
+	}
+
+	// A token that does not verify falls back to the public view
+	c.Request.Header.Set("Authorization", "Bearer not-a-token")
+	if v := viewerOf(c); v.role != "" {
+		t.Fatalf("caller with a bad token has role %q, want public", v.role)
+	}
+
+	c.Set("user_role", "team")
+	c.Set("team_id", "team-1")
+	if v := viewerOf(c); v.role != "team" || v.teamID != "team-1" {
+		t.Fatalf("authenticated caller = %+v, want team team-1", v)
+	}
+}
@@ -0,0 +1,246 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"auction-backend/models"
+	"auction-backend/repository"
+	"auction-backend/websocket"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// maxWatchlistNotes is the longest note a team may keep on a player
+const maxWatchlistNotes = 2000
+
+// WatchlistItem is a watchlist entry with its player, as the team sees them
+type WatchlistItem struct {
+	models.WatchlistEntry
+	Player PlayerView `json:"player"`
+}
+
+// watchlistItems loads the team's watchlist with each player, skipping
+// entries whose player no longer exists
+func watchlistItems(store repository.Store, v viewer, teamID uuid.UUID) ([]WatchlistItem, error) {
+	entries, err := store.Watchlists().ListByTeam(teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]WatchlistItem, 0, len(entries))
+	for _, entry := range entries {
+		player, err := store.Players().GetByID(entry.PlayerID)
+		if err != nil {
+			continue
+		}
+		items = append(items, WatchlistItem{WatchlistEntry: entry, Player: v.player(*player)})
+	}
+	return items, nil
+}
+
+// GetWatchlist returns the team's watchlist, highest priority first
+func (h *Handlers) GetWatchlist(c *gin.Context) {
+	teamUUID, err := uuid.Parse(c.GetString("team_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid team ID",
+		})
+		return
+	}
+
+	items, err := watchlistItems(h.Store, viewerOf(c), teamUUID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   "Failed to fetch watchlist",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    items,
+	})
+}
+
+// SaveWatchlistEntry adds a player to the team's watchlist, or updates the
+// entry: {"priority": 1-5, "target_price": 1500, "notes": "..."}. Priority
+// defaults to 3; a target price of 0 means none.
+func (h *Handlers) SaveWatchlistEntry(c *gin.Context) {
+	teamUUID, err := uuid.Parse(c.GetString("team_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid team ID",
+		})
+		return
+	}
+	playerUUID, err := uuid.Parse(c.Param("playerId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid player ID",
+		})
+		return
+	}
+
+	var req struct {
+		Priority    *int   `json:"priority"`
+		TargetPrice int    `json:"target_price"`
+		Notes       string `json:"notes"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid request data",
+		})
+		return
+	}
+
+	priority := 3
+	if req.Priority != nil {
+		priority = *req.Priority
+	}
+	var invalid string
+	switch {
+	case priority < 1 || priority > 5:
+		invalid = "Priority must be between 1 and 5"
+	case req.TargetPrice < 0:
+		invalid = "Target price cannot be negative"
+	case len([]rune(req.Notes)) > maxWatchlistNotes:
+		invalid = "Notes are too long"
+	}
+	if invalid != "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   invalid,
+		})
+		return
+	}
+
+	player, err := h.Store.Players().GetByID(playerUUID)
+	if err != nil || player.RegistrationStatus != models.RegistrationApproved {
+		c.JSON(http.StatusNotFound, gin.H{
+			"success": false,
+			"error":   "Player not found",
+		})
+		return
+	}
+
+	now := time.Now()
+	entry := models.WatchlistEntry{
+		TeamID:      teamUUID,
+		PlayerID:    player.ID,
+		Priority:    priority,
+		TargetPrice: req.TargetPrice,
+		Notes:       req.Notes,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	err = h.Store.Transaction(func(tx repository.Store) error {
+		// before stays nil for a new entry
+		var before interface{}
+		existing, err := tx.Watchlists().Get(teamUUID, player.ID)
+		switch {
+		case err == nil:
+			before = existing
+		case !errors.Is(err, repository.ErrNotFound):
+			return fail(http.StatusInternalServerError, "Failed to save watchlist entry")
+		}
+		if err := tx.Watchlists().Save(&entry); err != nil {
+			return fail(http.StatusInternalServerError, "Failed to save watchlist entry")
+		}
+
+		// The upsert keeps the original entry; read it back for its ID and creation time
+		if saved, err := tx.Watchlists().Get(teamUUID, player.ID); err == nil {
+			entry = *saved
+		}
+
+		if err := h.recordAudit(tx, c, "watchlist.saved", "watchlist", entry.ID, before, entry); err != nil {
+			return fail(http.StatusInternalServerError, "Failed to record audit event")
+		}
+		return nil
+	})
+	if err != nil {
+		respondError(c, err, "Failed to save watchlist entry")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    WatchlistItem{WatchlistEntry: entry, Player: viewerOf(c).player(*player)},
+	})
+}
+
+// DeleteWatchlistEntry removes a player from the team's watchlist
+func (h *Handlers) DeleteWatchlistEntry(c *gin.Context) {
+	teamUUID, err := uuid.Parse(c.GetString("team_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid team ID",
+		})
+		return
+	}
+	playerUUID, err := uuid.Parse(c.Param("playerId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid player ID",
+		})
+		return
+	}
+
+	err = h.Store.Transaction(func(tx repository.Store) error {
+		before, err := tx.Watchlists().Get(teamUUID, playerUUID)
+		if errors.Is(err, repository.ErrNotFound) {
+			// Nothing to remove
+			return nil
+		}
+		if err != nil {
+			return fail(http.StatusInternalServerError, "Failed to delete watchlist entry")
+		}
+		if err := tx.Watchlists().Delete(teamUUID, playerUUID); err != nil {
+			return fail(http.StatusInternalServerError, "Failed to delete watchlist entry")
+		}
+		if err := h.recordAudit(tx, c, "watchlist.deleted", "watchlist", before.ID, before, nil); err != nil {
+			return fail(http.StatusInternalServerError, "Failed to record audit event")
+		}
+		return nil
+	})
+	if err != nil {
+		respondError(c, err, "Failed to delete watchlist entry")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Player removed from watchlist",
+	})
+}
+
+// alertWatchers tells each team watching the player, in its own room, that
+// the player is up for bidding, with the team's priority, target and notes
+func (h *Handlers) alertWatchers(auctionID uuid.UUID, player *models.Player) {
+	entries, err := h.Store.Watchlists().ListByPlayer(player.ID)
+	if err != nil {
+		log.Printf("Failed to load watchlists for player %s: %v", player.ID, err)
+		return
+	}
+
+	for _, entry := range entries {
+		v := viewer{role: "team", teamID: entry.TeamID.String()}
+		h.Hub.Publish(websocket.TeamRoom(entry.TeamID.String()), "watched_player_up", gin.H{
+			"auction_id":   auctionID,
+			"player":       v.player(*player),
+			"priority":     entry.Priority,
+			"target_price": entry.TargetPrice,
+			"notes":        entry.Notes,
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestWatchlistChangesAreAudited(t *testing.T) {
+	s := newTestServer(t)
+	team := s.team("Smashers", 12000)
+	player := s.player("asha")
+	path := "/team/watchlist/" + player.ID.String()
+
+	status, body := s.do(teamIdentity(team), http.MethodPut, "/team/watchlist/:playerId", path, s.h.SaveWatchlistEntry, gin.H{
+		"priority":     5,
+		"target_price": 1500,
+	})
+	if status != http.StatusOK {
+		t.Fatalf("save: status %d, body %v", status, body)
+	}
+	status, body = s.do(teamIdentity(team), http.MethodDelete, "/team/watchlist/:playerId", path, s.h.DeleteWatchlistEntry, nil)
+	if status != http.StatusOK {
+		t.Fatalf("delete: status %d, body %v", status, body)
+	}
+
+	actions := s.auditActions()
+	if len(actions) != 2 || actions[0] != "watchlist.deleted" || actions[1] != "watchlist.saved" {
+		t.Fatalf("audit actions = %v, want watchlist.deleted then watchlist.saved", actions)
+	}
+}
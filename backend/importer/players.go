@@ -0,0 +1,228 @@
+package importer
+
+import (
+	"fmt"
+	"net/mail"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaxRows is the most players one file may hold
+const MaxRows = 2000
+
+// RowError is one problem found in a file. Row is the spreadsheet row number,
+// counting the header as row 1; Field names the column at fault, if any.
+type RowError struct {
+	Row     int    `json:"row"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+// PlayerRow is a validated row of a player file
+type PlayerRow struct {
+	Row             int       `json:"row"`
+	Name            string    `json:"name"`
+	Gender          string    `json:"gender"`
+	DateOfBirth     time.Time `json:"date_of_birth"`
+	Mobile          string    `json:"mobile"`
+	PlayingCategory string    `json:"playing_category"`
+	Accomplishments string    `json:"accomplishments"`
+	Email           string    `json:"email,omitempty"`
+}
+
+// DuplicateKeys are the keys a player is matched on: the mobile number, and
+// the name together with the date of birth. Dates of birth are stored as UTC
+// midnight but read back in the connection's time zone, so the date is taken
+// in UTC.
+func DuplicateKeys(name string, dob time.Time, mobile string) (byMobile, byNameDOB string) {
+	return NormalizeMobile(mobile), strings.ToLower(strings.Join(strings.Fields(name), " ")) + "|" + dob.UTC().Format("2006-01-02")
+}
+
+// columns maps accepted header spellings to fields
+var columns = map[string]string{
+	"name":             "name",
+	"full_name":        "name",
+	"gender":           "gender",
+	"sex":              "gender",
+	"date_of_birth":    "date_of_birth",
+	"dob":              "date_of_birth",
+	"birth_date":       "date_of_birth",
+	"mobile":           "mobile",
+	"phone":            "mobile",
+	"mobile_number":    "mobile",
+	"playing_category": "playing_category",
+	"category":         "playing_category",
+	"accomplishments":  "accomplishments",
+	"email":            "email",
+}
+
+var requiredColumns = []string{"name", "gender", "date_of_birth", "mobile", "playing_category"}
+
+// dateLayouts are the date formats accepted for date_of_birth, day before month
+var dateLayouts = []string{"2006-01-02", "02/01/2006", "2/1/2006", "02-01-2006", "02.01.2006", "2 Jan 2006", "02 Jan 2006"}
+
+// ParsePlayers validates the rows of a player file, whose first row names the
+// columns. Rows with errors are reported and left out of the result; blank
+// rows are skipped. Rows repeating an earlier row's mobile number or name and
+// date of birth are reported as duplicates.
+func ParsePlayers(table [][]string, now time.Time) ([]PlayerRow, []RowError) {
+	if len(table) == 0 {
+		return nil, []RowError{{Row: 1, Message: "file is empty"}}
+	}
+
+	index := make(map[string]int)
+	for i, header := range table[0] {
+		key := strings.ToLower(strings.Join(strings.Fields(strings.NewReplacer("-", " ", "_", " ").Replace(header)), "_"))
+		if field, ok := columns[key]; ok {
+			if _, seen := index[field]; !seen {
+				index[field] = i
+			}
+		}
+	}
+	var errs []RowError
+	for _, field := range requiredColumns {
+		if _, ok := index[field]; !ok {
+			errs = append(errs, RowError{Row: 1, Field: field, Message: "missing column"})
+		}
+	}
+	if errs != nil {
+		return nil, errs
+	}
+	if len(table)-1 > MaxRows {
+		return nil, []RowError{{Message: fmt.Sprintf("file has more than %d rows", MaxRows)}}
+	}
+
+	var rows []PlayerRow
+	firstByMobile := make(map[string]int)
+	firstByNameDOB := make(map[string]int)
+	for i, record := range table[1:] {
+		rowNumber := i + 2
+		cell := func(field string) string {
+			if col, ok := index[field]; ok && col < len(record) {
+				return strings.TrimSpace(record[col])
+			}
+			return ""
+		}
+		if strings.TrimSpace(strings.Join(record, "")) == "" {
+			continue
+		}
+
+		row := PlayerRow{
+			Row:             rowNumber,
+			Name:            strings.Join(strings.Fields(cell("name")), " "),
+			Accomplishments: cell("accomplishments"),
+		}
+		rowErrs := len(errs)
+		report := func(field, message string) {
+			errs = append(errs, RowError{Row: rowNumber, Field: field, Message: message})
+		}
+
+		if row.Name == "" {
+			report("name", "name is required")
+		}
+
+		switch strings.ToLower(cell("gender")) {
+		case "male", "m":
+			row.Gender = "male"
+		case "female", "f":
+			row.Gender = "female"
+		default:
+			report("gender", "gender must be male or female")
+		}
+
+		if dob, err := parseDate(cell("date_of_birth")); err != nil {
+			report("date_of_birth", err.Error())
+		} else if !dob.Before(now) {
+			report("date_of_birth", "date of birth must be in the past")
+		} else if dob.Before(now.AddDate(-100, 0, 0)) {
+			report("date_of_birth", "date of birth is more than 100 years ago")
+		} else {
+			row.DateOfBirth = dob
+		}
+
+		if mobile := NormalizeMobile(cell("mobile")); mobile == "" {
+			report("mobile", "mobile must be 10 to 15 digits, optionally starting with +")
+		} else {
+			row.Mobile = mobile
+		}
+
+		switch category := strings.ToLower(cell("playing_category")); category {
+		case "singles", "doubles", "both":
+			row.PlayingCategory = category
+		default:
+			report("playing_category", "playing_category must be singles, doubles or both")
+		}
+
+		if email := cell("email"); email != "" {
+			if addr, err := mail.ParseAddress(email); err != nil || addr.Address != email {
+				report("email", "email is not a valid address")
+			} else {
+				row.Email = strings.ToLower(email)
+			}
+		}
+
+		if len(errs) > rowErrs {
+			continue
+		}
+
+		byMobile, byNameDOB := DuplicateKeys(row.Name, row.DateOfBirth, row.Mobile)
+		if first, ok := firstByMobile[byMobile]; ok {
+			report("mobile", fmt.Sprintf("duplicate of row %d (same mobile)", first))
+			continue
+		}
+		if first, ok := firstByNameDOB[byNameDOB]; ok {
+			report("name", fmt.Sprintf("duplicate of row %d (same name and date of birth)", first))
+			continue
+		}
+		firstByMobile[byMobile] = rowNumber
+		firstByNameDOB[byNameDOB] = rowNumber
+		rows = append(rows, row)
+	}
+
+	if len(rows) == 0 && len(errs) == 0 {
+		errs = append(errs, RowError{Row: 2, Message: "file has no players"})
+	}
+	return rows, errs
+}
+
+// NormalizeMobile strips spacing and punctuation from a mobile number,
+// returning "" when what remains is not 10 to 15 digits with an optional +
+func NormalizeMobile(mobile string) string {
+	mobile = strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '(', ')', '.':
+			return -1
+		}
+		return r
+	}, strings.TrimSpace(mobile))
+
+	digits := strings.TrimPrefix(mobile, "+")
+	if len(digits) < 10 || len(digits) > 15 {
+		return ""
+	}
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return ""
+		}
+	}
+	return mobile
+}
+
+// parseDate reads a date in one of dateLayouts, or a spreadsheet date serial
+// as XLSX stores dates
+func parseDate(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, fmt.Errorf("date_of_birth is required")
+	}
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	// Days since 30 December 1899, the epoch spreadsheets count dates from
+	if serial, err := strconv.ParseFloat(value, 64); err == nil && serial >= 1 && serial < 100000 {
+		return time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC).AddDate(0, 0, int(serial)), nil
+	}
+	return time.Time{}, fmt.Errorf("date_of_birth %q is not a date; use YYYY-MM-DD or DD/MM/YYYY", value)
+}
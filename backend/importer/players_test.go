@@ -0,0 +1,21 @@
+package importer
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDuplicateKeysIgnoreTimeZone(t *testing.T) {
+	_, want := DuplicateKeys("Asha  Rao", time.Date(1998, 1, 1, 0, 0, 0, 0, time.UTC), "")
+
+	// The stored date as the database hands it back in other zones
+	stored := time.Date(1998, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, zone := range []*time.Location{
+		time.FixedZone("IST", 5*3600+1800),
+		time.FixedZone("EST", -5*3600),
+	} {
+		if _, got := DuplicateKeys("asha rao", stored.In(zone), ""); got != want {
+			t.Errorf("key in %s = %q, want %q", zone, got, want)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+// Package importer reads player spreadsheets (CSV or XLSX) and validates
+// their rows before anything is written
+package importer
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// maxUnzipBytes bounds how much of an XLSX file is decompressed, so a small
+// crafted file cannot expand to fill memory
+const maxUnzipBytes = 64 << 20
+
+// ErrUnsupportedFile is returned for files that are neither CSV nor XLSX
+var ErrUnsupportedFile = errors.New("file must be CSV or XLSX")
+
+// ReadTable reads the rows of a CSV file, or of the first sheet of an XLSX
+// workbook. XLSX is recognised by its content, whatever the file is called.
+func ReadTable(data []byte) ([][]string, error) {
+	if bytes.HasPrefix(data, []byte("PK\x03\x04")) {
+		return readXLSX(data)
+	}
+	return readCSV(data)
+}
+
+func readCSV(data []byte) ([][]string, error) {
+	data = bytes.TrimPrefix(data, []byte("\xEF\xBB\xBF")) // Excel writes a BOM
+	if bytes.IndexByte(data, 0) >= 0 {
+		return nil, ErrUnsupportedFile
+	}
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV: %w", err)
+	}
+	return rows, nil
+}
+
+// readXLSX reads the first sheet with cell values as stored, so dates arrive
+// as day serials rather than in the workbook's display format
+func readXLSX(data []byte) ([][]string, error) {
+	book, err := excelize.OpenReader(bytes.NewReader(data), excelize.Options{UnzipSizeLimit: maxUnzipBytes})
+	if err != nil {
+		return nil, fmt.Errorf("invalid XLSX: %w", err)
+	}
+	defer book.Close()
+
+	sheets := book.GetSheetList()
+	if len(sheets) == 0 {
+		return nil, errors.New("invalid XLSX: workbook has no sheets")
+	}
+	rows, err := book.GetRows(sheets[0], excelize.Options{RawCellValue: true})
+	if err != nil {
+		return nil, fmt.Errorf("invalid XLSX: %w", err)
+	}
+	return rows, nil
+}
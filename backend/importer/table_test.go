@@ -0,0 +1,72 @@
+package importer
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestReadTableXLSX(t *testing.T) {
+	book := excelize.NewFile()
+	defer book.Close()
+
+	// A shared string, a rich text cell, a number, a date and a gap left by
+	// an empty row and column
+	rows := map[string]interface{}{
+		"A1": "name", "B1": "mobile", "D1": "date_of_birth",
+		"A3": "Asha Rao", "B3": 9800000000, "D3": time.Date(1998, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	for cell, value := range rows {
+		if err := book.SetCellValue("Sheet1", cell, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := book.SetCellRichText("Sheet1", "A4", []excelize.RichTextRun{{Text: "Bina "}, {Text: "Das"}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := book.NewSheet("Notes"); err != nil {
+		t.Fatal(err)
+	}
+	if err := book.SetCellValue("Notes", "A1", "ignored"); err != nil {
+		t.Fatal(err)
+	}
+	buf, err := book.WriteToBuffer()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	table, err := ReadTable(buf.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]string{
+		{"name", "mobile", "", "date_of_birth"},
+		nil,
+		{"Asha Rao", "9800000000", "", "35796"},
+		{"Bina Das"},
+	}
+	if len(table) != len(want) {
+		t.Fatalf("rows = %q, want %q", table, want)
+	}
+	for i := range want {
+		if len(table[i]) == 0 && len(want[i]) == 0 {
+			continue
+		}
+		if !reflect.DeepEqual(table[i], want[i]) {
+			t.Fatalf("row %d = %q, want %q", i+1, table[i], want[i])
+		}
+	}
+
+	// The date serial is what parseDate reads
+	if dob, err := parseDate(table[2][3]); err != nil || !dob.Equal(time.Date(1998, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("date of birth = %v (%v)", dob, err)
+	}
+}
+
+func TestReadTableRejectsBrokenXLSX(t *testing.T) {
+	if _, err := ReadTable([]byte("PK\x03\x04 not really a zip")); err == nil {
+		t.Fatal("broken workbook was read")
+	}
+}
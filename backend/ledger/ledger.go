@@ -0,0 +1,201 @@
+package ledger
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"auction-backend/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Transaction types recorded in the points ledger
+const (
+	TypePurchase   = "purchase"
+	TypeRetention  = "retention"
+	TypeRefund     = "refund"
+	TypeAdjustment = "adjustment"
+	TypePenalty    = "penalty"
+	// A traded player's price is charged to the team they join and credited
+	// to the team they leave; points paid as part of a trade are trade payments
+	TypeTransferIn   = "transfer_in"
+	TypeTransferOut  = "transfer_out"
+	TypeTradePayment = "trade_payment"
+	// The share of a released player's price returned to their team
+	TypeRelease = "release"
+)
+
+// AcquisitionTypes are the entries that price a player on a team's roster
+var AcquisitionTypes = []string{TypePurchase, TypeRetention, TypeTransferIn}
+
+// ValidType reports whether t is a known transaction type
+func ValidType(t string) bool {
+	switch t {
+	case TypePurchase, TypeRetention, TypeRefund, TypeAdjustment, TypePenalty,
+		TypeTransferIn, TypeTransferOut, TypeTradePayment, TypeRelease:
+		return true
+	}
+	return false
+}
+
+// ManualType reports whether an admin may record a transaction of type t by
+// hand. Purchases come only from the auction, transfers only from trades, and
+// release refunds only from approved releases.
+func ManualType(t string) bool {
+	switch t {
+	case TypeRetention, TypeRefund, TypeAdjustment, TypePenalty:
+		return true
+	}
+	return false
+}
+
+// Record appends a transaction to the ledger and re-derives the team's used points
+// from it. Call it with the open transaction so both writes commit together.
+func Record(tx *gorm.DB, entry *models.PointsTransaction) error {
+	if !ValidType(entry.Type) {
+		return fmt.Errorf("invalid transaction type: %s", entry.Type)
+	}
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
+	}
+
+	if err := tx.Create(entry).Error; err != nil {
+		return fmt.Errorf("failed to record points transaction: %v", err)
+	}
+
+	return tx.Exec(`UPDATE teams SET used_points = (
+		SELECT COALESCE(SUM(amount), 0) FROM points_transactions WHERE team_id = ?
+	), updated_at = ? WHERE id = ?`, entry.TeamID, time.Now(), entry.TeamID).Error
+}
+
+// UsedPoints returns the points a team has spent according to the ledger
+func UsedPoints(db *gorm.DB, teamID uuid.UUID) (int, error) {
+	var used int
+	err := db.Model(&models.PointsTransaction{}).
+		Where("team_id = ?", teamID).
+		Select("COALESCE(SUM(amount), 0)").
+		Scan(&used).Error
+	return used, err
+}
+
+// Backfill seeds the ledger for teams that have spent points but have no ledger
+// history yet: one purchase per sold player plus an adjustment for any remainder,
+// so the ledger agrees with teams.used_points.
+func Backfill(db *gorm.DB) error {
+	var teams []models.Team
+	if err := db.Where("used_points <> 0 AND NOT EXISTS (SELECT 1 FROM points_transactions pt WHERE pt.team_id = teams.id)").
+		Find(&teams).Error; err != nil {
+		return err
+	}
+
+	for _, team := range teams {
+		err := db.Transaction(func(tx *gorm.DB) error {
+			var players []models.Player
+			if err := tx.Where("current_team_id = ? AND is_sold = ?", team.ID, true).Find(&players).Error; err != nil {
+				return err
+			}
+
+			remainder := team.UsedPoints
+			for _, player := range players {
+				playerID := player.ID
+				if err := Record(tx, &models.PointsTransaction{
+					TeamID:    team.ID,
+					PlayerID:  &playerID,
+					Type:      TypePurchase,
+					Amount:    player.CurrentPrice,
+					Note:      "Opening balance",
+					CreatedBy: "system",
+				}); err != nil {
+					return err
+				}
+				remainder -= player.CurrentPrice
+			}
+
+			if remainder != 0 {
+				return Record(tx, &models.PointsTransaction{
+					TeamID:    team.ID,
+					Type:      TypeAdjustment,
+					Amount:    remainder,
+					Note:      "Opening balance",
+					CreatedBy: "system",
+				})
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		log.Printf("Ledger backfill: opening balance of %d points for team %s", team.UsedPoints, team.Name)
+	}
+	return nil
+}
+
+// Drift describes a team whose ledger, stored counter and roster prices disagree
+type Drift struct {
+	TeamID            uuid.UUID `json:"team_id"`
+	TeamName          string    `json:"team_name"`
+	LedgerTotal       int       `json:"ledger_total"`
+	UsedPoints        int       `json:"used_points"`
+	RosterTotal       int       `json:"roster_total"`
+	RosterLedgerTotal int       `json:"roster_ledger_total"`
+}
+
+// Reconcile returns the teams whose books disagree. Two checks are made per team:
+// the ledger sum must equal teams.used_points, and the sum of current_price over
+// the sold roster must equal the latest acquisition entry for each of those
+// players. Refunds, adjustments, penalties, trade payments and release
+// refunds are not roster prices, so they are only part of the first check.
+func Reconcile(db *gorm.DB) ([]Drift, error) {
+	var rows []Drift
+
+	err := db.Raw(`
+		SELECT t.id AS team_id, t.name AS team_name, t.used_points,
+			COALESCE((SELECT SUM(amount) FROM points_transactions pt WHERE pt.team_id = t.id), 0) AS ledger_total,
+			COALESCE((SELECT SUM(current_price) FROM players p WHERE p.current_team_id = t.id AND p.is_sold), 0) AS roster_total,
+			COALESCE((SELECT SUM(lp.amount) FROM players p
+				CROSS JOIN LATERAL (
+					SELECT amount FROM points_transactions pt
+					WHERE pt.player_id = p.id AND pt.team_id = t.id AND pt.type IN ?
+					ORDER BY pt.created_at DESC LIMIT 1
+				) lp
+				WHERE p.current_team_id = t.id AND p.is_sold), 0) AS roster_ledger_total
+		FROM teams t
+		ORDER BY t.name`, AcquisitionTypes).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	drifts := []Drift{}
+	for _, row := range rows {
+		if row.LedgerTotal != row.UsedPoints || row.RosterLedgerTotal != row.RosterTotal {
+			drifts = append(drifts, row)
+		}
+	}
+	return drifts, nil
+}
+
+// StartReconciler runs Reconcile on the given interval and logs any drift found.
+// onDrift, when not nil, is also called with each non-empty result.
+func StartReconciler(db *gorm.DB, interval time.Duration, onDrift func([]Drift)) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			drifts, err := Reconcile(db)
+			if err != nil {
+				log.Printf("Ledger reconciliation failed: %v", err)
+				continue
+			}
+			for _, d := range drifts {
+				log.Printf("Ledger drift for team %s (%s): ledger=%d used_points=%d roster=%d roster_ledger=%d",
+					d.TeamName, d.TeamID, d.LedgerTotal, d.UsedPoints, d.RosterTotal, d.RosterLedgerTotal)
+			}
+			if len(drifts) > 0 && onDrift != nil {
+				onDrift(drifts)
+			}
+		}
+	}()
+}
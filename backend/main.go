@@ -1,13 +1,19 @@
 package main
 
 import (
+	"fmt"
 	"log"
 	"os"
+	"strconv"
+	"time"
 
 	"auction-backend/database"
 	"auction-backend/handlers"
+	"auction-backend/ledger"
 	"auction-backend/middleware"
+	"auction-backend/repository"
 	"auction-backend/routes"
+	"auction-backend/storage"
 	"auction-backend/websocket"
 
 	"github.com/gin-contrib/cors"
@@ -21,19 +27,60 @@ func main() {
 		log.Println("No .env file found, using system environment variables")
 	}
 
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		runMigrate(os.Args[2:])
+		return
+	}
+
 	// Initialize database
 	db, err := database.InitDB()
 	if err != nil {
 		log.Fatal("Failed to connect to database:", err)
 	}
 
+	// Seed the points ledger for teams that predate it, then watch for drift
+	if err := ledger.Backfill(db); err != nil {
+		log.Fatal("Failed to backfill points ledger:", err)
+	}
+
+	reconcileInterval := 10 * time.Minute
+	if v := os.Getenv("LEDGER_RECONCILE_INTERVAL"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			reconcileInterval = d
+		}
+	}
+
 	// Initialize Redis for sessions
 	redisClient := database.InitRedis()
 
-	// Initialize WebSocket hub
-	hub := websocket.NewHub()
+	// Initialize WebSocket hub. With WS_BROKER=redis, events published on any
+	// replica reach clients connected to every replica.
+	replayBufferSize := websocket.DefaultReplayBufferSize
+	if v := os.Getenv("WS_REPLAY_BUFFER_SIZE"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			replayBufferSize = n
+		}
+	}
+
+	var broker websocket.Broker
+	switch os.Getenv("WS_BROKER") {
+	case "redis":
+		broker = websocket.NewRedisBroker(redisClient, replayBufferSize)
+		log.Println("WebSocket events published through Redis")
+	case "", "memory":
+		broker = websocket.NewMemoryBroker(replayBufferSize)
+	default:
+		log.Fatalf("Unknown WS_BROKER %q, expected memory or redis", os.Getenv("WS_BROKER"))
+	}
+
+	hub := websocket.NewHub(broker)
 	go hub.Run()
 
+	// Report ledger drift to connected admins as well as the log
+	ledger.StartReconciler(db, reconcileInterval, func(drifts []ledger.Drift) {
+		hub.Publish(websocket.AdminRoom, "ledger_drift", drifts)
+	})
+
 	// Set Gin mode
 	if os.Getenv("GIN_MODE") == "release" {
 		gin.SetMode(gin.ReleaseMode)
@@ -46,14 +93,31 @@ func main() {
 	corsConfig := cors.DefaultConfig()
 	corsConfig.AllowOrigins = []string{"http://localhost:3000", "http://localhost:3001", "http://localhost:9999"}
 	corsConfig.AllowCredentials = true
-	corsConfig.AllowHeaders = []string{"Origin", "Content-Type", "Accept", "Authorization"}
+	corsConfig.AllowHeaders = []string{"Origin", "Content-Type", "Accept", "Authorization", "X-Request-ID"}
 	corsConfig.AllowMethods = []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}
 	r.Use(cors.New(corsConfig))
 
 	// Initialize handlers with dependencies
-	handlers := handlers.NewHandlers(db, redisClient, hub)
+	handlers := handlers.NewHandlers(repository.NewGormStore(db), db, redisClient, hub)
+	handlers.PresenceRule = os.Getenv("PRESENCE_RULE")
+	if v := os.Getenv("RELEASE_REFUND_PERCENT"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 && n <= 100 {
+			handlers.ReleaseRefundPercent = n
+		}
+	}
+
+	// File storage for player photos and documents
+	fileStorage, err := storage.FromEnv()
+	if err != nil {
+		log.Fatal("Failed to configure file storage:", err)
+	}
+	handlers.Storage = fileStorage
+	if local, ok := fileStorage.(*storage.Local); ok && local.MountPath() != "" {
+		r.Static(local.MountPath(), local.PublicDir())
+	}
 
 	// Setup middleware
+	r.Use(middleware.RequestID())
 	r.Use(middleware.Logger())
 	r.Use(middleware.Recovery())
 
@@ -71,3 +135,61 @@ func main() {
 		log.Fatal("Failed to start server:", err)
 	}
 }
+
+// runMigrate implements the migrate subcommand: migrate up | down [steps] | status
+func runMigrate(args []string) {
+	db, err := database.Connect()
+	if err != nil {
+		log.Fatal("Failed to connect to database:", err)
+	}
+
+	command := "up"
+	if len(args) > 0 {
+		command = args[0]
+	}
+
+	switch command {
+	case "up":
+		applied, err := database.MigrateUp(db)
+		for _, m := range applied {
+			fmt.Printf("Applied %04d_%s\n", m.Version, m.Name)
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+		if len(applied) == 0 {
+			fmt.Println("Schema is up to date")
+		}
+
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			if steps, err = strconv.Atoi(args[1]); err != nil || steps < 1 {
+				log.Fatal("Invalid number of steps: ", args[1])
+			}
+		}
+		reverted, err := database.MigrateDown(db, steps)
+		for _, m := range reverted {
+			fmt.Printf("Reverted %04d_%s\n", m.Version, m.Name)
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+
+	case "status":
+		states, err := database.MigrationStatus(db)
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, state := range states {
+			applied := "pending"
+			if state.AppliedAt != nil {
+				applied = "applied " + state.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Printf("%04d_%s  %s\n", state.Version, state.Name, applied)
+		}
+
+	default:
+		log.Fatalf("Unknown migrate command %q, expected up, down or status", command)
+	}
+}
@@ -0,0 +1,118 @@
+// Package media validates uploaded images and renders the sizes the app shows
+package media
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+
+	"golang.org/x/image/draw"
+)
+
+// MaxPhotoBytes is the largest photo upload accepted
+const MaxPhotoBytes = 5 << 20
+
+const (
+	// minPhotoSide keeps out images too small to show on a card
+	minPhotoSide = 100
+	// maxPhotoPixels bounds the memory a decoded photo may take: 12 MP is a
+	// phone camera photo and decodes to at most 48 MB
+	maxPhotoPixels = 12_000_000
+	jpegQuality    = 85
+)
+
+// Size is a rendition of a photo, cropped to its aspect ratio from the centre
+type Size struct {
+	Name   string
+	Width  int
+	Height int
+}
+
+// Photo sizes: a square thumbnail for lists and a 3:4 card for the auction screen
+var (
+	Thumb = Size{Name: "thumb", Width: 160, Height: 160}
+	Card  = Size{Name: "card", Width: 480, Height: 640}
+)
+
+// Errors returned for photos that cannot be used
+var (
+	ErrUnsupportedPhoto = errors.New("photo must be a JPEG or PNG image")
+	ErrPhotoTooSmall    = errors.New("photo must be at least 100x100 pixels")
+	ErrPhotoTooLarge    = errors.New("photo has too many pixels")
+)
+
+// ProcessPhoto checks an uploaded photo and renders it at each size as JPEG,
+// upright according to its EXIF orientation. The result is keyed by size name.
+func ProcessPhoto(data []byte, sizes ...Size) (map[string][]byte, error) {
+	var decode func([]byte) (image.Image, error)
+	switch http.DetectContentType(data) {
+	case "image/jpeg":
+		decode = func(b []byte) (image.Image, error) { return jpeg.Decode(bytes.NewReader(b)) }
+	case "image/png":
+		decode = func(b []byte) (image.Image, error) { return png.Decode(bytes.NewReader(b)) }
+	default:
+		return nil, ErrUnsupportedPhoto
+	}
+
+	// Check the dimensions from the header before decoding the pixels
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedPhoto
+	}
+	if config.Width < minPhotoSide || config.Height < minPhotoSide {
+		return nil, ErrPhotoTooSmall
+	}
+	if config.Width*config.Height > maxPhotoPixels {
+		return nil, ErrPhotoTooLarge
+	}
+
+	src, err := decode(data)
+	if err != nil {
+		return nil, ErrUnsupportedPhoto
+	}
+
+	orientation := exifOrientation(data)
+	renditions := make(map[string][]byte, len(sizes))
+	for _, size := range sizes {
+		var buf bytes.Buffer
+		if err := jpeg.Encode(&buf, render(src, size, orientation), &jpeg.Options{Quality: jpegQuality}); err != nil {
+			return nil, err
+		}
+		renditions[size.Name] = buf.Bytes()
+	}
+	return renditions, nil
+}
+
+// render scales the centre of src down to size, flattened onto white and
+// turned upright. Only the rendition is flattened and oriented, so the decoded
+// photo is never copied at full size.
+func render(src image.Image, size Size, orientation int) *image.RGBA {
+	// Orientations 5-8 store the photo on its side, so the crop and the
+	// rendition stay sideways until orient turns them
+	w, h := size.Width, size.Height
+	if orientation >= 5 {
+		w, h = h, w
+	}
+
+	// Flatten onto white, since JPEG has no transparency
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.Draw(dst, dst.Bounds(), image.White, image.Point{}, draw.Src)
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, cropToAspect(src.Bounds(), w, h), draw.Over, nil)
+	return orient(dst, orientation)
+}
+
+// cropToAspect returns the largest centred rectangle of r with the aspect ratio w:h
+func cropToAspect(r image.Rectangle, w, h int) image.Rectangle {
+	cw, ch := r.Dx(), r.Dy()
+	if cw*h > ch*w {
+		cw = ch * w / h
+	} else {
+		ch = cw * h / w
+	}
+	x := r.Min.X + (r.Dx()-cw)/2
+	y := r.Min.Y + (r.Dy()-ch)/2
+	return image.Rect(x, y, x+cw, y+ch)
+}
@@ -0,0 +1,154 @@
+package media
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+// encodePNG fills a w x h image with fill and encodes it as PNG
+func encodePNG(t *testing.T, w, h int, fill func(x, y int) color.Color) []byte {
+	t.Helper()
+
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, fill(x, y))
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// withOrientation inserts an EXIF block with the orientation tag after the
+// start marker of a JPEG
+func withOrientation(jpg []byte, orientation uint16) []byte {
+	tiff := []byte("MM\x00\x2a\x00\x00\x00\x08")
+	tiff = binary.BigEndian.AppendUint16(tiff, 1)
+	tiff = binary.BigEndian.AppendUint16(tiff, 0x0112)
+	tiff = binary.BigEndian.AppendUint16(tiff, 3)
+	tiff = binary.BigEndian.AppendUint32(tiff, 1)
+	tiff = binary.BigEndian.AppendUint16(tiff, orientation)
+	tiff = append(tiff, 0, 0, 0, 0, 0, 0)
+
+	segment := append([]byte("Exif\x00\x00"), tiff...)
+	app1 := []byte{0xFF, 0xE1}
+	app1 = binary.BigEndian.AppendUint16(app1, uint16(len(segment)+2))
+	app1 = append(app1, segment...)
+
+	out := append([]byte{}, jpg[:2]...)
+	out = append(out, app1...)
+	return append(out, jpg[2:]...)
+}
+
+// decodeRendition decodes a rendition and checks its size
+func decodeRendition(t *testing.T, data []byte, size Size) image.Image {
+	t.Helper()
+
+	img, err := jpeg.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("%s: %v", size.Name, err)
+	}
+	if b := img.Bounds(); b.Dx() != size.Width || b.Dy() != size.Height {
+		t.Fatalf("%s is %dx%d, want %dx%d", size.Name, b.Dx(), b.Dy(), size.Width, size.Height)
+	}
+	return img
+}
+
+// near reports whether c is within a JPEG's error of r, g, b
+func near(c color.Color, r, g, b uint8) bool {
+	cr, cg, cb, _ := c.RGBA()
+	diff := func(a uint32, b uint8) bool { d := int(a>>8) - int(b); return d > -24 && d < 24 }
+	return diff(cr, r) && diff(cg, g) && diff(cb, b)
+}
+
+func TestProcessPhotoRendersSizes(t *testing.T) {
+	// Transparent on the left, red on the right
+	data := encodePNG(t, 600, 400, func(x, y int) color.Color {
+		if x < 300 {
+			return color.NRGBA{}
+		}
+		return color.NRGBA{R: 255, A: 255}
+	})
+
+	renditions, err := ProcessPhoto(data, Thumb, Card)
+	if err != nil {
+		t.Fatal(err)
+	}
+	thumb := decodeRendition(t, renditions[Thumb.Name], Thumb)
+	decodeRendition(t, renditions[Card.Name], Card)
+
+	if c := thumb.At(5, 80); !near(c, 255, 255, 255) {
+		t.Fatalf("transparent pixel = %v, want white", c)
+	}
+	if c := thumb.At(154, 80); !near(c, 255, 0, 0) {
+		t.Fatalf("right pixel = %v, want red", c)
+	}
+}
+
+func TestProcessPhotoTurnsUpright(t *testing.T) {
+	// Stored sideways: red on the left, blue on the right. Orientation 6 means
+	// the camera was turned, so upright the red half is on top.
+	img := image.NewRGBA(image.Rect(0, 0, 800, 600))
+	for y := 0; y < 600; y++ {
+		for x := 0; x < 800; x++ {
+			if x < 400 {
+				img.Set(x, y, color.RGBA{R: 255, A: 255})
+			} else {
+				img.Set(x, y, color.RGBA{B: 255, A: 255})
+			}
+		}
+	}
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	renditions, err := ProcessPhoto(withOrientation(buf.Bytes(), 6), Card)
+	if err != nil {
+		t.Fatal(err)
+	}
+	card := decodeRendition(t, renditions[Card.Name], Card)
+	if c := card.At(240, 20); !near(c, 255, 0, 0) {
+		t.Fatalf("top pixel = %v, want red", c)
+	}
+	if c := card.At(240, 620); !near(c, 0, 0, 255) {
+		t.Fatalf("bottom pixel = %v, want blue", c)
+	}
+}
+
+func TestProcessPhotoRejects(t *testing.T) {
+	white := func(x, y int) color.Color { return color.White }
+
+	// A header claiming more pixels than allowed is refused before decoding
+	huge := encodePNG(t, 100, 100, white)
+	binary.BigEndian.PutUint32(huge[16:], 4000)
+	binary.BigEndian.PutUint32(huge[20:], 3001)
+	binary.BigEndian.PutUint32(huge[29:], crc32.ChecksumIEEE(huge[12:29]))
+
+	tests := []struct {
+		name string
+		data []byte
+		want error
+	}{
+		{"not an image", []byte("%PDF-1.4 not a photo"), ErrUnsupportedPhoto},
+		{"too small", encodePNG(t, 80, 120, white), ErrPhotoTooSmall},
+		{"too many pixels", huge, ErrPhotoTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ProcessPhoto(tt.data, Thumb); !errors.Is(err, tt.want) {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -1,12 +1,17 @@
 package middleware
 
 import (
+	"errors"
 	"fmt"
 	"log"
+	"math"
 	"net/http"
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
 // Logger middleware for request logging
@@ -38,6 +43,64 @@ func Recovery() gin.HandlerFunc {
 	})
 }
 
+// RequestID middleware tags every request with an ID, reusing X-Request-ID when the caller sent one
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader("X-Request-ID")
+		if requestID == "" {
+			requestID = uuid.New().String()
+		}
+
+		c.Set("request_id", requestID)
+		c.Header("X-Request-ID", requestID)
+		c.Next()
+	}
+}
+
+// Identity is the caller a token authenticates
+type Identity struct {
+	UserID string
+	Role   string
+	TeamID string
+}
+
+// BearerToken strips an optional "Bearer " prefix from an Authorization value
+func BearerToken(header string) string {
+	if len(header) > 7 && header[:7] == "Bearer " {
+		return header[7:]
+	}
+	return header
+}
+
+// Authenticate verifies a token and returns the identity it belongs to
+func Authenticate(token string) (Identity, error) {
+	// TODO: Implement JWT validation
+	// For now, just check if token exists and extract user info from token
+	if token == "" {
+		return Identity{}, errors.New("Invalid token")
+	}
+
+	// Extract user info from mock token (format: mock-jwt-token-{user_id})
+	if len(token) <= 15 || token[:15] != "mock-jwt-token-" {
+		return Identity{}, errors.New("Invalid token format")
+	}
+	userID := token[15:] // Extract user ID from token
+
+	// Determine role based on user ID or token pattern
+	// For team users, the token format is mock-jwt-token-team{team_id}
+	if len(userID) > 4 && userID[:4] == "team" {
+		return Identity{UserID: userID, Role: "team", TeamID: userID[4:]}, nil
+	}
+
+	// For players, the token format is mock-jwt-token-player{user_id}
+	if len(userID) > 6 && userID[:6] == "player" {
+		return Identity{UserID: userID[6:], Role: "player"}, nil
+	}
+
+	// For admin users
+	return Identity{UserID: userID, Role: "admin", TeamID: userID}, nil
+}
+
 // Auth middleware for JWT authentication
 func Auth() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -48,43 +111,24 @@ func Auth() gin.HandlerFunc {
 			return
 		}
 
-		// Remove "Bearer " prefix if present
-		if len(token) > 7 && token[:7] == "Bearer " {
-			token = token[7:]
-		}
-
-		// TODO: Implement JWT validation
-		// For now, just check if token exists and extract user info from token
-		if token == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+		identity, err := Authenticate(BearerToken(token))
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
 			c.Abort()
 			return
 		}
 
-		// Extract user info from mock token (format: mock-jwt-token-{user_id})
-		if len(token) > 15 && token[:15] == "mock-jwt-token-" {
-			userID := token[15:] // Extract user ID from token
-
-			// Set user info in context
-			c.Set("user_id", userID)
-
-			// Determine role based on user ID or token pattern
-			// For team users, the token format is mock-jwt-token-team{team_id}
-			if len(userID) > 4 && userID[:4] == "team" {
-				teamID := userID[4:] // Extract team ID
-				c.Set("user_role", "team")
-				c.Set("team_id", teamID)
-				log.Printf("Team user authenticated: userID=%s, teamID=%s", userID, teamID)
-			} else {
-				// For admin users
-				c.Set("user_role", "admin")
-				c.Set("team_id", userID)
-				log.Printf("Admin user authenticated: userID=%s", userID)
-			}
-		} else {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token format"})
-			c.Abort()
-			return
+		// Set user info in context
+		c.Set("user_id", identity.UserID)
+		c.Set("user_role", identity.Role)
+		c.Set("team_id", identity.TeamID)
+		switch identity.Role {
+		case "team":
+			log.Printf("Team user authenticated: userID=%s, teamID=%s", identity.UserID, identity.TeamID)
+		case "player":
+			log.Printf("Player user authenticated: userID=%s", identity.UserID)
+		default:
+			log.Printf("Admin user authenticated: userID=%s", identity.UserID)
 		}
 
 		c.Next()
@@ -123,3 +167,58 @@ func RoleAuth(roles ...string) gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// rateBucket is one client's token bucket
+type rateBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// RateLimit lets each client IP make burst requests at once, refilled at
+// perSecond, and answers 429 beyond that. Limits are per server instance.
+func RateLimit(perSecond float64, burst int) gin.HandlerFunc {
+	var mu sync.Mutex
+	buckets := make(map[string]*rateBucket)
+	lastSweep := time.Now()
+
+	return func(c *gin.Context) {
+		now := time.Now()
+		ip := c.ClientIP()
+
+		mu.Lock()
+		// Forget clients whose buckets have long been full again
+		if now.Sub(lastSweep) > time.Minute {
+			for key, b := range buckets {
+				if now.Sub(b.last) > time.Minute {
+					delete(buckets, key)
+				}
+			}
+			lastSweep = now
+		}
+
+		b, ok := buckets[ip]
+		if !ok {
+			b = &rateBucket{tokens: float64(burst), last: now}
+			buckets[ip] = b
+		}
+		b.tokens = math.Min(float64(burst), b.tokens+now.Sub(b.last).Seconds()*perSecond)
+		b.last = now
+		allowed := b.tokens >= 1
+		if allowed {
+			b.tokens--
+		}
+		wait := (1 - b.tokens) / perSecond
+		mu.Unlock()
+
+		if !allowed {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(wait))))
+			c.JSON(http.StatusTooManyRequests, gin.H{
+				"success": false,
+				"error":   "Too many requests",
+			})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
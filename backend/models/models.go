@@ -1,6 +1,9 @@
 package models
 
 import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -38,10 +41,26 @@ type Player struct {
 	BasePrice       int        `json:"base_price" gorm:"default:200"`
 	CurrentPrice    int        `json:"current_price" gorm:"default:200"`
 	IsSold          bool       `json:"is_sold" gorm:"default:false"`
-	CreatedAt       time.Time  `json:"created_at"`
-	UpdatedAt       time.Time  `json:"updated_at"`
+	// RegistrationStatus is set by admin review; only approved players enter the auction pool
+	RegistrationStatus string     `json:"registration_status" gorm:"not null;default:'approved';index"` // pending, approved, rejected
+	ReviewReason       string     `json:"review_reason" gorm:"type:text"`
+	ReviewedBy         string     `json:"reviewed_by"`
+	ReviewedAt         *time.Time `json:"reviewed_at"`
+	// PhotoKey is the storage key prefix of the photo's renditions
+	PhotoKey      string    `json:"-"`
+	PhotoThumbURL string    `json:"photo_thumb_url"`
+	PhotoCardURL  string    `json:"photo_card_url"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
 }
 
+// Player registration statuses
+const (
+	RegistrationPending  = "pending"
+	RegistrationApproved = "approved"
+	RegistrationRejected = "rejected"
+)
+
 // Team represents a team in the auction
 type Team struct {
 	ID          uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
@@ -90,8 +109,11 @@ type Auction struct {
 	CurrentPlayerID *uuid.UUID `json:"current_player_id" gorm:"type:uuid"`
 	CurrentBid      int        `json:"current_bid" gorm:"default:0"`
 	WinningTeamID   *uuid.UUID `json:"winning_team_id" gorm:"type:uuid"`
-	CreatedAt       time.Time  `json:"created_at"`
-	UpdatedAt       time.Time  `json:"updated_at"`
+	// Supplementary auctions sign replacements: only teams with an open
+	// replacement slot may bid, and each sale fills the winner's slot
+	Supplementary bool      `json:"supplementary" gorm:"default:false"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
 }
 
 // Bid represents a bid in an auction
@@ -120,6 +142,174 @@ type RetainedPlayer struct {
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
+// PlayerDocument is a file uploaded for a player's registration, such as an
+// ID or age proof. Documents are private to admins and the player.
+type PlayerDocument struct {
+	ID          uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	PlayerID    uuid.UUID `json:"player_id" gorm:"type:uuid;not null;index"`
+	Kind        string    `json:"kind" gorm:"not null"` // id_proof, age_proof, other
+	FileName    string    `json:"file_name" gorm:"not null"`
+	ContentType string    `json:"content_type" gorm:"not null"`
+	Size        int       `json:"size" gorm:"not null"`
+	StorageKey  string    `json:"-" gorm:"not null"`
+	UploadedBy  string    `json:"uploaded_by"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// WatchlistEntry is a player on a team's private watchlist. Only that team
+// can see it.
+type WatchlistEntry struct {
+	ID          uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	TeamID      uuid.UUID `json:"team_id" gorm:"type:uuid;not null;uniqueIndex:idx_watchlist_team_player"`
+	PlayerID    uuid.UUID `json:"player_id" gorm:"type:uuid;not null;uniqueIndex:idx_watchlist_team_player;index"`
+	Priority    int       `json:"priority" gorm:"not null;default:3"` // 1 (top target) to 5
+	TargetPrice int       `json:"target_price" gorm:"default:0"`      // 0 when not set
+	Notes       string    `json:"notes" gorm:"type:text"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// Trade is a proposal between two teams to swap players, points or both
+// after the auction. The receiving team accepts it, then an admin approves
+// it, which settles it.
+type Trade struct {
+	ID             uuid.UUID   `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	ProposerTeamID uuid.UUID   `json:"proposer_team_id" gorm:"type:uuid;not null;index"`
+	ReceiverTeamID uuid.UUID   `json:"receiver_team_id" gorm:"type:uuid;not null;index"`
+	Status         string      `json:"status" gorm:"not null;default:'proposed';index"` // proposed, accepted, completed, declined, cancelled, rejected
+	Points         int         `json:"points" gorm:"default:0"`                         // paid by the proposer to the receiver; negative when the receiver pays
+	Note           string      `json:"note" gorm:"type:text"`
+	ProposedBy     string      `json:"proposed_by"`
+	RespondedAt    *time.Time  `json:"responded_at"`
+	ReviewedBy     string      `json:"reviewed_by"`
+	ReviewReason   string      `json:"review_reason" gorm:"type:text"`
+	CompletedAt    *time.Time  `json:"completed_at"`
+	CreatedAt      time.Time   `json:"created_at"`
+	UpdatedAt      time.Time   `json:"updated_at"`
+	Items          []TradeItem `json:"items" gorm:"foreignKey:TradeID"`
+}
+
+// Trade statuses
+const (
+	TradeProposed  = "proposed"
+	TradeAccepted  = "accepted"
+	TradeCompleted = "completed"
+	TradeDeclined  = "declined"
+	TradeCancelled = "cancelled"
+	TradeRejected  = "rejected"
+)
+
+// TradeItem is one player moving between the teams of a trade
+type TradeItem struct {
+	ID         uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	TradeID    uuid.UUID `json:"trade_id" gorm:"type:uuid;not null;index"`
+	PlayerID   uuid.UUID `json:"player_id" gorm:"type:uuid;not null"`
+	FromTeamID uuid.UUID `json:"from_team_id" gorm:"type:uuid;not null"`
+	ToTeamID   uuid.UUID `json:"to_team_id" gorm:"type:uuid;not null"`
+	// Price is the player's price when the trade settled; it moves with them
+	Price int `json:"price" gorm:"default:0"`
+}
+
+// Release is a team's request to release a player from its squad. Once an
+// admin approves it, the team gets part of the price back, the player returns
+// to the pool, and the team has a slot to sign one replacement.
+type Release struct {
+	ID            uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	TeamID        uuid.UUID  `json:"team_id" gorm:"type:uuid;not null;index"`
+	PlayerID      uuid.UUID  `json:"player_id" gorm:"type:uuid;not null;index"`
+	Status        string     `json:"status" gorm:"not null;default:'requested';index"` // requested, approved, rejected
+	Reason        string     `json:"reason" gorm:"type:text"`
+	RequestedBy   string     `json:"requested_by"`
+	Price         int        `json:"price" gorm:"default:0"` // the player's price when released
+	RefundPercent int        `json:"refund_percent" gorm:"default:0"`
+	RefundAmount  int        `json:"refund_amount" gorm:"default:0"`
+	ReviewedBy    string     `json:"reviewed_by"`
+	ReviewReason  string     `json:"review_reason" gorm:"type:text"`
+	ReviewedAt    *time.Time `json:"reviewed_at"`
+	// The replacement signed into the slot; nil while the slot is open
+	ReplacementPlayerID *uuid.UUID `json:"replacement_player_id" gorm:"type:uuid"`
+	ReplacementPrice    int        `json:"replacement_price" gorm:"default:0"`
+	ReplacedAt          *time.Time `json:"replaced_at"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+}
+
+// Release statuses
+const (
+	ReleaseRequested = "requested"
+	ReleaseApproved  = "approved"
+	ReleaseRejected  = "rejected"
+)
+
+// PointsTransaction is a single movement in a team's points ledger. A team's used
+// points are the sum of its transactions; negative amounts return points.
+type PointsTransaction struct {
+	ID        uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	TeamID    uuid.UUID  `json:"team_id" gorm:"type:uuid;not null;index"`
+	PlayerID  *uuid.UUID `json:"player_id" gorm:"type:uuid;index"`
+	AuctionID *uuid.UUID `json:"auction_id" gorm:"type:uuid"`
+	Type      string     `json:"type" gorm:"not null"` // purchase, retention, refund, adjustment, penalty, transfer_in, transfer_out, trade_payment, release
+	Amount    int        `json:"amount" gorm:"not null"`
+	Note      string     `json:"note"`
+	CreatedBy string     `json:"created_by"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// AuditEvent records a single state-changing action. Rows are only ever appended.
+type AuditEvent struct {
+	ID         uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	ActorID    string    `json:"actor_id" gorm:"index"`
+	ActorRole  string    `json:"actor_role"`
+	Action     string    `json:"action" gorm:"not null;index"` // e.g. team.points_updated, bid.created
+	EntityType string    `json:"entity_type" gorm:"not null;index:idx_audit_entity"`
+	EntityID   string    `json:"entity_id" gorm:"index:idx_audit_entity"`
+	Before     JSON      `json:"before" gorm:"type:jsonb"`
+	After      JSON      `json:"after" gorm:"type:jsonb"`
+	RequestID  string    `json:"request_id" gorm:"index"`
+	IPAddress  string    `json:"ip_address"`
+	CreatedAt  time.Time `json:"created_at" gorm:"index"`
+}
+
+// JSON is a raw JSON document stored in a jsonb column
+type JSON json.RawMessage
+
+// Value implements driver.Valuer
+func (j JSON) Value() (driver.Value, error) {
+	if len(j) == 0 {
+		return nil, nil
+	}
+	return string(j), nil
+}
+
+// Scan implements sql.Scanner
+func (j *JSON) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*j = nil
+	case []byte:
+		*j = append((*j)[0:0], v...)
+	case string:
+		*j = JSON(v)
+	default:
+		return fmt.Errorf("cannot scan %T into JSON", value)
+	}
+	return nil
+}
+
+// MarshalJSON returns the raw document, or null when empty
+func (j JSON) MarshalJSON() ([]byte, error) {
+	if len(j) == 0 {
+		return []byte("null"), nil
+	}
+	return j, nil
+}
+
+// UnmarshalJSON stores a copy of the raw document
+func (j *JSON) UnmarshalJSON(data []byte) error {
+	*j = append((*j)[0:0], data...)
+	return nil
+}
+
 // BeforeCreate hook to set timestamps
 func (u *User) BeforeCreate(tx *gorm.DB) error {
 	u.CreatedAt = time.Now()
@@ -0,0 +1,584 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+
+	"auction-backend/ledger"
+	"auction-backend/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// gormStore implements Store on PostgreSQL through gorm
+type gormStore struct {
+	db *gorm.DB
+}
+
+// NewGormStore returns a Store backed by db. Pass an open transaction to run
+// repository calls inside it.
+func NewGormStore(db *gorm.DB) Store {
+	return &gormStore{db: db}
+}
+
+func (s *gormStore) Users() UserRepository                           { return &gormUsers{s.db} }
+func (s *gormStore) Players() PlayerRepository                       { return &gormPlayers{s.db} }
+func (s *gormStore) Teams() TeamRepository                           { return &gormTeams{s.db} }
+func (s *gormStore) Auctions() AuctionRepository                     { return &gormAuctions{s.db} }
+func (s *gormStore) Bids() BidRepository                             { return &gormBids{s.db} }
+func (s *gormStore) PointsTransactions() PointsTransactionRepository { return &gormPoints{s.db} }
+func (s *gormStore) AuditEvents() AuditEventRepository               { return &gormAuditEvents{s.db} }
+func (s *gormStore) PlayerDocuments() PlayerDocumentRepository       { return &gormPlayerDocuments{s.db} }
+func (s *gormStore) Watchlists() WatchlistRepository                 { return &gormWatchlists{s.db} }
+func (s *gormStore) Trades() TradeRepository                         { return &gormTrades{s.db} }
+func (s *gormStore) Releases() ReleaseRepository                     { return &gormReleases{s.db} }
+func (s *gormStore) Categories() CategoryRepository                  { return &gormCategories{s.db} }
+
+func (s *gormStore) Transaction(fn func(tx Store) error) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		return fn(NewGormStore(tx))
+	})
+}
+
+func (s *gormStore) Snapshot(fn func(tx Store) error) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		return fn(NewGormStore(tx))
+	}, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+}
+
+// notFound maps gorm's not-found error to ErrNotFound
+func notFound(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrNotFound
+	}
+	return err
+}
+
+type gormUsers struct{ db *gorm.DB }
+
+func (r *gormUsers) GetByID(id uuid.UUID) (*models.User, error) {
+	var user models.User
+	if err := r.db.First(&user, "id = ?", id).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return &user, nil
+}
+
+func (r *gormUsers) GetByEmail(email string) (*models.User, error) {
+	var user models.User
+	if err := r.db.Where("email = ?", email).First(&user).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return &user, nil
+}
+
+func (r *gormUsers) ExistsByEmailOrUsername(email, username string) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.User{}).Where("email = ? OR username = ?", email, username).Count(&count).Error
+	return count > 0, err
+}
+
+func (r *gormUsers) Create(user *models.User) error {
+	return r.db.Create(user).Error
+}
+
+type gormPlayers struct{ db *gorm.DB }
+
+// categoryScope filters players by derived category, matching Player.GetPlayerCategory
+func categoryScope(playerCategory string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		switch playerCategory {
+		case "women":
+			return db.Where("gender = ?", "female")
+		case "men_under_35":
+			return db.Where("gender = ? AND EXTRACT(YEAR FROM AGE(date_of_birth)) < ?", "male", 35)
+		case "men_35_plus":
+			return db.Where("gender = ? AND EXTRACT(YEAR FROM AGE(date_of_birth)) >= ?", "male", 35)
+		}
+		return db
+	}
+}
+
+func (r *gormPlayers) GetByID(id uuid.UUID) (*models.Player, error) {
+	var player models.Player
+	if err := r.db.Preload("User").First(&player, "id = ?", id).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return &player, nil
+}
+
+func (r *gormPlayers) GetByIDForUpdate(id uuid.UUID) (*models.Player, error) {
+	var player models.Player
+	if err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("User").First(&player, "id = ?", id).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return &player, nil
+}
+
+func (r *gormPlayers) GetByUserID(userID uuid.UUID) (*models.Player, error) {
+	var player models.Player
+	if err := r.db.Preload("User").First(&player, "user_id = ?", userID).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return &player, nil
+}
+
+// filtered applies filter to a player query
+func (r *gormPlayers) filtered(filter PlayerFilter) *gorm.DB {
+	query := r.db.Model(&models.Player{})
+
+	switch filter.Status {
+	case "sold":
+		query = query.Where("is_sold = ?", true)
+	case "unsold":
+		query = query.Where("is_sold = ?", false)
+	}
+
+	if filter.PlayingCategory != "" {
+		query = query.Where("playing_category = ?", filter.PlayingCategory)
+	}
+	if filter.RegistrationStatus != "" {
+		query = query.Where("registration_status = ?", filter.RegistrationStatus)
+	}
+	return query.Scopes(categoryScope(filter.PlayerCategory))
+}
+
+func (r *gormPlayers) List(filter PlayerFilter) ([]models.Player, error) {
+	var players []models.Player
+	err := r.filtered(filter).Preload("User").Find(&players).Error
+	return players, err
+}
+
+func (r *gormPlayers) Count(filter PlayerFilter) (int, error) {
+	var count int64
+	err := r.filtered(filter).Count(&count).Error
+	return int(count), err
+}
+
+func (r *gormPlayers) ListByTeam(teamID uuid.UUID) ([]models.Player, error) {
+	var players []models.Player
+	err := r.db.Preload("User").Where("current_team_id = ?", teamID).Find(&players).Error
+	return players, err
+}
+
+func (r *gormPlayers) NextUnsold(playerCategory string, afterID *uuid.UUID, withReleased bool) (*models.Player, error) {
+	query := r.db.Where("is_sold = ? AND registration_status = ?", false, models.RegistrationApproved).
+		Scopes(categoryScope(playerCategory))
+	if afterID != nil {
+		query = query.Where("id > ?", *afterID)
+	}
+	if !withReleased {
+		query = query.Where("NOT EXISTS (SELECT 1 FROM releases WHERE releases.player_id = players.id AND releases.status = ?)", models.ReleaseApproved)
+	}
+
+	var player models.Player
+	if err := query.First(&player).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return &player, nil
+}
+
+func (r *gormPlayers) Create(player *models.Player) error {
+	return r.db.Omit(clause.Associations).Create(player).Error
+}
+
+func (r *gormPlayers) Save(player *models.Player) error {
+	return r.db.Omit(clause.Associations).Save(player).Error
+}
+
+func (r *gormPlayers) Updates(player *models.Player, changes *models.Player) error {
+	return r.db.Model(player).Omit(clause.Associations).Updates(changes).Error
+}
+
+func (r *gormPlayers) Delete(id uuid.UUID) error {
+	return r.db.Delete(&models.Player{}, "id = ?", id).Error
+}
+
+type gormTeams struct{ db *gorm.DB }
+
+func (r *gormTeams) GetByID(id uuid.UUID) (*models.Team, error) {
+	var team models.Team
+	if err := r.db.First(&team, "id = ?", id).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return &team, nil
+}
+
+func (r *gormTeams) GetByIDForUpdate(id uuid.UUID) (*models.Team, error) {
+	var team models.Team
+	if err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&team, "id = ?", id).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return &team, nil
+}
+
+func (r *gormTeams) ListWithPlayers() ([]models.Team, error) {
+	var teams []models.Team
+	err := r.db.Preload("Players").Find(&teams).Error
+	return teams, err
+}
+
+func (r *gormTeams) Count() (int, error) {
+	var count int64
+	err := r.db.Model(&models.Team{}).Count(&count).Error
+	return int(count), err
+}
+
+func (r *gormTeams) TotalUsedPoints() (int, error) {
+	var total int64
+	err := r.db.Model(&models.Team{}).Select("COALESCE(SUM(used_points), 0)").Scan(&total).Error
+	return int(total), err
+}
+
+func (r *gormTeams) Create(team *models.Team) error {
+	return r.db.Omit(clause.Associations).Create(team).Error
+}
+
+func (r *gormTeams) Save(team *models.Team) error {
+	return r.db.Omit(clause.Associations).Save(team).Error
+}
+
+func (r *gormTeams) Updates(team *models.Team, changes *models.Team) error {
+	return r.db.Model(team).Omit(clause.Associations).Updates(changes).Error
+}
+
+type gormAuctions struct{ db *gorm.DB }
+
+func (r *gormAuctions) GetByID(id uuid.UUID) (*models.Auction, error) {
+	var auction models.Auction
+	if err := r.db.First(&auction, "id = ?", id).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return &auction, nil
+}
+
+func (r *gormAuctions) GetByIDForUpdate(id uuid.UUID) (*models.Auction, error) {
+	var auction models.Auction
+	if err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&auction, "id = ?", id).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return &auction, nil
+}
+
+func (r *gormAuctions) List(status string) ([]models.Auction, error) {
+	query := r.db
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var auctions []models.Auction
+	err := query.Find(&auctions).Error
+	return auctions, err
+}
+
+func (r *gormAuctions) Count(status string) (int, error) {
+	query := r.db.Model(&models.Auction{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var count int64
+	err := query.Count(&count).Error
+	return int(count), err
+}
+
+func (r *gormAuctions) GetActive() (*models.Auction, error) {
+	var auction models.Auction
+	if err := r.db.Where("status = ?", "active").First(&auction).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return &auction, nil
+}
+
+func (r *gormAuctions) Create(auction *models.Auction) error {
+	return r.db.Create(auction).Error
+}
+
+func (r *gormAuctions) Save(auction *models.Auction) error {
+	return r.db.Save(auction).Error
+}
+
+func (r *gormAuctions) Updates(auction *models.Auction, changes *models.Auction) error {
+	return r.db.Model(auction).Updates(changes).Error
+}
+
+func (r *gormAuctions) Delete(id uuid.UUID) error {
+	return r.db.Delete(&models.Auction{}, "id = ?", id).Error
+}
+
+type gormBids struct{ db *gorm.DB }
+
+func (r *gormBids) Create(bid *models.Bid) error {
+	return r.db.Omit(clause.Associations).Create(bid).Error
+}
+
+func (r *gormBids) Count() (int, error) {
+	var count int64
+	err := r.db.Model(&models.Bid{}).Count(&count).Error
+	return int(count), err
+}
+
+func (r *gormBids) ClearWinning(auctionID uuid.UUID) error {
+	return r.db.Model(&models.Bid{}).
+		Where("auction_id = ? AND is_winning = ?", auctionID, true).
+		Update("is_winning", false).Error
+}
+
+func (r *gormBids) GetWinning(auctionID uuid.UUID) (*models.Bid, error) {
+	var bid models.Bid
+	if err := r.db.Where("auction_id = ? AND is_winning = ?", auctionID, true).
+		Preload("Team").
+		First(&bid).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return &bid, nil
+}
+
+func (r *gormBids) ListByAuction(auctionID uuid.UUID) ([]models.Bid, error) {
+	var bids []models.Bid
+	err := r.db.Where("auction_id = ?", auctionID).
+		Preload("Team").
+		Order("created_at DESC").
+		Find(&bids).Error
+	return bids, err
+}
+
+func (r *gormBids) ListRecentByTeam(teamID uuid.UUID, limit int) ([]models.Bid, error) {
+	var bids []models.Bid
+	err := r.db.Where("team_id = ?", teamID).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&bids).Error
+	return bids, err
+}
+
+type gormPoints struct{ db *gorm.DB }
+
+func (r *gormPoints) Record(entry *models.PointsTransaction) error {
+	return ledger.Record(r.db, entry)
+}
+
+func (r *gormPoints) UsedPoints(teamID uuid.UUID) (int, error) {
+	return ledger.UsedPoints(r.db, teamID)
+}
+
+func (r *gormPoints) ListByTeam(teamID uuid.UUID) ([]models.PointsTransaction, error) {
+	var transactions []models.PointsTransaction
+	err := r.db.Where("team_id = ?", teamID).Order("created_at DESC").Find(&transactions).Error
+	return transactions, err
+}
+
+func (r *gormPoints) ListRecentPurchases(auctionID uuid.UUID, limit int) ([]models.PointsTransaction, error) {
+	var transactions []models.PointsTransaction
+	err := r.db.Where("auction_id = ? AND type = ?", auctionID, ledger.TypePurchase).
+		Order("created_at DESC").Limit(limit).Find(&transactions).Error
+	return transactions, err
+}
+
+type gormAuditEvents struct{ db *gorm.DB }
+
+func (r *gormAuditEvents) Create(event *models.AuditEvent) error {
+	return r.db.Create(event).Error
+}
+
+func (r *gormAuditEvents) List(filter AuditEventFilter) ([]models.AuditEvent, int, error) {
+	query := r.db.Model(&models.AuditEvent{})
+
+	columns := map[string]string{
+		"actor_id":    filter.ActorID,
+		"actor_role":  filter.ActorRole,
+		"action":      filter.Action,
+		"entity_type": filter.EntityType,
+		"entity_id":   filter.EntityID,
+		"request_id":  filter.RequestID,
+	}
+	for column, value := range columns {
+		if value != "" {
+			query = query.Where(column+" = ?", value)
+		}
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at <= ?", *filter.To)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var events []models.AuditEvent
+	err := query.Order("created_at DESC").Limit(filter.Limit).Offset(filter.Offset).Find(&events).Error
+	return events, int(total), err
+}
+
+type gormCategories struct{ db *gorm.DB }
+
+func (r *gormCategories) List() ([]models.Category, error) {
+	var categories []models.Category
+	err := r.db.Find(&categories).Error
+	return categories, err
+}
+
+func (r *gormCategories) Create(category *models.Category) error {
+	return r.db.Create(category).Error
+}
+
+type gormPlayerDocuments struct{ db *gorm.DB }
+
+func (r *gormPlayerDocuments) GetByID(id uuid.UUID) (*models.PlayerDocument, error) {
+	var document models.PlayerDocument
+	if err := r.db.First(&document, "id = ?", id).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return &document, nil
+}
+
+func (r *gormPlayerDocuments) ListByPlayer(playerID uuid.UUID) ([]models.PlayerDocument, error) {
+	var documents []models.PlayerDocument
+	err := r.db.Where("player_id = ?", playerID).Order("created_at DESC").Find(&documents).Error
+	return documents, err
+}
+
+func (r *gormPlayerDocuments) Create(document *models.PlayerDocument) error {
+	return r.db.Create(document).Error
+}
+
+func (r *gormPlayerDocuments) Delete(id uuid.UUID) error {
+	return r.db.Delete(&models.PlayerDocument{}, "id = ?", id).Error
+}
+
+type gormWatchlists struct{ db *gorm.DB }
+
+func (r *gormWatchlists) Get(teamID, playerID uuid.UUID) (*models.WatchlistEntry, error) {
+	var entry models.WatchlistEntry
+	if err := r.db.Where("team_id = ? AND player_id = ?", teamID, playerID).First(&entry).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return &entry, nil
+}
+
+func (r *gormWatchlists) ListByTeam(teamID uuid.UUID) ([]models.WatchlistEntry, error) {
+	var entries []models.WatchlistEntry
+	err := r.db.Where("team_id = ?", teamID).Order("priority, created_at").Find(&entries).Error
+	return entries, err
+}
+
+func (r *gormWatchlists) ListByPlayer(playerID uuid.UUID) ([]models.WatchlistEntry, error) {
+	var entries []models.WatchlistEntry
+	err := r.db.Where("player_id = ?", playerID).Find(&entries).Error
+	return entries, err
+}
+
+func (r *gormWatchlists) Save(entry *models.WatchlistEntry) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "team_id"}, {Name: "player_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"priority", "target_price", "notes", "updated_at"}),
+	}).Create(entry).Error
+}
+
+func (r *gormWatchlists) Delete(teamID, playerID uuid.UUID) error {
+	return r.db.Where("team_id = ? AND player_id = ?", teamID, playerID).Delete(&models.WatchlistEntry{}).Error
+}
+
+type gormTrades struct{ db *gorm.DB }
+
+func (r *gormTrades) GetByID(id uuid.UUID) (*models.Trade, error) {
+	var trade models.Trade
+	if err := r.db.Preload("Items").First(&trade, "id = ?", id).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return &trade, nil
+}
+
+func (r *gormTrades) GetByIDForUpdate(id uuid.UUID) (*models.Trade, error) {
+	var trade models.Trade
+	if err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&trade, "id = ?", id).Error; err != nil {
+		return nil, notFound(err)
+	}
+	if err := r.db.Where("trade_id = ?", trade.ID).Find(&trade.Items).Error; err != nil {
+		return nil, err
+	}
+	return &trade, nil
+}
+
+func (r *gormTrades) List(filter TradeFilter) ([]models.Trade, error) {
+	query := r.db.Preload("Items")
+	if filter.TeamID != nil {
+		query = query.Where("proposer_team_id = ? OR receiver_team_id = ?", *filter.TeamID, *filter.TeamID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+
+	var trades []models.Trade
+	err := query.Order("created_at DESC").Find(&trades).Error
+	return trades, err
+}
+
+func (r *gormTrades) Create(trade *models.Trade) error {
+	return r.db.Create(trade).Error
+}
+
+func (r *gormTrades) Save(trade *models.Trade) error {
+	if err := r.db.Omit(clause.Associations).Save(trade).Error; err != nil {
+		return err
+	}
+	for i := range trade.Items {
+		if err := r.db.Save(&trade.Items[i]).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type gormReleases struct{ db *gorm.DB }
+
+func (r *gormReleases) GetByID(id uuid.UUID) (*models.Release, error) {
+	var release models.Release
+	if err := r.db.First(&release, "id = ?", id).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return &release, nil
+}
+
+func (r *gormReleases) GetByIDForUpdate(id uuid.UUID) (*models.Release, error) {
+	var release models.Release
+	if err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&release, "id = ?", id).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return &release, nil
+}
+
+func (r *gormReleases) List(filter ReleaseFilter) ([]models.Release, error) {
+	query := r.db
+	if filter.TeamID != nil {
+		query = query.Where("team_id = ?", *filter.TeamID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+
+	var releases []models.Release
+	err := query.Order("created_at DESC").Find(&releases).Error
+	return releases, err
+}
+
+func (r *gormReleases) ListOpen(teamID uuid.UUID) ([]models.Release, error) {
+	var releases []models.Release
+	err := r.db.Where("team_id = ? AND status = ? AND replacement_player_id IS NULL", teamID, models.ReleaseApproved).
+		Order("reviewed_at, created_at").Find(&releases).Error
+	return releases, err
+}
+
+func (r *gormReleases) Create(release *models.Release) error {
+	return r.db.Create(release).Error
+}
+
+func (r *gormReleases) Save(release *models.Release) error {
+	return r.db.Save(release).Error
+}
@@ -0,0 +1,1080 @@
+package repository
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"auction-backend/ledger"
+	"auction-backend/models"
+
+	"github.com/google/uuid"
+)
+
+// memoryData is the state held by a MemoryStore
+type memoryData struct {
+	users      map[uuid.UUID]models.User
+	players    map[uuid.UUID]models.Player
+	teams      map[uuid.UUID]models.Team
+	auctions   map[uuid.UUID]models.Auction
+	bids       []models.Bid
+	points     []models.PointsTransaction
+	audit      []models.AuditEvent
+	docs       map[uuid.UUID]models.PlayerDocument
+	watch      map[uuid.UUID]models.WatchlistEntry
+	trades     map[uuid.UUID]models.Trade
+	releases   map[uuid.UUID]models.Release
+	categories []models.Category
+}
+
+func (d *memoryData) clone() *memoryData {
+	c := &memoryData{
+		users:      make(map[uuid.UUID]models.User, len(d.users)),
+		players:    make(map[uuid.UUID]models.Player, len(d.players)),
+		teams:      make(map[uuid.UUID]models.Team, len(d.teams)),
+		auctions:   make(map[uuid.UUID]models.Auction, len(d.auctions)),
+		bids:       append([]models.Bid(nil), d.bids...),
+		points:     append([]models.PointsTransaction(nil), d.points...),
+		audit:      append([]models.AuditEvent(nil), d.audit...),
+		docs:       make(map[uuid.UUID]models.PlayerDocument, len(d.docs)),
+		watch:      make(map[uuid.UUID]models.WatchlistEntry, len(d.watch)),
+		trades:     make(map[uuid.UUID]models.Trade, len(d.trades)),
+		releases:   make(map[uuid.UUID]models.Release, len(d.releases)),
+		categories: append([]models.Category(nil), d.categories...),
+	}
+	for k, v := range d.users {
+		c.users[k] = v
+	}
+	for k, v := range d.players {
+		c.players[k] = v
+	}
+	for k, v := range d.teams {
+		c.teams[k] = v
+	}
+	for k, v := range d.auctions {
+		c.auctions[k] = v
+	}
+	for k, v := range d.docs {
+		c.docs[k] = v
+	}
+	for k, v := range d.watch {
+		c.watch[k] = v
+	}
+	for k, v := range d.trades {
+		c.trades[k] = v
+	}
+	for k, v := range d.releases {
+		c.releases[k] = v
+	}
+	return c
+}
+
+// MemoryStore is an in-process Store for tests and local runs without Postgres.
+// A transaction works on its own copy of the data, swapped in when it commits.
+// Transactions are serialized, and a write made outside one waits for the
+// running transaction to finish, so no write is lost when it commits.
+type MemoryStore struct {
+	mu   sync.Mutex // guards data
+	txMu sync.Mutex // held by a running transaction and by writes outside one
+	data *memoryData
+}
+
+// NewMemoryStore returns an empty in-memory Store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		data: &memoryData{
+			users:    map[uuid.UUID]models.User{},
+			players:  map[uuid.UUID]models.Player{},
+			teams:    map[uuid.UUID]models.Team{},
+			auctions: map[uuid.UUID]models.Auction{},
+			docs:     map[uuid.UUID]models.PlayerDocument{},
+			watch:    map[uuid.UUID]models.WatchlistEntry{},
+			trades:   map[uuid.UUID]models.Trade{},
+			releases: map[uuid.UUID]models.Release{},
+		},
+	}
+}
+
+func (s *MemoryStore) Users() UserRepository                           { return &memoryUsers{s} }
+func (s *MemoryStore) Players() PlayerRepository                       { return &memoryPlayers{s} }
+func (s *MemoryStore) Teams() TeamRepository                           { return &memoryTeams{s} }
+func (s *MemoryStore) Auctions() AuctionRepository                     { return &memoryAuctions{s} }
+func (s *MemoryStore) Bids() BidRepository                             { return &memoryBids{s} }
+func (s *MemoryStore) PointsTransactions() PointsTransactionRepository { return &memoryPoints{s} }
+func (s *MemoryStore) AuditEvents() AuditEventRepository               { return &memoryAuditEvents{s} }
+func (s *MemoryStore) PlayerDocuments() PlayerDocumentRepository       { return &memoryPlayerDocuments{s} }
+func (s *MemoryStore) Watchlists() WatchlistRepository                 { return &memoryWatchlists{s} }
+func (s *MemoryStore) Trades() TradeRepository                         { return &memoryTrades{s} }
+func (s *MemoryStore) Releases() ReleaseRepository                     { return &memoryReleases{s} }
+func (s *MemoryStore) Categories() CategoryRepository                  { return &memoryCategories{s} }
+
+func (s *MemoryStore) Transaction(fn func(tx Store) error) error {
+	s.txMu.Lock()
+	defer s.txMu.Unlock()
+
+	s.mu.Lock()
+	tx := &MemoryStore{data: s.data.clone()}
+	s.mu.Unlock()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.data = tx.data
+	s.mu.Unlock()
+	return nil
+}
+
+// Snapshot runs fn against a copy of the committed data, so writes made by fn are discarded
+func (s *MemoryStore) Snapshot(fn func(tx Store) error) error {
+	s.mu.Lock()
+	snapshot := &MemoryStore{data: s.data.clone()}
+	s.mu.Unlock()
+
+	return fn(snapshot)
+}
+
+// lockWrite locks s for a write and returns the unlock function. Taking txMu
+// makes a write outside a transaction wait until a running one has committed.
+func (s *MemoryStore) lockWrite() func() {
+	s.txMu.Lock()
+	s.mu.Lock()
+	return func() {
+		s.mu.Unlock()
+		s.txMu.Unlock()
+	}
+}
+
+// stamp fills in the ID and timestamps the database would otherwise set
+func stamp(id *uuid.UUID, createdAt, updatedAt *time.Time) {
+	now := time.Now()
+	if *id == uuid.Nil {
+		*id = uuid.New()
+	}
+	if createdAt != nil && createdAt.IsZero() {
+		*createdAt = now
+	}
+	if updatedAt != nil && updatedAt.IsZero() {
+		*updatedAt = now
+	}
+}
+
+// mergeNonZero copies the non-zero exported fields of src onto dst, like gorm's Updates with a struct
+func mergeNonZero(dst, src interface{}) {
+	dv := reflect.ValueOf(dst).Elem()
+	sv := reflect.ValueOf(src).Elem()
+	for i := 0; i < sv.NumField(); i++ {
+		field := sv.Type().Field(i)
+		if !field.IsExported() || field.Type.Kind() == reflect.Slice || field.Type.Kind() == reflect.Struct && field.Type != reflect.TypeOf(time.Time{}) {
+			continue
+		}
+		if !sv.Field(i).IsZero() {
+			dv.Field(i).Set(sv.Field(i))
+		}
+	}
+}
+
+func lessID(a, b uuid.UUID) bool {
+	return bytes.Compare(a[:], b[:]) < 0
+}
+
+type memoryUsers struct{ s *MemoryStore }
+
+func (r *memoryUsers) GetByID(id uuid.UUID) (*models.User, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	user, ok := r.s.data.users[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &user, nil
+}
+
+func (r *memoryUsers) GetByEmail(email string) (*models.User, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for _, user := range r.s.data.users {
+		if user.Email == email {
+			return &user, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (r *memoryUsers) ExistsByEmailOrUsername(email, username string) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for _, user := range r.s.data.users {
+		if user.Email == email || user.Username == username {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *memoryUsers) Create(user *models.User) error {
+	unlock := r.s.lockWrite()
+	defer unlock()
+
+	stamp(&user.ID, &user.CreatedAt, &user.UpdatedAt)
+	if user.Role == "" {
+		user.Role = "player"
+	}
+	r.s.data.users[user.ID] = *user
+	return nil
+}
+
+type memoryPlayers struct{ s *MemoryStore }
+
+// load returns a copy of the player as a database read would: User attached and derived fields computed
+func (r *memoryPlayers) load(player models.Player) models.Player {
+	player.User = r.s.data.users[player.UserID]
+	player.CalculateAge()
+	player.PlayerCategory = player.GetPlayerCategory()
+	return player
+}
+
+// sorted returns players ordered by ID, the order Postgres uses for uuid primary keys
+func (r *memoryPlayers) sorted(match func(models.Player) bool) []models.Player {
+	players := []models.Player{}
+	for _, player := range r.s.data.players {
+		if match(player) {
+			players = append(players, r.load(player))
+		}
+	}
+	sort.Slice(players, func(i, j int) bool {
+		return lessID(players[i].ID, players[j].ID)
+	})
+	return players
+}
+
+func (r *memoryPlayers) GetByID(id uuid.UUID) (*models.Player, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	player, ok := r.s.data.players[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	player = r.load(player)
+	return &player, nil
+}
+
+func (r *memoryPlayers) GetByIDForUpdate(id uuid.UUID) (*models.Player, error) {
+	return r.GetByID(id)
+}
+
+func (r *memoryPlayers) GetByUserID(userID uuid.UUID) (*models.Player, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for _, player := range r.s.data.players {
+		if player.UserID == userID {
+			player = r.load(player)
+			return &player, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+// matches reports whether p passes filter
+func (r *memoryPlayers) matches(filter PlayerFilter) func(models.Player) bool {
+	return func(p models.Player) bool {
+		if filter.Status == "sold" && !p.IsSold || filter.Status == "unsold" && p.IsSold {
+			return false
+		}
+		if filter.PlayingCategory != "" && p.PlayingCategory != filter.PlayingCategory {
+			return false
+		}
+		if filter.PlayerCategory != "" && p.GetPlayerCategory() != filter.PlayerCategory {
+			return false
+		}
+		if filter.RegistrationStatus != "" && p.RegistrationStatus != filter.RegistrationStatus {
+			return false
+		}
+		return true
+	}
+}
+
+func (r *memoryPlayers) List(filter PlayerFilter) ([]models.Player, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.sorted(r.matches(filter)), nil
+}
+
+func (r *memoryPlayers) Count(filter PlayerFilter) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	count := 0
+	match := r.matches(filter)
+	for _, player := range r.s.data.players {
+		if match(player) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *memoryPlayers) ListByTeam(teamID uuid.UUID) ([]models.Player, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.sorted(func(p models.Player) bool {
+		return p.CurrentTeamID != nil && *p.CurrentTeamID == teamID
+	}), nil
+}
+
+func (r *memoryPlayers) NextUnsold(playerCategory string, afterID *uuid.UUID, withReleased bool) (*models.Player, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	released := map[uuid.UUID]bool{}
+	if !withReleased {
+		for _, release := range r.s.data.releases {
+			if release.Status == models.ReleaseApproved {
+				released[release.PlayerID] = true
+			}
+		}
+	}
+	players := r.sorted(func(p models.Player) bool {
+		if p.IsSold || p.RegistrationStatus != models.RegistrationApproved || released[p.ID] {
+			return false
+		}
+		if playerCategory != "" && p.GetPlayerCategory() != playerCategory {
+			return false
+		}
+		return afterID == nil || lessID(*afterID, p.ID)
+	})
+	if len(players) == 0 {
+		return nil, ErrNotFound
+	}
+	return &players[0], nil
+}
+
+func (r *memoryPlayers) Create(player *models.Player) error {
+	unlock := r.s.lockWrite()
+	defer unlock()
+
+	stamp(&player.ID, &player.CreatedAt, &player.UpdatedAt)
+	if player.RegistrationStatus == "" {
+		player.RegistrationStatus = models.RegistrationApproved // the column default
+	}
+	player.CalculateAge()
+	r.s.data.players[player.ID] = *player
+	return nil
+}
+
+func (r *memoryPlayers) Save(player *models.Player) error {
+	unlock := r.s.lockWrite()
+	defer unlock()
+
+	stamp(&player.ID, &player.CreatedAt, nil)
+	player.UpdatedAt = time.Now()
+	player.CalculateAge()
+	r.s.data.players[player.ID] = *player
+	return nil
+}
+
+func (r *memoryPlayers) Updates(player *models.Player, changes *models.Player) error {
+	unlock := r.s.lockWrite()
+	defer unlock()
+
+	stored, ok := r.s.data.players[player.ID]
+	if !ok {
+		return ErrNotFound
+	}
+	mergeNonZero(&stored, changes)
+	r.s.data.players[player.ID] = stored
+	mergeNonZero(player, changes)
+	return nil
+}
+
+func (r *memoryPlayers) Delete(id uuid.UUID) error {
+	unlock := r.s.lockWrite()
+	defer unlock()
+
+	delete(r.s.data.players, id)
+	return nil
+}
+
+type memoryTeams struct{ s *MemoryStore }
+
+func (r *memoryTeams) GetByID(id uuid.UUID) (*models.Team, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	team, ok := r.s.data.teams[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &team, nil
+}
+
+func (r *memoryTeams) GetByIDForUpdate(id uuid.UUID) (*models.Team, error) {
+	return r.GetByID(id)
+}
+
+func (r *memoryTeams) ListWithPlayers() ([]models.Team, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	players := &memoryPlayers{r.s}
+	teams := []models.Team{}
+	for _, team := range r.s.data.teams {
+		teamID := team.ID
+		team.Players = players.sorted(func(p models.Player) bool {
+			return p.CurrentTeamID != nil && *p.CurrentTeamID == teamID
+		})
+		teams = append(teams, team)
+	}
+	sort.Slice(teams, func(i, j int) bool {
+		return lessID(teams[i].ID, teams[j].ID)
+	})
+	return teams, nil
+}
+
+func (r *memoryTeams) Count() (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return len(r.s.data.teams), nil
+}
+
+func (r *memoryTeams) TotalUsedPoints() (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	total := 0
+	for _, team := range r.s.data.teams {
+		total += team.UsedPoints
+	}
+	return total, nil
+}
+
+func (r *memoryTeams) Create(team *models.Team) error {
+	unlock := r.s.lockWrite()
+	defer unlock()
+
+	stamp(&team.ID, &team.CreatedAt, &team.UpdatedAt)
+	stored := *team
+	stored.Players = nil
+	r.s.data.teams[team.ID] = stored
+	return nil
+}
+
+func (r *memoryTeams) Save(team *models.Team) error {
+	unlock := r.s.lockWrite()
+	defer unlock()
+
+	stamp(&team.ID, &team.CreatedAt, nil)
+	team.UpdatedAt = time.Now()
+	stored := *team
+	stored.Players = nil
+	r.s.data.teams[team.ID] = stored
+	return nil
+}
+
+func (r *memoryTeams) Updates(team *models.Team, changes *models.Team) error {
+	unlock := r.s.lockWrite()
+	defer unlock()
+
+	stored, ok := r.s.data.teams[team.ID]
+	if !ok {
+		return ErrNotFound
+	}
+	mergeNonZero(&stored, changes)
+	r.s.data.teams[team.ID] = stored
+	mergeNonZero(team, changes)
+	return nil
+}
+
+type memoryAuctions struct{ s *MemoryStore }
+
+func (r *memoryAuctions) GetByID(id uuid.UUID) (*models.Auction, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	auction, ok := r.s.data.auctions[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &auction, nil
+}
+
+// GetByIDForUpdate needs no lock of its own: transactions already run one at a time
+func (r *memoryAuctions) GetByIDForUpdate(id uuid.UUID) (*models.Auction, error) {
+	return r.GetByID(id)
+}
+
+func (r *memoryAuctions) List(status string) ([]models.Auction, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	auctions := []models.Auction{}
+	for _, auction := range r.s.data.auctions {
+		if status == "" || auction.Status == status {
+			auctions = append(auctions, auction)
+		}
+	}
+	sort.Slice(auctions, func(i, j int) bool {
+		return auctions[i].CreatedAt.Before(auctions[j].CreatedAt)
+	})
+	return auctions, nil
+}
+
+func (r *memoryAuctions) Count(status string) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	count := 0
+	for _, auction := range r.s.data.auctions {
+		if status == "" || auction.Status == status {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *memoryAuctions) GetActive() (*models.Auction, error) {
+	auctions, _ := r.List("active")
+	if len(auctions) == 0 {
+		return nil, ErrNotFound
+	}
+	return &auctions[0], nil
+}
+
+func (r *memoryAuctions) Create(auction *models.Auction) error {
+	unlock := r.s.lockWrite()
+	defer unlock()
+
+	stamp(&auction.ID, &auction.CreatedAt, &auction.UpdatedAt)
+	if auction.Status == "" {
+		auction.Status = "pending"
+	}
+	r.s.data.auctions[auction.ID] = *auction
+	return nil
+}
+
+func (r *memoryAuctions) Save(auction *models.Auction) error {
+	unlock := r.s.lockWrite()
+	defer unlock()
+
+	stamp(&auction.ID, &auction.CreatedAt, nil)
+	auction.UpdatedAt = time.Now()
+	r.s.data.auctions[auction.ID] = *auction
+	return nil
+}
+
+func (r *memoryAuctions) Updates(auction *models.Auction, changes *models.Auction) error {
+	unlock := r.s.lockWrite()
+	defer unlock()
+
+	stored, ok := r.s.data.auctions[auction.ID]
+	if !ok {
+		return ErrNotFound
+	}
+	mergeNonZero(&stored, changes)
+	r.s.data.auctions[auction.ID] = stored
+	mergeNonZero(auction, changes)
+	return nil
+}
+
+func (r *memoryAuctions) Delete(id uuid.UUID) error {
+	unlock := r.s.lockWrite()
+	defer unlock()
+
+	delete(r.s.data.auctions, id)
+	return nil
+}
+
+type memoryBids struct{ s *MemoryStore }
+
+// withTeam attaches the bid's Team as a Preload would
+func (r *memoryBids) withTeam(bid models.Bid) models.Bid {
+	bid.Team = r.s.data.teams[bid.TeamID]
+	return bid
+}
+
+func (r *memoryBids) Create(bid *models.Bid) error {
+	unlock := r.s.lockWrite()
+	defer unlock()
+
+	stamp(&bid.ID, &bid.CreatedAt, &bid.UpdatedAt)
+	r.s.data.bids = append(r.s.data.bids, *bid)
+	return nil
+}
+
+func (r *memoryBids) ClearWinning(auctionID uuid.UUID) error {
+	unlock := r.s.lockWrite()
+	defer unlock()
+
+	for i := range r.s.data.bids {
+		if r.s.data.bids[i].AuctionID == auctionID {
+			r.s.data.bids[i].IsWinning = false
+		}
+	}
+	return nil
+}
+
+func (r *memoryBids) GetWinning(auctionID uuid.UUID) (*models.Bid, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for _, bid := range r.s.data.bids {
+		if bid.AuctionID == auctionID && bid.IsWinning {
+			bid = r.withTeam(bid)
+			return &bid, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (r *memoryBids) ListByAuction(auctionID uuid.UUID) ([]models.Bid, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	bids := []models.Bid{}
+	for i := len(r.s.data.bids) - 1; i >= 0; i-- {
+		if bid := r.s.data.bids[i]; bid.AuctionID == auctionID {
+			bids = append(bids, r.withTeam(bid))
+		}
+	}
+	return bids, nil
+}
+
+func (r *memoryBids) ListRecentByTeam(teamID uuid.UUID, limit int) ([]models.Bid, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	bids := []models.Bid{}
+	for i := len(r.s.data.bids) - 1; i >= 0 && len(bids) < limit; i-- {
+		if bid := r.s.data.bids[i]; bid.TeamID == teamID {
+			bids = append(bids, bid)
+		}
+	}
+	return bids, nil
+}
+
+func (r *memoryBids) Count() (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return len(r.s.data.bids), nil
+}
+
+type memoryPoints struct{ s *MemoryStore }
+
+func (r *memoryPoints) Record(entry *models.PointsTransaction) error {
+	unlock := r.s.lockWrite()
+	defer unlock()
+
+	if !ledger.ValidType(entry.Type) {
+		return fmt.Errorf("invalid transaction type: %s", entry.Type)
+	}
+	team, ok := r.s.data.teams[entry.TeamID]
+	if !ok {
+		return ErrNotFound
+	}
+
+	stamp(&entry.ID, &entry.CreatedAt, nil)
+	r.s.data.points = append(r.s.data.points, *entry)
+	team.UsedPoints = r.usedPoints(entry.TeamID)
+	team.UpdatedAt = time.Now()
+	r.s.data.teams[team.ID] = team
+	return nil
+}
+
+func (r *memoryPoints) usedPoints(teamID uuid.UUID) int {
+	used := 0
+	for _, entry := range r.s.data.points {
+		if entry.TeamID == teamID {
+			used += entry.Amount
+		}
+	}
+	return used
+}
+
+func (r *memoryPoints) UsedPoints(teamID uuid.UUID) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.usedPoints(teamID), nil
+}
+
+func (r *memoryPoints) ListByTeam(teamID uuid.UUID) ([]models.PointsTransaction, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	transactions := []models.PointsTransaction{}
+	for i := len(r.s.data.points) - 1; i >= 0; i-- {
+		if entry := r.s.data.points[i]; entry.TeamID == teamID {
+			transactions = append(transactions, entry)
+		}
+	}
+	return transactions, nil
+}
+
+func (r *memoryPoints) ListRecentPurchases(auctionID uuid.UUID, limit int) ([]models.PointsTransaction, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	transactions := []models.PointsTransaction{}
+	for i := len(r.s.data.points) - 1; i >= 0 && len(transactions) < limit; i-- {
+		entry := r.s.data.points[i]
+		if entry.Type == ledger.TypePurchase && entry.AuctionID != nil && *entry.AuctionID == auctionID {
+			transactions = append(transactions, entry)
+		}
+	}
+	return transactions, nil
+}
+
+type memoryAuditEvents struct{ s *MemoryStore }
+
+func (r *memoryAuditEvents) Create(event *models.AuditEvent) error {
+	unlock := r.s.lockWrite()
+	defer unlock()
+
+	stamp(&event.ID, &event.CreatedAt, nil)
+	r.s.data.audit = append(r.s.data.audit, *event)
+	return nil
+}
+
+func (r *memoryAuditEvents) List(filter AuditEventFilter) ([]models.AuditEvent, int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	matches := func(e models.AuditEvent) bool {
+		fields := [][2]string{
+			{filter.ActorID, e.ActorID},
+			{filter.ActorRole, e.ActorRole},
+			{filter.Action, e.Action},
+			{filter.EntityType, e.EntityType},
+			{filter.EntityID, e.EntityID},
+			{filter.RequestID, e.RequestID},
+		}
+		for _, f := range fields {
+			if f[0] != "" && f[0] != f[1] {
+				return false
+			}
+		}
+		if filter.From != nil && e.CreatedAt.Before(*filter.From) {
+			return false
+		}
+		return filter.To == nil || !e.CreatedAt.After(*filter.To)
+	}
+
+	// Events are appended in time order, so walking backwards yields newest first
+	events := []models.AuditEvent{}
+	total := 0
+	for i := len(r.s.data.audit) - 1; i >= 0; i-- {
+		event := r.s.data.audit[i]
+		if !matches(event) {
+			continue
+		}
+		if total >= filter.Offset && (filter.Limit <= 0 || len(events) < filter.Limit) {
+			events = append(events, event)
+		}
+		total++
+	}
+	return events, total, nil
+}
+
+type memoryCategories struct{ s *MemoryStore }
+
+func (r *memoryCategories) List() ([]models.Category, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return append([]models.Category{}, r.s.data.categories...), nil
+}
+
+func (r *memoryCategories) Create(category *models.Category) error {
+	unlock := r.s.lockWrite()
+	defer unlock()
+
+	stamp(&category.ID, &category.CreatedAt, &category.UpdatedAt)
+	r.s.data.categories = append(r.s.data.categories, *category)
+	return nil
+}
+
+type memoryPlayerDocuments struct{ s *MemoryStore }
+
+func (r *memoryPlayerDocuments) GetByID(id uuid.UUID) (*models.PlayerDocument, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	document, ok := r.s.data.docs[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &document, nil
+}
+
+func (r *memoryPlayerDocuments) ListByPlayer(playerID uuid.UUID) ([]models.PlayerDocument, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	documents := []models.PlayerDocument{}
+	for _, document := range r.s.data.docs {
+		if document.PlayerID == playerID {
+			documents = append(documents, document)
+		}
+	}
+	sort.Slice(documents, func(i, j int) bool {
+		return documents[i].CreatedAt.After(documents[j].CreatedAt)
+	})
+	return documents, nil
+}
+
+func (r *memoryPlayerDocuments) Create(document *models.PlayerDocument) error {
+	unlock := r.s.lockWrite()
+	defer unlock()
+
+	stamp(&document.ID, &document.CreatedAt, nil)
+	r.s.data.docs[document.ID] = *document
+	return nil
+}
+
+func (r *memoryPlayerDocuments) Delete(id uuid.UUID) error {
+	unlock := r.s.lockWrite()
+	defer unlock()
+
+	delete(r.s.data.docs, id)
+	return nil
+}
+
+type memoryWatchlists struct{ s *MemoryStore }
+
+// find returns the ID of the team's entry for the player
+func (r *memoryWatchlists) find(teamID, playerID uuid.UUID) (uuid.UUID, bool) {
+	for id, entry := range r.s.data.watch {
+		if entry.TeamID == teamID && entry.PlayerID == playerID {
+			return id, true
+		}
+	}
+	return uuid.Nil, false
+}
+
+func (r *memoryWatchlists) Get(teamID, playerID uuid.UUID) (*models.WatchlistEntry, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	id, ok := r.find(teamID, playerID)
+	if !ok {
+		return nil, ErrNotFound
+	}
+	entry := r.s.data.watch[id]
+	return &entry, nil
+}
+
+func (r *memoryWatchlists) ListByTeam(teamID uuid.UUID) ([]models.WatchlistEntry, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	entries := []models.WatchlistEntry{}
+	for _, entry := range r.s.data.watch {
+		if entry.TeamID == teamID {
+			entries = append(entries, entry)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Priority != entries[j].Priority {
+			return entries[i].Priority < entries[j].Priority
+		}
+		return entries[i].CreatedAt.Before(entries[j].CreatedAt)
+	})
+	return entries, nil
+}
+
+func (r *memoryWatchlists) ListByPlayer(playerID uuid.UUID) ([]models.WatchlistEntry, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	entries := []models.WatchlistEntry{}
+	for _, entry := range r.s.data.watch {
+		if entry.PlayerID == playerID {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+func (r *memoryWatchlists) Save(entry *models.WatchlistEntry) error {
+	unlock := r.s.lockWrite()
+	defer unlock()
+
+	if id, ok := r.find(entry.TeamID, entry.PlayerID); ok {
+		existing := r.s.data.watch[id]
+		entry.ID, entry.CreatedAt = existing.ID, existing.CreatedAt
+	}
+	stamp(&entry.ID, &entry.CreatedAt, &entry.UpdatedAt)
+	r.s.data.watch[entry.ID] = *entry
+	return nil
+}
+
+func (r *memoryWatchlists) Delete(teamID, playerID uuid.UUID) error {
+	unlock := r.s.lockWrite()
+	defer unlock()
+
+	if id, ok := r.find(teamID, playerID); ok {
+		delete(r.s.data.watch, id)
+	}
+	return nil
+}
+
+type memoryTrades struct{ s *MemoryStore }
+
+// copyTrade returns the trade with its own copy of the items, so callers
+// cannot change the stored trade through them
+func copyTrade(trade models.Trade) models.Trade {
+	trade.Items = append([]models.TradeItem{}, trade.Items...)
+	return trade
+}
+
+func (r *memoryTrades) GetByID(id uuid.UUID) (*models.Trade, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	trade, ok := r.s.data.trades[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	trade = copyTrade(trade)
+	return &trade, nil
+}
+
+func (r *memoryTrades) GetByIDForUpdate(id uuid.UUID) (*models.Trade, error) {
+	return r.GetByID(id)
+}
+
+func (r *memoryTrades) List(filter TradeFilter) ([]models.Trade, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	trades := []models.Trade{}
+	for _, trade := range r.s.data.trades {
+		if filter.TeamID != nil && trade.ProposerTeamID != *filter.TeamID && trade.ReceiverTeamID != *filter.TeamID {
+			continue
+		}
+		if filter.Status != "" && trade.Status != filter.Status {
+			continue
+		}
+		trades = append(trades, copyTrade(trade))
+	}
+	sort.Slice(trades, func(i, j int) bool {
+		return trades[i].CreatedAt.After(trades[j].CreatedAt)
+	})
+	return trades, nil
+}
+
+func (r *memoryTrades) Create(trade *models.Trade) error {
+	unlock := r.s.lockWrite()
+	defer unlock()
+
+	stamp(&trade.ID, &trade.CreatedAt, &trade.UpdatedAt)
+	for i := range trade.Items {
+		trade.Items[i].TradeID = trade.ID
+		stamp(&trade.Items[i].ID, nil, nil)
+	}
+	r.s.data.trades[trade.ID] = copyTrade(*trade)
+	return nil
+}
+
+func (r *memoryTrades) Save(trade *models.Trade) error {
+	unlock := r.s.lockWrite()
+	defer unlock()
+
+	if _, ok := r.s.data.trades[trade.ID]; !ok {
+		return ErrNotFound
+	}
+	trade.UpdatedAt = time.Now()
+	r.s.data.trades[trade.ID] = copyTrade(*trade)
+	return nil
+}
+
+type memoryReleases struct{ s *MemoryStore }
+
+func (r *memoryReleases) GetByID(id uuid.UUID) (*models.Release, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	release, ok := r.s.data.releases[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &release, nil
+}
+
+func (r *memoryReleases) GetByIDForUpdate(id uuid.UUID) (*models.Release, error) {
+	return r.GetByID(id)
+}
+
+func (r *memoryReleases) List(filter ReleaseFilter) ([]models.Release, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	releases := []models.Release{}
+	for _, release := range r.s.data.releases {
+		if filter.TeamID != nil && release.TeamID != *filter.TeamID {
+			continue
+		}
+		if filter.Status != "" && release.Status != filter.Status {
+			continue
+		}
+		releases = append(releases, release)
+	}
+	sort.Slice(releases, func(i, j int) bool {
+		return releases[i].CreatedAt.After(releases[j].CreatedAt)
+	})
+	return releases, nil
+}
+
+func (r *memoryReleases) ListOpen(teamID uuid.UUID) ([]models.Release, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	releases := []models.Release{}
+	for _, release := range r.s.data.releases {
+		if release.TeamID == teamID && release.Status == models.ReleaseApproved && release.ReplacementPlayerID == nil {
+			releases = append(releases, release)
+		}
+	}
+	sort.Slice(releases, func(i, j int) bool {
+		a, b := releases[i], releases[j]
+		if a.ReviewedAt != nil && b.ReviewedAt != nil && !a.ReviewedAt.Equal(*b.ReviewedAt) {
+			return a.ReviewedAt.Before(*b.ReviewedAt)
+		}
+		return a.CreatedAt.Before(b.CreatedAt)
+	})
+	return releases, nil
+}
+
+func (r *memoryReleases) Create(release *models.Release) error {
+	unlock := r.s.lockWrite()
+	defer unlock()
+
+	stamp(&release.ID, &release.CreatedAt, &release.UpdatedAt)
+	r.s.data.releases[release.ID] = *release
+	return nil
+}
+
+func (r *memoryReleases) Save(release *models.Release) error {
+	unlock := r.s.lockWrite()
+	defer unlock()
+
+	if _, ok := r.s.data.releases[release.ID]; !ok {
+		return ErrNotFound
+	}
+	release.UpdatedAt = time.Now()
+	r.s.data.releases[release.ID] = *release
+	return nil
+}
@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"auction-backend/models"
+)
+
+func TestMemoryTransactionRollsBack(t *testing.T) {
+	s := NewMemoryStore()
+	team := &models.Team{Name: "Smashers", TotalPoints: 12000}
+	if err := s.Teams().Create(team); err != nil {
+		t.Fatal(err)
+	}
+
+	errAbort := errors.New("abort")
+	err := s.Transaction(func(tx Store) error {
+		changed := *team
+		changed.PlayerCount = 5
+		if err := tx.Teams().Save(&changed); err != nil {
+			return err
+		}
+
+		// The transaction sees its own write, committed readers do not
+		if got, _ := tx.Teams().GetByID(team.ID); got.PlayerCount != 5 {
+			t.Errorf("inside transaction: player count %d, want 5", got.PlayerCount)
+		}
+		if got, _ := s.Teams().GetByID(team.ID); got.PlayerCount != 0 {
+			t.Errorf("outside transaction: player count %d, want 0", got.PlayerCount)
+		}
+		return errAbort
+	})
+	if !errors.Is(err, errAbort) {
+		t.Fatalf("Transaction returned %v, want %v", err, errAbort)
+	}
+	if got, _ := s.Teams().GetByID(team.ID); got.PlayerCount != 0 {
+		t.Fatalf("after rollback: player count %d, want 0", got.PlayerCount)
+	}
+}
+
+func TestMemorySnapshotInsideTransaction(t *testing.T) {
+	s := NewMemoryStore()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- s.Transaction(func(tx Store) error {
+			return s.Snapshot(func(Store) error { return nil })
+		})
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Snapshot inside a Transaction deadlocked")
+	}
+}
+
+func TestMemoryWriteOutsideTransactionIsNotLost(t *testing.T) {
+	s := NewMemoryStore()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	done := make(chan error, 1)
+	go func() {
+		done <- s.Transaction(func(tx Store) error {
+			close(started)
+			<-release
+			return tx.Teams().Create(&models.Team{Name: "In Transaction"})
+		})
+	}()
+	<-started
+
+	// This write waits for the transaction instead of being overwritten when it commits
+	written := make(chan error, 1)
+	go func() {
+		written <- s.Teams().Create(&models.Team{Name: "Outside"})
+	}()
+	close(release)
+
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	if err := <-written; err != nil {
+		t.Fatal(err)
+	}
+	if count, _ := s.Teams().Count(); count != 2 {
+		t.Fatalf("teams = %d, want 2", count)
+	}
+}
+
+func TestMemoryNestedTransactionRollsBackAlone(t *testing.T) {
+	s := NewMemoryStore()
+
+	errAbort := errors.New("abort")
+	err := s.Transaction(func(tx Store) error {
+		if err := tx.Teams().Create(&models.Team{Name: "Kept"}); err != nil {
+			return err
+		}
+		if err := tx.Transaction(func(inner Store) error {
+			if err := inner.Teams().Create(&models.Team{Name: "Discarded"}); err != nil {
+				return err
+			}
+			return errAbort
+		}); !errors.Is(err, errAbort) {
+			t.Errorf("nested Transaction returned %v, want %v", err, errAbort)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	teams, err := s.Teams().ListWithPlayers()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(teams) != 1 || teams[0].Name != "Kept" {
+		t.Fatalf("teams = %+v, want only Kept", teams)
+	}
+}
@@ -0,0 +1,223 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"auction-backend/models"
+
+	"github.com/google/uuid"
+)
+
+// ErrNotFound is returned when a lookup matches no record
+var ErrNotFound = errors.New("record not found")
+
+// PlayerFilter narrows a player listing. Empty fields are ignored.
+type PlayerFilter struct {
+	Status          string // sold, unsold
+	PlayingCategory string // singles, doubles, both
+	PlayerCategory  string // women, men_under_35, men_35_plus
+	// RegistrationStatus is pending, approved or rejected
+	RegistrationStatus string
+}
+
+// UserRepository stores login accounts
+type UserRepository interface {
+	GetByID(id uuid.UUID) (*models.User, error)
+	GetByEmail(email string) (*models.User, error)
+	ExistsByEmailOrUsername(email, username string) (bool, error)
+	Create(user *models.User) error
+}
+
+// PlayerRepository stores players. Reads include the player's User.
+type PlayerRepository interface {
+	GetByID(id uuid.UUID) (*models.Player, error)
+	// GetByIDForUpdate reads the player and, inside a transaction, locks it
+	// until the transaction ends
+	GetByIDForUpdate(id uuid.UUID) (*models.Player, error)
+	// GetByUserID returns the player profile belonging to a login
+	GetByUserID(userID uuid.UUID) (*models.Player, error)
+	List(filter PlayerFilter) ([]models.Player, error)
+	Count(filter PlayerFilter) (int, error)
+	ListByTeam(teamID uuid.UUID) ([]models.Player, error)
+	// NextUnsold returns the approved, unsold player in the category with the
+	// lowest ID, greater than afterID when given. Players released by a team
+	// are skipped unless withReleased is set, as only replacement auctions
+	// offer them again.
+	NextUnsold(playerCategory string, afterID *uuid.UUID, withReleased bool) (*models.Player, error)
+	Create(player *models.Player) error
+	Save(player *models.Player) error
+	// Updates copies the non-zero fields of changes onto player
+	Updates(player *models.Player, changes *models.Player) error
+	Delete(id uuid.UUID) error
+}
+
+// TeamRepository stores teams
+type TeamRepository interface {
+	GetByID(id uuid.UUID) (*models.Team, error)
+	// GetByIDForUpdate reads the team and, inside a transaction, locks it
+	// until the transaction ends
+	GetByIDForUpdate(id uuid.UUID) (*models.Team, error)
+	// ListWithPlayers returns every team with its roster loaded
+	ListWithPlayers() ([]models.Team, error)
+	Count() (int, error)
+	// TotalUsedPoints sums the used points of every team
+	TotalUsedPoints() (int, error)
+	Create(team *models.Team) error
+	Save(team *models.Team) error
+	// Updates copies the non-zero fields of changes onto team
+	Updates(team *models.Team, changes *models.Team) error
+}
+
+// AuctionRepository stores auctions
+type AuctionRepository interface {
+	GetByID(id uuid.UUID) (*models.Auction, error)
+	// GetByIDForUpdate reads the auction and, inside a transaction, locks it
+	// until the transaction ends so concurrent bids are checked one at a time
+	GetByIDForUpdate(id uuid.UUID) (*models.Auction, error)
+	// List returns all auctions, or those with the given status when it is not empty
+	List(status string) ([]models.Auction, error)
+	// Count returns the number of auctions, or of those with the given status when it is not empty
+	Count(status string) (int, error)
+	GetActive() (*models.Auction, error)
+	Create(auction *models.Auction) error
+	Save(auction *models.Auction) error
+	// Updates copies the non-zero fields of changes onto auction
+	Updates(auction *models.Auction, changes *models.Auction) error
+	Delete(id uuid.UUID) error
+}
+
+// BidRepository stores bids
+type BidRepository interface {
+	Create(bid *models.Bid) error
+	// ClearWinning marks every bid in the auction as not winning
+	ClearWinning(auctionID uuid.UUID) error
+	// GetWinning returns the auction's winning bid with its Team loaded
+	GetWinning(auctionID uuid.UUID) (*models.Bid, error)
+	// ListByAuction returns the auction's bids, newest first, with Team loaded
+	ListByAuction(auctionID uuid.UUID) ([]models.Bid, error)
+	ListRecentByTeam(teamID uuid.UUID, limit int) ([]models.Bid, error)
+	Count() (int, error)
+}
+
+// PointsTransactionRepository stores the team points ledger
+type PointsTransactionRepository interface {
+	// Record appends the entry and re-derives the team's used points from the ledger
+	Record(entry *models.PointsTransaction) error
+	UsedPoints(teamID uuid.UUID) (int, error)
+	// ListByTeam returns the team's transactions, newest first
+	ListByTeam(teamID uuid.UUID) ([]models.PointsTransaction, error)
+	// ListRecentPurchases returns up to limit of the auction's purchases, newest first
+	ListRecentPurchases(auctionID uuid.UUID, limit int) ([]models.PointsTransaction, error)
+}
+
+// PlayerDocumentRepository stores the metadata of uploaded player documents
+type PlayerDocumentRepository interface {
+	GetByID(id uuid.UUID) (*models.PlayerDocument, error)
+	// ListByPlayer returns the player's documents, newest first
+	ListByPlayer(playerID uuid.UUID) ([]models.PlayerDocument, error)
+	Create(document *models.PlayerDocument) error
+	Delete(id uuid.UUID) error
+}
+
+// WatchlistRepository stores teams' watchlists
+type WatchlistRepository interface {
+	Get(teamID, playerID uuid.UUID) (*models.WatchlistEntry, error)
+	// ListByTeam returns the team's watchlist, highest priority first
+	ListByTeam(teamID uuid.UUID) ([]models.WatchlistEntry, error)
+	// ListByPlayer returns every team's entry for the player
+	ListByPlayer(playerID uuid.UUID) ([]models.WatchlistEntry, error)
+	// Save creates the entry, or replaces the team's entry for the same player
+	Save(entry *models.WatchlistEntry) error
+	Delete(teamID, playerID uuid.UUID) error
+}
+
+// TradeFilter narrows a trade listing. Empty fields are ignored.
+type TradeFilter struct {
+	// TeamID matches trades the team proposed or received
+	TeamID *uuid.UUID
+	Status string
+}
+
+// TradeRepository stores trades between teams. Reads include the trade's Items.
+type TradeRepository interface {
+	GetByID(id uuid.UUID) (*models.Trade, error)
+	// GetByIDForUpdate reads the trade and, inside a transaction, locks it
+	// until the transaction ends
+	GetByIDForUpdate(id uuid.UUID) (*models.Trade, error)
+	// List returns the matching trades, newest first
+	List(filter TradeFilter) ([]models.Trade, error)
+	// Create stores the trade with its items
+	Create(trade *models.Trade) error
+	// Save updates the trade and its items
+	Save(trade *models.Trade) error
+}
+
+// ReleaseFilter narrows a release listing. Empty fields are ignored.
+type ReleaseFilter struct {
+	TeamID *uuid.UUID
+	Status string
+}
+
+// ReleaseRepository stores player releases and the replacement slots they open
+type ReleaseRepository interface {
+	GetByID(id uuid.UUID) (*models.Release, error)
+	// GetByIDForUpdate reads the release and, inside a transaction, locks it
+	// until the transaction ends
+	GetByIDForUpdate(id uuid.UUID) (*models.Release, error)
+	// List returns the matching releases, newest first
+	List(filter ReleaseFilter) ([]models.Release, error)
+	// ListOpen returns the team's approved releases with no replacement yet, oldest first
+	ListOpen(teamID uuid.UUID) ([]models.Release, error)
+	Create(release *models.Release) error
+	Save(release *models.Release) error
+}
+
+// AuditEventFilter narrows an audit log query. Empty fields are ignored.
+type AuditEventFilter struct {
+	ActorID    string
+	ActorRole  string
+	Action     string
+	EntityType string
+	EntityID   string
+	RequestID  string
+	From       *time.Time
+	To         *time.Time
+	Limit      int
+	Offset     int
+}
+
+// AuditEventRepository appends to and queries the audit log
+type AuditEventRepository interface {
+	Create(event *models.AuditEvent) error
+	// List returns a page of the matching events, newest first, and how many match in all
+	List(filter AuditEventFilter) ([]models.AuditEvent, int, error)
+}
+
+// CategoryRepository stores tournament categories
+type CategoryRepository interface {
+	List() ([]models.Category, error)
+	Create(category *models.Category) error
+}
+
+// Store groups the repositories so handlers can share one transaction across them
+type Store interface {
+	Users() UserRepository
+	Players() PlayerRepository
+	Teams() TeamRepository
+	Auctions() AuctionRepository
+	Bids() BidRepository
+	PointsTransactions() PointsTransactionRepository
+	AuditEvents() AuditEventRepository
+	PlayerDocuments() PlayerDocumentRepository
+	Watchlists() WatchlistRepository
+	Trades() TradeRepository
+	Releases() ReleaseRepository
+	Categories() CategoryRepository
+
+	// Transaction runs fn against a store whose writes commit together, or not at all if fn returns an error.
+	// Called on a transaction's store, it runs fn in a savepoint of that transaction.
+	Transaction(fn func(tx Store) error) error
+	// Snapshot runs fn against a read-only store whose reads all see the data as of one point in time
+	Snapshot(fn func(tx Store) error) error
+}
@@ -9,6 +9,12 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+// Overlay requests allowed per client IP: a burst, then a steady rate
+const (
+	overlayRatePerSecond = 2
+	overlayRateBurst     = 10
+)
+
 // SetupRoutes configures all API routes
 func SetupRoutes(r *gin.Engine, h *handlers.Handlers) {
 	// API v1 group
@@ -21,6 +27,14 @@ func SetupRoutes(r *gin.Engine, h *handlers.Handlers) {
 		v1.GET("/teams", h.GetTeams)
 		v1.GET("/categories", h.GetCategories)
 
+		// Public overlay for stream graphics: no login, rate limited per IP, no private data
+		public := v1.Group("/public")
+		public.Use(middleware.RateLimit(overlayRatePerSecond, overlayRateBurst))
+		{
+			public.GET("/auctions/:id/overlay", h.GetOverlay)
+			public.GET("/auctions/:id/overlay/ws", h.SpectateOverlay)
+		}
+
 		// Protected routes
 		protected := v1.Group("/")
 		protected.Use(middleware.Auth())
@@ -28,12 +42,19 @@ func SetupRoutes(r *gin.Engine, h *handlers.Handlers) {
 			// Player management
 			protected.GET("/players/categories", h.GetPlayersByCategory)
 			protected.GET("/players/:id", h.GetPlayer)
-			protected.PUT("/players/:id", h.UpdatePlayer)
-			protected.DELETE("/players/:id", h.DeletePlayer)
+			protected.PUT("/players/:id", middleware.RoleAuth("admin"), h.UpdatePlayer)
+			protected.DELETE("/players/:id", middleware.RoleAuth("admin"), h.DeletePlayer)
+
+			// Player photos and documents; admins or the player themselves
+			protected.POST("/players/:id/photo", h.UploadPlayerPhoto)
+			protected.DELETE("/players/:id/photo", h.DeletePlayerPhoto)
+			protected.POST("/players/:id/documents", h.UploadPlayerDocument)
+			protected.GET("/players/:id/documents", h.GetPlayerDocuments)
+			protected.GET("/players/:id/documents/:documentId", h.DownloadPlayerDocument)
 
 			// Team management
 			protected.GET("/teams/:id", h.GetTeam)
-			protected.PUT("/teams/:id", h.UpdateTeam)
+			protected.PUT("/teams/:id", middleware.RoleAuth("admin"), h.UpdateTeam)
 			protected.GET("/teams/:id/players", h.GetTeamPlayers)
 			protected.GET("/teams/:id/points", h.GetTeamPoints)
 
@@ -47,6 +68,7 @@ func SetupRoutes(r *gin.Engine, h *handlers.Handlers) {
 			protected.POST("/auctions/:id/bid", h.CreateBid)
 			protected.GET("/auctions/:id/bids", h.GetAuctionBids)
 			protected.GET("/auctions/:id/current-bid", h.GetCurrentBid)
+			protected.GET("/auctions/:id/state", h.GetAuctionState)
 
 			// Admin routes
 			admin := protected.Group("/admin")
@@ -54,17 +76,39 @@ func SetupRoutes(r *gin.Engine, h *handlers.Handlers) {
 			{
 				admin.GET("/dashboard", h.GetAdminDashboard)
 				admin.POST("/players/create", h.CreatePlayer)
+				admin.POST("/players/import", h.ImportPlayers)
+				admin.GET("/players/registrations", h.GetPlayerRegistrations)
 				admin.POST("/players/approve", h.ApprovePlayer)
 				admin.POST("/teams/create", h.CreateTeam)
 				admin.PUT("/teams/:id/points", h.UpdateTeamPoints)
+				admin.GET("/teams/:id/transactions", h.GetTeamTransactions)
+				admin.POST("/teams/:id/transactions", h.RecordTeamTransaction)
+				admin.GET("/points/reconcile", h.ReconcilePoints)
 				admin.POST("/teams/:id/assign-player", h.AssignPlayerToTeam)
 				admin.POST("/auctions/:id/assign-player", h.AssignPlayerToAuction)
 				admin.POST("/auctions/:id/next-player", h.NextPlayer)
 				admin.GET("/auctions/:id/status", h.GetAuctionStatus)
+				admin.GET("/auctions/:id/presence", h.GetAuctionPresence)
 				admin.POST("/auctions/:id/start", h.StartAuction)
 				admin.POST("/auctions/:id/end", h.EndAuction)
 				admin.GET("/available-players", h.GetAvailablePlayers)
 				admin.GET("/auctions/:id", h.GetAuction)
+				admin.GET("/audit-events", h.GetAuditEvents)
+				admin.GET("/exports/results", h.ExportResults)
+				admin.GET("/exports/squads", h.ExportSquads)
+				admin.GET("/exports/bids", h.ExportBids)
+				admin.GET("/analytics/prices", h.GetCategoryPrices)
+				admin.GET("/analytics/spend", h.GetSpendCurves)
+				admin.GET("/analytics/lots/contested", h.GetContestedLots)
+				admin.GET("/analytics/lots/times", h.GetLotTimes)
+				admin.GET("/analytics/efficiency", h.GetBudgetEfficiency)
+				admin.GET("/trades", h.GetTrades)
+				admin.POST("/trades/:id/review", h.ReviewTrade)
+				admin.GET("/releases", h.GetReleases)
+				admin.POST("/releases/:id/review", h.ReviewRelease)
+				admin.GET("/doctor", h.GetDoctorReport)
+				admin.POST("/doctor/repair", h.RepairAuctionData)
+				admin.GET("/ws/clients", h.GetWebSocketStats)
 			}
 
 			// Team routes
@@ -74,15 +118,41 @@ func SetupRoutes(r *gin.Engine, h *handlers.Handlers) {
 				team.GET("/dashboard", h.GetTeamDashboard)
 				team.GET("/roster", h.GetTeamRoster)
 				team.GET("/budget", h.GetTeamBudget)
+				team.GET("/budget/plan", h.GetBudgetPlan)
+				team.GET("/transactions", h.GetMyTeamTransactions)
 				team.POST("/retain-player", h.RetainPlayer)
+				team.GET("/watchlist", h.GetWatchlist)
+				team.PUT("/watchlist/:playerId", h.SaveWatchlistEntry)
+				team.DELETE("/watchlist/:playerId", h.DeleteWatchlistEntry)
+				team.GET("/trades", h.GetMyTrades)
+				team.POST("/trades", h.ProposeTrade)
+				team.POST("/trades/:id/accept", h.AcceptTrade)
+				team.POST("/trades/:id/decline", h.DeclineTrade)
+				team.POST("/trades/:id/cancel", h.CancelTrade)
+				team.GET("/releases", h.GetMyReleases)
+				team.POST("/releases", h.RequestRelease)
+				team.POST("/releases/:id/pick", h.PickReplacement)
+				team.GET("/replacement-pool", h.GetReplacementPool)
+			}
+
+			// Player routes
+			player := protected.Group("/player")
+			player.Use(middleware.RoleAuth("player"))
+			{
+				player.GET("/profile", h.GetMyPlayerProfile)
+				player.PUT("/profile", h.SubmitPlayerProfile)
+				player.GET("/outcome", h.GetMyAuctionOutcome)
 			}
 		}
 
 	}
 
-	// WebSocket route (public, no authentication required)
+	// WebSocket route (authenticates with a token during the handshake, see websocket.HandleWebSocket)
 	v1.GET("/ws", h.HandleWebSocket)
 
+	// Server-Sent Events fallback for the auction room (token in the query or header, see websocket.HandleEvents)
+	v1.GET("/auctions/:id/events", h.StreamAuctionEvents)
+
 	// Health check
 	r.GET("/health", func(c *gin.Context) {
 		c.JSON(200, gin.H{"status": "ok"})
@@ -0,0 +1,105 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Local stores files in a directory. Public files are served by the web
+// server from PublicDir at the base URL.
+type Local struct {
+	root    string
+	baseURL string
+}
+
+// NewLocal stores files under root; public files are linked at baseURL
+func NewLocal(root, baseURL string) (*Local, error) {
+	if err := os.MkdirAll(filepath.Join(root, filepath.FromSlash(PublicPrefix)), 0o755); err != nil {
+		return nil, err
+	}
+	return &Local{root: root, baseURL: strings.TrimSuffix(baseURL, "/")}, nil
+}
+
+// PublicDir is the directory holding public files, to be served at the base URL
+func (l *Local) PublicDir() string {
+	return filepath.Join(l.root, filepath.FromSlash(PublicPrefix))
+}
+
+// MountPath is the URL path the public directory is served at, taken from the
+// base URL. It is empty when the base URL has no path to mount on.
+func (l *Local) MountPath() string {
+	base, err := url.Parse(l.baseURL)
+	if err != nil || base.Path == "" || base.Path == "/" {
+		return ""
+	}
+	return base.Path
+}
+
+func (l *Local) path(key string) (string, error) {
+	if err := checkKey(key); err != nil {
+		return "", err
+	}
+	return filepath.Join(l.root, filepath.FromSlash(key)), nil
+}
+
+// Put writes the file through a temporary file, so readers never see it half written
+func (l *Local) Put(ctx context.Context, key string, body []byte, contentType string) error {
+	name, err := l.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(name), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(body); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), name)
+}
+
+func (l *Local) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	name, err := l.path(key)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	return file, err
+}
+
+func (l *Local) Delete(ctx context.Context, key string) error {
+	name, err := l.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(name); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (l *Local) URL(key string) string {
+	return l.baseURL + "/" + strings.TrimPrefix(key, PublicPrefix)
+}
@@ -0,0 +1,75 @@
+// Package storage keeps uploaded files behind one interface, backed by the
+// local filesystem or an S3-compatible bucket.
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+)
+
+// PublicPrefix starts the keys of files anyone may load, such as player
+// photos. Other keys are private and only read back through the API.
+const PublicPrefix = "public/"
+
+// ErrNotFound is returned when a key holds no file
+var ErrNotFound = errors.New("storage: object not found")
+
+// Storage stores files by key. Keys are slash-separated relative paths.
+type Storage interface {
+	Put(ctx context.Context, key string, body []byte, contentType string) error
+	// Get opens a stored file; the caller closes it
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes a file; deleting a missing key is not an error
+	Delete(ctx context.Context, key string) error
+	// URL returns the address a browser loads a public file from
+	URL(key string) string
+}
+
+// IsPublic reports whether a key may be served to anyone
+func IsPublic(key string) bool {
+	return strings.HasPrefix(key, PublicPrefix)
+}
+
+// checkKey rejects keys that are empty, absolute or climb out of the store
+func checkKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || path.Clean(key) != key || strings.HasPrefix(key, "../") || key == ".." {
+		return fmt.Errorf("storage: invalid key %q", key)
+	}
+	return nil
+}
+
+// FromEnv builds the storage chosen by STORAGE_DRIVER: local (the default),
+// writing under STORAGE_LOCAL_DIR, or s3, configured by the STORAGE_S3_* variables
+func FromEnv() (Storage, error) {
+	switch driver := os.Getenv("STORAGE_DRIVER"); driver {
+	case "", "local":
+		dir := os.Getenv("STORAGE_LOCAL_DIR")
+		if dir == "" {
+			dir = "./uploads"
+		}
+		baseURL := os.Getenv("STORAGE_PUBLIC_URL")
+		if baseURL == "" {
+			baseURL = "/uploads"
+		}
+		return NewLocal(dir, baseURL)
+
+	case "s3":
+		return NewS3(S3Config{
+			Endpoint:  os.Getenv("STORAGE_S3_ENDPOINT"),
+			Region:    os.Getenv("STORAGE_S3_REGION"),
+			Bucket:    os.Getenv("STORAGE_S3_BUCKET"),
+			AccessKey: os.Getenv("STORAGE_S3_ACCESS_KEY"),
+			SecretKey: os.Getenv("STORAGE_S3_SECRET_KEY"),
+			PathStyle: os.Getenv("STORAGE_S3_PATH_STYLE") == "true",
+			PublicURL: os.Getenv("STORAGE_PUBLIC_URL"),
+		})
+
+	default:
+		return nil, fmt.Errorf("unknown STORAGE_DRIVER %q, expected local or s3", driver)
+	}
+}
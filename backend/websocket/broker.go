@@ -0,0 +1,317 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// Event is a message for a room, or for every client when Room is empty, as
+// carried between hubs by a Broker
+type Event struct {
+	Room string          `json:"room,omitempty"`
+	Seq  uint64          `json:"seq,omitempty"`
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+// Broker carries events between every hub that shares it, so clients connected
+// to any replica receive events published on any other. It also numbers
+// auction room events and keeps their replay buffer.
+type Broker interface {
+	// Publish numbers the event if its room is sequenced and delivers it to
+	// every subscribed hub, this one included
+	Publish(event Event) error
+	// Subscribe registers handle to receive every published event, in order
+	Subscribe(handle func(Event))
+	// CurrentSeq returns the latest sequence number issued for room
+	CurrentSeq(room string) (uint64, error)
+	// Since returns the room's events after lastSeq. ok is false when some are
+	// no longer buffered and the client must resync from a snapshot.
+	Since(room string, lastSeq uint64) (events []Event, ok bool, err error)
+	// SetPresence records the open connections per team on hub hubID,
+	// replacing what that hub recorded before
+	SetPresence(hubID string, counts map[string]int) error
+	// Presence returns the open connections per team summed over every hub
+	Presence() (map[string]int, error)
+}
+
+// memoryBroker delivers events within this process only
+type memoryBroker struct {
+	mu       sync.Mutex
+	log      *replayLog
+	handlers []func(Event)
+	presence map[string]map[string]int // hub ID -> team ID -> connections
+}
+
+// NewMemoryBroker returns a Broker for a single backend instance, keeping
+// replaySize events per auction in memory
+func NewMemoryBroker(replaySize int) Broker {
+	return &memoryBroker{
+		log:      newReplayLog(replaySize, time.Now()),
+		presence: make(map[string]map[string]int),
+	}
+}
+
+func (b *memoryBroker) Publish(event Event) error {
+	// Hold the lock through delivery so handlers see events in sequence order
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if sequenced(event.Room) {
+		event = b.log.append(event)
+	}
+	for _, handle := range b.handlers {
+		handle(event)
+	}
+	return nil
+}
+
+func (b *memoryBroker) Subscribe(handle func(Event)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers = append(b.handlers, handle)
+}
+
+func (b *memoryBroker) CurrentSeq(room string) (uint64, error) {
+	return b.log.current(room), nil
+}
+
+func (b *memoryBroker) Since(room string, lastSeq uint64) ([]Event, bool, error) {
+	events, ok := b.log.since(room, lastSeq)
+	return events, ok, nil
+}
+
+func (b *memoryBroker) SetPresence(hubID string, counts map[string]int) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.presence[hubID] = counts
+	return nil
+}
+
+func (b *memoryBroker) Presence() (map[string]int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	totals := make(map[string]int)
+	for _, counts := range b.presence {
+		for teamID, n := range counts {
+			totals[teamID] += n
+		}
+	}
+	return totals, nil
+}
+
+// redisEventsChannel is the pub/sub channel every replica's hub subscribes to
+const redisEventsChannel = "ws:events"
+
+// redisTimeout bounds each Redis call made by the broker
+const redisTimeout = 2 * time.Second
+
+// publishScript numbers an auction event, appends it to the room's replay
+// list and publishes it in one atomic step, so every replica receives events
+// in sequence order. Entries are "{seq}:{event JSON}"; the sequence is read
+// back with GET because Lua would format a large INCR result in exponent form.
+var publishScript = redis.NewScript(`
+redis.call('INCR', KEYS[1])
+local seq = redis.call('GET', KEYS[1])
+local entry = seq .. ':' .. ARGV[2]
+redis.call('RPUSH', KEYS[2], entry)
+redis.call('LTRIM', KEYS[2], -tonumber(ARGV[3]), -1)
+redis.call('PUBLISH', ARGV[1], entry)
+return seq
+`)
+
+// redisBroker shares events between replicas through Redis pub/sub, with
+// sequence numbers and replay lists stored in Redis
+type redisBroker struct {
+	client     *redis.Client
+	replaySize int
+}
+
+// NewRedisBroker returns a Broker that reaches every replica subscribed to
+// the same Redis, keeping replaySize events per auction in Redis lists
+func NewRedisBroker(client *redis.Client, replaySize int) Broker {
+	return &redisBroker{client: client, replaySize: replaySize}
+}
+
+func redisSeqKey(room string) string { return "ws:seq:" + room }
+func redisLogKey(room string) string { return "ws:log:" + room }
+
+// Each hub keeps its team connection counts in a hash that expires unless
+// refreshed, so a replica that dies stops counting after presenceTTL
+const (
+	redisPresenceHubsKey = "ws:presence:hubs"
+	presenceTTL          = 3 * presenceRefresh
+)
+
+func redisPresenceKey(hubID string) string { return "ws:presence:" + hubID }
+
+// presenceAlive is stored in every hub's hash so it exists while the hub
+// has no team connections
+const presenceAlive = "_"
+
+func (b *redisBroker) Publish(event Event) error {
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	if !sequenced(event.Room) {
+		return b.client.Publish(ctx, redisEventsChannel, "0:"+string(payload)).Err()
+	}
+	keys := []string{redisSeqKey(event.Room), redisLogKey(event.Room)}
+	return publishScript.Run(ctx, b.client, keys, redisEventsChannel, string(payload), b.replaySize).Err()
+}
+
+func (b *redisBroker) Subscribe(handle func(Event)) {
+	// The subscription reconnects by itself if Redis goes away
+	pubsub := b.client.Subscribe(context.Background(), redisEventsChannel)
+	go func() {
+		for msg := range pubsub.Channel() {
+			event, err := decodeRedisEntry(msg.Payload)
+			if err != nil {
+				log.Printf("Failed to decode broker event: %v", err)
+				continue
+			}
+			handle(event)
+		}
+	}()
+}
+
+func (b *redisBroker) CurrentSeq(room string) (uint64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+
+	seq, err := b.client.Get(ctx, redisSeqKey(room)).Uint64()
+	if errors.Is(err, redis.Nil) {
+		return 0, nil
+	}
+	return seq, err
+}
+
+func (b *redisBroker) Since(room string, lastSeq uint64) ([]Event, bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+
+	// Read the counter and the list in one transaction so they agree
+	var seqCmd *redis.StringCmd
+	var logCmd *redis.StringSliceCmd
+	_, err := b.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		seqCmd = pipe.Get(ctx, redisSeqKey(room))
+		logCmd = pipe.LRange(ctx, redisLogKey(room), 0, -1)
+		return nil
+	})
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return nil, false, err
+	}
+
+	var current uint64
+	if seqCmd.Err() == nil {
+		if current, err = seqCmd.Uint64(); err != nil {
+			return nil, false, err
+		}
+	}
+
+	buffered := make([]Event, 0, len(logCmd.Val()))
+	for _, entry := range logCmd.Val() {
+		event, err := decodeRedisEntry(entry)
+		if err != nil {
+			return nil, false, err
+		}
+		buffered = append(buffered, event)
+	}
+
+	events, ok := eventsSince(current, buffered, lastSeq)
+	return events, ok, nil
+}
+
+func (b *redisBroker) SetPresence(hubID string, counts map[string]int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+
+	fields := map[string]interface{}{presenceAlive: 0}
+	for teamID, n := range counts {
+		fields[teamID] = n
+	}
+
+	key := redisPresenceKey(hubID)
+	_, err := b.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, key)
+		pipe.HSet(ctx, key, fields)
+		pipe.Expire(ctx, key, presenceTTL)
+		pipe.SAdd(ctx, redisPresenceHubsKey, hubID)
+		return nil
+	})
+	return err
+}
+
+func (b *redisBroker) Presence() (map[string]int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+
+	hubIDs, err := b.client.SMembers(ctx, redisPresenceHubsKey).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	cmds := make([]*redis.StringStringMapCmd, len(hubIDs))
+	_, err = b.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, hubID := range hubIDs {
+			cmds[i] = pipe.HGetAll(ctx, redisPresenceKey(hubID))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	totals := make(map[string]int)
+	for i, cmd := range cmds {
+		counts := cmd.Val()
+		if len(counts) == 0 {
+			// The hub's hash expired, so the replica is gone
+			b.client.SRem(ctx, redisPresenceHubsKey, hubIDs[i])
+			continue
+		}
+		for teamID, n := range counts {
+			if teamID == presenceAlive {
+				continue
+			}
+			count, _ := strconv.Atoi(n)
+			totals[teamID] += count
+		}
+	}
+	return totals, nil
+}
+
+// decodeRedisEntry parses a "{seq}:{event JSON}" entry
+func decodeRedisEntry(entry string) (Event, error) {
+	i := strings.IndexByte(entry, ':')
+	if i < 0 {
+		return Event{}, fmt.Errorf("malformed entry %q", entry)
+	}
+
+	seq, err := strconv.ParseUint(entry[:i], 10, 64)
+	if err != nil {
+		return Event{}, err
+	}
+
+	var event Event
+	if err := json.Unmarshal([]byte(entry[i+1:]), &event); err != nil {
+		return Event{}, err
+	}
+	event.Seq = seq
+	return event, nil
+}
@@ -0,0 +1,109 @@
+package websocket
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// clientMetrics counts a client's traffic. Fields are updated from its pumps
+// and the hub, so they are read and written atomically.
+type clientMetrics struct {
+	connectedAt      time.Time
+	messagesSent     int64
+	bytesSent        int64
+	messagesReceived int64
+	messagesDropped  int64
+	lastPong         int64 // unix nanoseconds
+}
+
+// ClientStats describes one connected client
+type ClientStats struct {
+	UserID           string    `json:"user_id"`
+	Role             string    `json:"role"`
+	TeamID           string    `json:"team_id,omitempty"`
+	Transport        string    `json:"transport"`
+	RemoteAddr       string    `json:"remote_addr"`
+	Rooms            []string  `json:"rooms"`
+	ConnectedAt      time.Time `json:"connected_at"`
+	LastPong         time.Time `json:"last_pong"`
+	MessagesSent     int64     `json:"messages_sent"`
+	BytesSent        int64     `json:"bytes_sent"`
+	MessagesReceived int64     `json:"messages_received"`
+	MessagesDropped  int64     `json:"messages_dropped"`
+	QueueLength      int       `json:"queue_length"`
+	QueueCapacity    int       `json:"queue_capacity"`
+}
+
+// HubStats describes the hub and every connected client
+type HubStats struct {
+	Clients             []ClientStats `json:"clients"`
+	TotalConnections    int64         `json:"total_connections"`
+	SlowDisconnects     int64         `json:"slow_disconnects"`
+	TimeoutDisconnects  int64         `json:"timeout_disconnects"`
+	OversizeDisconnects int64         `json:"oversize_disconnects"`
+}
+
+// hubMetrics counts connections over the hub's lifetime
+type hubMetrics struct {
+	totalConnections    int64
+	slowDisconnects     int64
+	timeoutDisconnects  int64
+	oversizeDisconnects int64
+}
+
+func (m *clientMetrics) sent(bytes int) {
+	atomic.AddInt64(&m.messagesSent, 1)
+	atomic.AddInt64(&m.bytesSent, int64(bytes))
+}
+
+func (m *clientMetrics) received() {
+	atomic.AddInt64(&m.messagesReceived, 1)
+}
+
+func (m *clientMetrics) dropped() {
+	atomic.AddInt64(&m.messagesDropped, 1)
+}
+
+func (m *clientMetrics) pong() {
+	atomic.StoreInt64(&m.lastPong, time.Now().UnixNano())
+}
+
+// stats reports the client's metrics. It runs in Run, which owns rooms.
+func (c *Client) stats() ClientStats {
+	stats := ClientStats{
+		UserID:           c.UserID,
+		Role:             c.Role,
+		TeamID:           c.TeamID,
+		Transport:        c.transport,
+		RemoteAddr:       c.remoteAddr,
+		Rooms:            make([]string, 0, len(c.rooms)),
+		ConnectedAt:      c.metrics.connectedAt,
+		MessagesSent:     atomic.LoadInt64(&c.metrics.messagesSent),
+		BytesSent:        atomic.LoadInt64(&c.metrics.bytesSent),
+		MessagesReceived: atomic.LoadInt64(&c.metrics.messagesReceived),
+		MessagesDropped:  atomic.LoadInt64(&c.metrics.messagesDropped),
+		QueueLength:      len(c.send),
+		QueueCapacity:    cap(c.send),
+	}
+	if pong := atomic.LoadInt64(&c.metrics.lastPong); pong > 0 {
+		stats.LastPong = time.Unix(0, pong)
+	}
+	for room := range c.rooms {
+		stats.Rooms = append(stats.Rooms, room)
+	}
+	return stats
+}
+
+// Stats returns the hub's connection counters and the metrics of every connected client
+func (h *Hub) Stats() HubStats {
+	reply := make(chan []ClientStats)
+	h.statsRequests <- reply
+
+	return HubStats{
+		Clients:             <-reply,
+		TotalConnections:    atomic.LoadInt64(&h.metrics.totalConnections),
+		SlowDisconnects:     atomic.LoadInt64(&h.metrics.slowDisconnects),
+		TimeoutDisconnects:  atomic.LoadInt64(&h.metrics.timeoutDisconnects),
+		OversizeDisconnects: atomic.LoadInt64(&h.metrics.oversizeDisconnects),
+	}
+}
@@ -0,0 +1,115 @@
+package websocket
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// presenceRefresh is how often a hub re-records its team connections with the
+// broker, keeping them from expiring
+const presenceRefresh = 30 * time.Second
+
+// presenceTracker counts this hub's open connections per team. Run updates it
+// and syncPresence hands it to the broker.
+type presenceTracker struct {
+	mu      sync.Mutex
+	counts  map[string]int
+	changed map[string]bool // teams that came online or went offline here since the last sync
+
+	// notify wakes syncPresence; it holds at most one pending signal
+	notify chan struct{}
+}
+
+func newPresenceTracker() *presenceTracker {
+	return &presenceTracker{
+		counts:  make(map[string]int),
+		changed: make(map[string]bool),
+		notify:  make(chan struct{}, 1),
+	}
+}
+
+// add records a team connection opening (delta 1) or closing (delta -1)
+func (p *presenceTracker) add(teamID string, delta int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	before := p.counts[teamID]
+	after := before + delta
+	if after > 0 {
+		p.counts[teamID] = after
+	} else {
+		delete(p.counts, teamID)
+	}
+
+	if (before > 0) != (after > 0) {
+		p.changed[teamID] = true
+		select {
+		case p.notify <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// take copies the counts and empties the changed set
+func (p *presenceTracker) take() (map[string]int, []string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	counts := make(map[string]int, len(p.counts))
+	for teamID, n := range p.counts {
+		counts[teamID] = n
+	}
+	changed := make([]string, 0, len(p.changed))
+	for teamID := range p.changed {
+		changed = append(changed, teamID)
+	}
+	p.changed = make(map[string]bool)
+	return counts, changed
+}
+
+// syncPresence records this hub's team connections with the broker whenever a
+// team comes online or goes offline here, and every presenceRefresh. For each
+// such team it broadcasts a presence event with the team's connections across
+// every hub.
+func (h *Hub) syncPresence() {
+	ticker := time.NewTicker(presenceRefresh)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-h.presence.notify:
+		case <-ticker.C:
+		}
+
+		counts, changed := h.presence.take()
+		if err := h.broker.SetPresence(h.id, counts); err != nil {
+			log.Printf("Failed to record presence: %v", err)
+			continue
+		}
+		if len(changed) == 0 {
+			continue
+		}
+
+		totals, err := h.broker.Presence()
+		if err != nil {
+			log.Printf("Failed to read presence: %v", err)
+			continue
+		}
+		for _, teamID := range changed {
+			h.Broadcast("presence", gin.H{
+				"team_id":     teamID,
+				"online":      totals[teamID] > 0,
+				"connections": totals[teamID],
+			})
+		}
+	}
+}
+
+// Presence returns the open connections per team ID across every hub sharing
+// the broker. Teams with no connections are absent.
+func (h *Hub) Presence() (map[string]int, error) {
+	return h.broker.Presence()
+}
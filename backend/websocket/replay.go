@@ -0,0 +1,104 @@
+package websocket
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultReplayBufferSize is how many recent events are kept per auction for reconnecting clients
+const DefaultReplayBufferSize = 500
+
+// eventLog numbers the events of one auction room and keeps the most recent ones
+type eventLog struct {
+	seq    uint64
+	events []Event // oldest first
+}
+
+// replayLog holds the event log of every auction room in memory. Sequence
+// numbers start from the process start time in microseconds, so they keep
+// increasing across restarts and a client holding a sequence from before a
+// restart is told to resync.
+type replayLog struct {
+	mu   sync.Mutex
+	base uint64
+	size int
+	logs map[string]*eventLog
+}
+
+func newReplayLog(size int, start time.Time) *replayLog {
+	return &replayLog{
+		base: uint64(start.UnixMicro()),
+		size: size,
+		logs: make(map[string]*eventLog),
+	}
+}
+
+// sequenced reports whether events sent to room carry sequence numbers
+func sequenced(room string) bool {
+	return strings.HasPrefix(room, "auction:")
+}
+
+// append assigns the event the room's next sequence number and buffers it
+func (r *replayLog) append(event Event) Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	log := r.logs[event.Room]
+	if log == nil {
+		log = &eventLog{seq: r.base}
+		r.logs[event.Room] = log
+	}
+
+	log.seq++
+	event.Seq = log.seq
+	log.events = append(log.events, event)
+	if len(log.events) > r.size {
+		log.events = append([]Event(nil), log.events[len(log.events)-r.size:]...)
+	}
+	return event
+}
+
+// current returns the room's latest sequence number
+func (r *replayLog) current(room string) uint64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if log := r.logs[room]; log != nil {
+		return log.seq
+	}
+	return r.base
+}
+
+// since returns the room's buffered events after lastSeq
+func (r *replayLog) since(room string, lastSeq uint64) ([]Event, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current := r.base
+	var buffered []Event
+	if log := r.logs[room]; log != nil {
+		current = log.seq
+		buffered = log.events
+	}
+	return eventsSince(current, buffered, lastSeq)
+}
+
+// eventsSince picks the events after lastSeq out of a room's buffer. ok is
+// false when some of them are no longer buffered, or lastSeq was never
+// issued, and the client must resync from a snapshot instead.
+func eventsSince(current uint64, buffered []Event, lastSeq uint64) (events []Event, ok bool) {
+	switch {
+	case lastSeq == current:
+		return nil, true
+	case lastSeq > current || len(buffered) == 0 || lastSeq+1 < buffered[0].Seq:
+		return nil, false
+	}
+
+	for _, event := range buffered {
+		if event.Seq > lastSeq {
+			events = append(events, event)
+		}
+	}
+	return events, true
+}
@@ -0,0 +1,59 @@
+package websocket
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"auction-backend/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// HandleSpectator connects an anonymous, read-only client to an auction's
+// overlay room. Spectators need no token and anything they send is ignored.
+// Once joined, the client is sent an "overlay" message built by initial, so
+// it has the current state before the next event arrives.
+func (h *Hub) HandleSpectator(c *gin.Context, initial func(auctionID uuid.UUID) (interface{}, error)) {
+	auctionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid auction ID",
+		})
+		return
+	}
+
+	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		log.Printf("WebSocket upgrade failed: %v", err)
+		return
+	}
+
+	client := &Client{
+		Identity:   middleware.Identity{Role: RoleSpectator},
+		hub:        h,
+		conn:       conn,
+		send:       make(chan []byte, sendBufferSize),
+		transport:  TransportWebSocket,
+		remoteAddr: c.ClientIP(),
+		rooms:      make(map[string]bool),
+		seen:       make(map[string]uint64),
+		metrics:    clientMetrics{connectedAt: time.Now()},
+	}
+
+	h.register <- client
+	h.joinRoom(client, OverlayRoom(auctionID.String()), nil)
+
+	go client.writePump()
+	go client.readPump()
+
+	// Built after joining, so any change it misses arrives as a later event
+	overlay, err := initial(auctionID)
+	if err != nil {
+		log.Printf("Failed to build overlay for spectator: %v", err)
+		return
+	}
+	client.sendMessage("overlay", overlay)
+}
@@ -0,0 +1,127 @@
+package websocket
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"auction-backend/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// sseRetry is the reconnect delay, in milliseconds, suggested to EventSource clients
+const sseRetry = 3000
+
+// HandleEvents streams an auction's events as Server-Sent Events for clients
+// that cannot open a WebSocket. Each event's data is the message a WebSocket
+// client would receive, and auction room events carry their seq as the event
+// id, so a reconnecting EventSource resumes from Last-Event-ID. The token is
+// read from the token query parameter or Authorization header, since
+// EventSource cannot set headers.
+func (h *Hub) HandleEvents(c *gin.Context) {
+	token := c.Query("token")
+	if token == "" {
+		token = middleware.BearerToken(c.GetHeader("Authorization"))
+	}
+	identity, err := middleware.Authenticate(token)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	auctionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid auction ID",
+		})
+		return
+	}
+
+	// Last-Event-ID is sent by EventSource on reconnect; the query parameter
+	// lets curl resume too
+	lastEventID := c.GetHeader("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = c.Query("last_event_id")
+	}
+	var lastSeq *uint64
+	if lastEventID != "" {
+		if seq, err := strconv.ParseUint(lastEventID, 10, 64); err == nil {
+			lastSeq = &seq
+		}
+	}
+
+	client := &Client{
+		Identity:   identity,
+		hub:        h,
+		send:       make(chan []byte, sendBufferSize),
+		transport:  TransportSSE,
+		remoteAddr: c.ClientIP(),
+		rooms:      make(map[string]bool),
+		seen:       make(map[string]uint64),
+		metrics:    clientMetrics{connectedAt: time.Now()},
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no") // keep nginx from buffering the stream
+	c.Status(http.StatusOK)
+	fmt.Fprintf(c.Writer, "retry: %d\n\n", sseRetry)
+	c.Writer.Flush()
+
+	h.register <- client
+	h.joinRoom(client, AuctionRoom(auctionID.String()), lastSeq)
+	defer func() {
+		h.unregister <- client
+	}()
+
+	// Comments keep proxies from closing an idle stream
+	ticker := time.NewTicker(pingPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case message, ok := <-client.send:
+			if !ok {
+				// Dropped by the hub; EventSource reconnects with Last-Event-ID
+				return
+			}
+			if err := writeEvent(c.Writer, message); err != nil {
+				return
+			}
+			c.Writer.Flush()
+			client.metrics.sent(len(message))
+		case <-ticker.C:
+			if _, err := fmt.Fprint(c.Writer, ": ping\n\n"); err != nil {
+				return
+			}
+			c.Writer.Flush()
+		case <-c.Request.Context().Done():
+			return
+		}
+	}
+}
+
+// writeEvent writes a hub message as one event, with its seq as the id
+func writeEvent(w http.ResponseWriter, message []byte) error {
+	var header struct {
+		Seq uint64 `json:"seq"`
+	}
+	json.Unmarshal(message, &header)
+
+	if header.Seq > 0 {
+		if _, err := fmt.Fprintf(w, "id: %d\n", header.Seq); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(w, "data: %s\n\n", message)
+	return err
+}
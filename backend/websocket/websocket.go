@@ -2,179 +2,787 @@ package websocket
 
 import (
 	"encoding/json"
+	"errors"
 	"log"
+	"net"
 	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"auction-backend/middleware"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
 )
 
+const (
+	// authTimeout bounds how long a connection may stay open before sending its auth message
+	authTimeout = 10 * time.Second
+
+	// writeWait is the time allowed to write a message to the client
+	writeWait = 10 * time.Second
+
+	// pongWait is the time allowed between pongs before the client is considered gone
+	pongWait = 60 * time.Second
+
+	// pingPeriod sends pings often enough that a live client always answers within pongWait
+	pingPeriod = (pongWait * 9) / 10
+
+	// maxMessageSize is the largest message a client may send
+	maxMessageSize = 4096
+
+	// sendBufferSize is how many outgoing messages may queue for a client before it counts as slow
+	sendBufferSize = 256
+)
+
+// Transports a client can be connected over
+const (
+	TransportWebSocket = "websocket"
+	TransportSSE       = "sse"
+)
+
+// CloseSlowClient is the close code sent to a client dropped because it could
+// not keep up. The client should reconnect and resubscribe with last_seq.
+const CloseSlowClient = 4001
+
 var upgrader = websocket.Upgrader{
 	ReadBufferSize:  1024,
 	WriteBufferSize: 1024,
 	CheckOrigin: func(r *http.Request) bool {
-		return true // Allow all origins for development
+		return true // Clients authenticate with a token rather than cookies, so any origin may connect
 	},
 }
 
+// AdminRoom is the room every admin connection joins
+const AdminRoom = "admin"
+
+// AuctionRoom names the room for events about one auction
+func AuctionRoom(auctionID string) string {
+	return "auction:" + auctionID
+}
+
+// OverlayRoom names the room for an auction's public overlay, the only room
+// spectators may join
+func OverlayRoom(auctionID string) string {
+	return "overlay:" + auctionID
+}
+
+// RoleSpectator is the role of an anonymous overlay connection. Spectators
+// receive only their overlay room, never lobby broadcasts.
+const RoleSpectator = "spectator"
+
+// TeamRoom names the room for events private to one team
+func TeamRoom(teamID string) string {
+	return "team:" + teamID
+}
+
 // Hub represents the WebSocket hub for real-time communication
 type Hub struct {
+	id         string // distinguishes this replica's hub to the broker
 	clients    map[*Client]bool
-	broadcast  chan []byte
+	rooms      map[string]map[*Client]bool
+	broadcast  chan delivery
 	register   chan *Client
 	unregister chan *Client
+	membership chan membership
+	broker     Broker
+
+	// delivered is the latest sequence Run has sent to each auction room
+	delivered map[string]uint64
+
+	presence *presenceTracker
+
+	statsRequests chan chan []ClientStats
+	metrics       hubMetrics
+
+	handlersMu sync.RWMutex
+	handlers   map[string]MessageHandler
+	commands   map[string]CommandHandler
 }
 
-// Client represents a WebSocket client connection
+// Client represents an authenticated WebSocket client connection
 type Client struct {
+	middleware.Identity
+
 	hub  *Hub
-	conn *websocket.Conn
+	conn *websocket.Conn // nil for an event stream
 	send chan []byte
+
+	transport  string
+	remoteAddr string
+
+	// rooms and seen are owned by the hub's Run goroutine. seen holds the
+	// latest sequence of each auction room the client has been sent or
+	// told it is at, so later copies of those events are not sent again.
+	rooms map[string]bool
+	seen  map[string]uint64
+
+	// closeCode and closeReason are set by the hub before it closes send
+	closeCode   int
+	closeReason string
+
+	metrics clientMetrics
 }
 
 // Message represents a WebSocket message
 type Message struct {
 	Type   string      `json:"type"`
+	Room   string      `json:"room,omitempty"`
+	Seq    uint64      `json:"seq,omitempty"` // per-auction sequence number on auction room events
 	Data   interface{} `json:"data"`
 	UserID string      `json:"user_id,omitempty"`
 	TeamID string      `json:"team_id,omitempty"`
 }
 
-// NewHub creates a new WebSocket hub
-func NewHub() *Hub {
-	return &Hub{
+// inboundMessage is a message sent by a client, with data left for its handler
+// to decode. Commands carry a request_id, echoed back in their ack or nack.
+type inboundMessage struct {
+	Type      string          `json:"type"`
+	RequestID json.RawMessage `json:"request_id"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// MessageHandler validates and acts on a client message. A returned error is
+// sent back to that client as an "error" message.
+type MessageHandler func(client *Client, data json.RawMessage) error
+
+// CommandHandler runs a client command, given the whole message, and returns
+// the result sent back in its ack. A returned error is sent back as a nack.
+type CommandHandler func(client *Client, message json.RawMessage) (interface{}, error)
+
+// CommandError rejects a command with a machine-readable reason code
+type CommandError struct {
+	Reason  string
+	Message string
+}
+
+func (e *CommandError) Error() string {
+	return e.Message
+}
+
+// Nack builds a CommandError
+func Nack(reason, message string) error {
+	return &CommandError{Reason: reason, Message: message}
+}
+
+// delivery is an event for one client, or for the room it names, or when both
+// are empty everyone
+type delivery struct {
+	event  Event
+	client *Client
+}
+
+// membership adds a client to a room or removes it. A join of an auction room
+// carries the room's sequence and, when lastSeq is set, the events the client
+// missed since then, read from the broker before the join reaches Run.
+type membership struct {
+	client  *Client
+	room    string
+	join    bool
+	lastSeq *uint64
+
+	seq      uint64
+	replay   []Event
+	replayOK bool
+}
+
+// NewHub creates a new WebSocket hub that publishes through broker. A nil
+// broker keeps events in this process.
+func NewHub(broker Broker) *Hub {
+	if broker == nil {
+		broker = NewMemoryBroker(DefaultReplayBufferSize)
+	}
+
+	h := &Hub{
+		id:         uuid.New().String(),
 		clients:    make(map[*Client]bool),
-		broadcast:  make(chan []byte),
+		rooms:      make(map[string]map[*Client]bool),
+		broadcast:  make(chan delivery),
 		register:   make(chan *Client),
 		unregister: make(chan *Client),
+		membership: make(chan membership),
+		broker:     broker,
+		delivered:  make(map[string]uint64),
+		presence:   newPresenceTracker(),
+		handlers:   make(map[string]MessageHandler),
+		commands:   make(map[string]CommandHandler),
+
+		statsRequests: make(chan chan []ClientStats),
 	}
+
+	h.HandleMessage("subscribe", h.handleSubscribe)
+	h.HandleMessage("unsubscribe", h.handleUnsubscribe)
+
+	// Events published on any hub sharing the broker reach this one's Run
+	broker.Subscribe(func(event Event) {
+		h.broadcast <- delivery{event: event}
+	})
+	return h
 }
 
 // Run starts the WebSocket hub
 func (h *Hub) Run() {
+	go h.syncPresence()
+
 	for {
 		select {
 		case client := <-h.register:
 			h.clients[client] = true
-			log.Printf("Client connected. Total clients: %d", len(h.clients))
+			atomic.AddInt64(&h.metrics.totalConnections, 1)
+			if client.Role == "team" {
+				h.presence.add(client.TeamID, 1)
+			}
+
+			// Admins and teams always receive their private rooms over
+			// the socket; an event stream follows only its auction
+			if client.transport == TransportWebSocket {
+				switch client.Role {
+				case "admin":
+					h.join(client, AdminRoom)
+				case "team":
+					h.join(client, TeamRoom(client.TeamID))
+				}
+			}
+			log.Printf("Client connected: userID=%s, role=%s. Total clients: %d", client.UserID, client.Role, len(h.clients))
 		case client := <-h.unregister:
 			if _, ok := h.clients[client]; ok {
-				delete(h.clients, client)
-				close(client.send)
+				h.remove(client)
 				log.Printf("Client disconnected. Total clients: %d", len(h.clients))
 			}
-		case message := <-h.broadcast:
-			for client := range h.clients {
-				select {
-				case client.send <- message:
-				default:
-					close(client.send)
-					delete(h.clients, client)
+		case m := <-h.membership:
+			if _, ok := h.clients[m.client]; !ok {
+				continue
+			}
+			if m.join {
+				h.subscribe(m)
+			} else {
+				h.leave(m.client, m.room)
+			}
+		case d := <-h.broadcast:
+			if d.event.Seq > 0 {
+				h.delivered[d.event.Room] = d.event.Seq
+			}
+			message := encode(d.event)
+			for client := range h.recipients(d) {
+				if d.event.Room == "" && d.client == nil && client.Role == RoleSpectator {
+					continue
+				}
+				if d.event.Seq > 0 && d.event.Seq <= client.seen[d.event.Room] {
+					continue
 				}
+				h.sendTo(client, message)
+			}
+		case reply := <-h.statsRequests:
+			stats := make([]ClientStats, 0, len(h.clients))
+			for client := range h.clients {
+				stats = append(stats, client.stats())
 			}
+			reply <- stats
 		}
 	}
 }
 
-// HandleWebSocket handles WebSocket connections
+// encode marshals an event as the message clients receive
+func encode(event Event) []byte {
+	message, err := json.Marshal(Message{
+		Type: event.Type,
+		Room: event.Room,
+		Seq:  event.Seq,
+		Data: event.Data,
+	})
+	if err != nil {
+		log.Printf("Failed to marshal message: %v", err)
+	}
+	return message
+}
+
+// sendTo queues a message for a client, dropping the client if its buffer is full
+func (h *Hub) sendTo(client *Client, message []byte) {
+	if !h.clients[client] {
+		return
+	}
+
+	select {
+	case client.send <- message:
+	default:
+		// Dropping one message would leave the client silently stale, so
+		// disconnect it with a code that tells it to reconnect and resync
+		client.metrics.dropped()
+		atomic.AddInt64(&h.metrics.slowDisconnects, 1)
+		log.Printf("Disconnecting slow client: userID=%s, queued=%d", client.UserID, len(client.send))
+		client.closeCode = CloseSlowClient
+		client.closeReason = "client too slow, reconnect and resync"
+		h.remove(client)
+	}
+}
+
+// joinRoom asks Run to add the client to room. For an auction room it first
+// reads the room's sequence, and the events after lastSeq when set, from the
+// broker here in the caller's goroutine, so a slow broker holds up only the
+// client joining rather than every delivery in Run.
+func (h *Hub) joinRoom(client *Client, room string, lastSeq *uint64) {
+	m := membership{client: client, room: room, join: true, lastSeq: lastSeq}
+	if sequenced(room) {
+		var err error
+		if m.seq, err = h.broker.CurrentSeq(room); err != nil {
+			log.Printf("Failed to read sequence for %s: %v", room, err)
+		}
+		if lastSeq != nil {
+			m.replay, m.replayOK, err = h.broker.Since(room, *lastSeq)
+			if err != nil {
+				log.Printf("Failed to read replay buffer for %s: %v", room, err)
+				m.replayOK = false
+			}
+			if n := len(m.replay); m.replayOK && n > 0 && m.replay[n-1].Seq > m.seq {
+				m.seq = m.replay[n-1].Seq
+			}
+		}
+	}
+	h.membership <- m
+}
+
+// subscribe joins a client to a room, confirms it with the room's current
+// sequence and replays what the client missed when it sent last_seq. Events up
+// to that sequence still on their way to Run are then skipped for the client,
+// so none is sent both in the replay and live. If Run already delivered events
+// newer than the replay, before the client joined, the client must resync.
+func (h *Hub) subscribe(m membership) {
+	h.join(m.client, m.room)
+
+	if !sequenced(m.room) {
+		h.sendTo(m.client, encodeData("subscribed", gin.H{"room": m.room}))
+		return
+	}
+
+	seq := m.seq
+	delivered := h.delivered[m.room]
+	if delivered > seq {
+		seq = delivered
+	}
+	m.client.seen[m.room] = seq
+	h.sendTo(m.client, encodeData("subscribed", gin.H{"room": m.room, "seq": seq}))
+
+	if m.lastSeq == nil {
+		return
+	}
+
+	missed := delivered > m.seq && delivered > *m.lastSeq
+	if !m.replayOK || missed {
+		h.sendTo(m.client, encode(Event{Type: "resync_required", Room: m.room, Data: mustMarshal(gin.H{
+			"room":     m.room,
+			"seq":      seq,
+			"last_seq": *m.lastSeq,
+			"snapshot": "/api/v1/auctions/" + strings.TrimPrefix(m.room, "auction:") + "/state",
+		})}))
+		return
+	}
+	for _, event := range m.replay {
+		h.sendTo(m.client, encode(event))
+	}
+}
+
+// encodeData marshals a message that belongs to no room
+func encodeData(messageType string, data interface{}) []byte {
+	return encode(Event{Type: messageType, Data: mustMarshal(data)})
+}
+
+// mustMarshal marshals data built by the hub itself, which always succeeds
+func mustMarshal(data interface{}) json.RawMessage {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		log.Printf("Failed to marshal message: %v", err)
+	}
+	return raw
+}
+
+// CurrentSeq returns the sequence number of the latest event published to an auction's room
+func (h *Hub) CurrentSeq(auctionID string) uint64 {
+	seq, err := h.broker.CurrentSeq(AuctionRoom(auctionID))
+	if err != nil {
+		log.Printf("Failed to read sequence for auction %s: %v", auctionID, err)
+	}
+	return seq
+}
+
+// recipients returns the clients a delivery is addressed to
+func (h *Hub) recipients(d delivery) map[*Client]bool {
+	switch {
+	case d.client != nil:
+		if !h.clients[d.client] {
+			return nil
+		}
+		return map[*Client]bool{d.client: true}
+	case d.event.Room != "":
+		return h.rooms[d.event.Room]
+	}
+	return h.clients
+}
+
+func (h *Hub) join(client *Client, room string) {
+	if h.rooms[room] == nil {
+		h.rooms[room] = make(map[*Client]bool)
+	}
+	h.rooms[room][client] = true
+	client.rooms[room] = true
+}
+
+func (h *Hub) leave(client *Client, room string) {
+	delete(h.rooms[room], client)
+	if len(h.rooms[room]) == 0 {
+		delete(h.rooms, room)
+	}
+	delete(client.rooms, room)
+	delete(client.seen, room)
+}
+
+// remove drops a client from the hub and its rooms and closes its send channel
+func (h *Hub) remove(client *Client) {
+	for room := range client.rooms {
+		h.leave(client, room)
+	}
+	delete(h.clients, client)
+	close(client.send)
+	if client.Role == "team" {
+		h.presence.add(client.TeamID, -1)
+	}
+}
+
+// HandleMessage registers the handler for client messages of the given type.
+// Messages without a registered handler are ignored.
+func (h *Hub) HandleMessage(messageType string, handler MessageHandler) {
+	h.handlersMu.Lock()
+	defer h.handlersMu.Unlock()
+	h.handlers[messageType] = handler
+}
+
+// HandleCommand registers the handler for client commands of the given type.
+// Every command is answered with an ack or a nack carrying its request_id.
+func (h *Hub) HandleCommand(messageType string, handler CommandHandler) {
+	h.handlersMu.Lock()
+	defer h.handlersMu.Unlock()
+	h.commands[messageType] = handler
+}
+
+// HandleWebSocket handles WebSocket connections. The token is read from the
+// token query parameter or Authorization header, or else from a first message
+// of the form {"type":"auth","data":{"token":"..."}}.
 func (h *Hub) HandleWebSocket(c *gin.Context) {
+	token := c.Query("token")
+	if token == "" {
+		token = middleware.BearerToken(c.GetHeader("Authorization"))
+	}
+
+	// Reject bad tokens before upgrading so the client gets a plain 401
+	var identity middleware.Identity
+	if token != "" {
+		var err error
+		identity, err = middleware.Authenticate(token)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"success": false,
+				"error":   err.Error(),
+			})
+			return
+		}
+	}
+
 	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
 	if err != nil {
 		log.Printf("WebSocket upgrade failed: %v", err)
 		return
 	}
 
+	conn.SetReadLimit(maxMessageSize)
+	if token == "" {
+		identity, err = readAuthMessage(conn)
+		if err != nil {
+			deadline := time.Now().Add(time.Second)
+			conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.ClosePolicyViolation, err.Error()), deadline)
+			conn.Close()
+			return
+		}
+	}
+
 	client := &Client{
-		hub:  h,
-		conn: conn,
-		send: make(chan []byte, 256),
+		Identity:   identity,
+		hub:        h,
+		conn:       conn,
+		send:       make(chan []byte, sendBufferSize),
+		transport:  TransportWebSocket,
+		remoteAddr: conn.RemoteAddr().String(),
+		rooms:      make(map[string]bool),
+		seen:       make(map[string]uint64),
+		metrics:    clientMetrics{connectedAt: time.Now()},
 	}
 
 	client.hub.register <- client
 
 	go client.writePump()
 	go client.readPump()
+
+	client.sendMessage("authenticated", gin.H{
+		"user_id": identity.UserID,
+		"role":    identity.Role,
+		"team_id": identity.TeamID,
+	})
 }
 
-// readPump pumps messages from the websocket connection to the hub
+// readAuthMessage waits for the connection's auth message and verifies its token
+func readAuthMessage(conn *websocket.Conn) (middleware.Identity, error) {
+	conn.SetReadDeadline(time.Now().Add(authTimeout))
+	defer conn.SetReadDeadline(time.Time{})
+
+	var msg inboundMessage
+	if err := conn.ReadJSON(&msg); err != nil {
+		return middleware.Identity{}, errors.New("Authentication required")
+	}
+	if msg.Type != "auth" {
+		return middleware.Identity{}, errors.New("Authentication required")
+	}
+
+	var data struct {
+		Token string `json:"token"`
+	}
+	if err := json.Unmarshal(msg.Data, &data); err != nil {
+		return middleware.Identity{}, errors.New("Invalid auth message")
+	}
+
+	return middleware.Authenticate(middleware.BearerToken(data.Token))
+}
+
+// readPump reads client messages and passes each to its registered handler.
+// The connection is closed if no pong arrives within pongWait or a message
+// exceeds maxMessageSize.
 func (c *Client) readPump() {
 	defer func() {
 		c.hub.unregister <- c
 		c.conn.Close()
 	}()
 
+	c.conn.SetReadLimit(maxMessageSize)
+	c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	c.conn.SetPongHandler(func(string) error {
+		c.metrics.pong()
+		return c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+
 	for {
 		_, message, err := c.conn.ReadMessage()
 		if err != nil {
-			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
+			var netErr net.Error
+			switch {
+			case errors.Is(err, websocket.ErrReadLimit):
+				atomic.AddInt64(&c.hub.metrics.oversizeDisconnects, 1)
+				log.Printf("WebSocket message from user %s exceeded %d bytes", c.UserID, maxMessageSize)
+			case errors.As(err, &netErr) && netErr.Timeout():
+				atomic.AddInt64(&c.hub.metrics.timeoutDisconnects, 1)
+				log.Printf("WebSocket client %s timed out waiting for pong", c.UserID)
+			case websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure):
 				log.Printf("WebSocket read error: %v", err)
 			}
 			break
 		}
+		c.metrics.received()
+
+		// Spectators are read-only
+		if c.Role == RoleSpectator {
+			continue
+		}
 
 		// Parse message
-		var msg Message
+		var msg inboundMessage
 		if err := json.Unmarshal(message, &msg); err != nil {
 			log.Printf("Failed to parse message: %v", err)
 			continue
 		}
 
-		// Handle different message types
-		switch msg.Type {
-		case "bid":
-			// Broadcast bid to all clients
-			c.hub.broadcast <- message
-		case "auction_status":
-			// Broadcast auction status
-			c.hub.broadcast <- message
-		case "player_sold":
-			// Broadcast player sold
-			c.hub.broadcast <- message
-		default:
-			log.Printf("Unknown message type: %s", msg.Type)
+		// Client messages are never relayed as-is; only registered handlers act on them
+		c.hub.handlersMu.RLock()
+		handler, ok := c.hub.handlers[msg.Type]
+		command, isCommand := c.hub.commands[msg.Type]
+		c.hub.handlersMu.RUnlock()
+		if isCommand {
+			c.runCommand(msg, message, command)
+			continue
+		}
+		if !ok {
+			log.Printf("Ignoring unhandled message type %s from user %s", msg.Type, c.UserID)
+			continue
+		}
+
+		if err := handler(c, msg.Data); err != nil {
+			c.sendMessage("error", gin.H{
+				"type":  msg.Type,
+				"error": err.Error(),
+			})
 		}
 	}
 }
 
-// writePump pumps messages from the hub to the websocket connection
+// runCommand runs a command and answers it with an ack holding the result or a
+// nack holding the reason it was rejected
+func (c *Client) runCommand(msg inboundMessage, message []byte, command CommandHandler) {
+	if len(msg.RequestID) == 0 || string(msg.RequestID) == "null" {
+		c.sendMessage("nack", gin.H{
+			"command": msg.Type,
+			"reason":  "request_id_required",
+			"error":   "request_id is required",
+		})
+		return
+	}
+
+	result, err := command(c, message)
+	if err != nil {
+		reason, text := "internal_error", "Command failed"
+		var cmdErr *CommandError
+		if errors.As(err, &cmdErr) {
+			reason, text = cmdErr.Reason, cmdErr.Message
+		} else {
+			log.Printf("Command %s from user %s failed: %v", msg.Type, c.UserID, err)
+		}
+		c.sendMessage("nack", gin.H{
+			"request_id": msg.RequestID,
+			"command":    msg.Type,
+			"reason":     reason,
+			"error":      text,
+		})
+		return
+	}
+
+	c.sendMessage("ack", gin.H{
+		"request_id": msg.RequestID,
+		"command":    msg.Type,
+		"result":     result,
+	})
+}
+
+// RemoteAddr returns the address the client connected from
+func (c *Client) RemoteAddr() string {
+	return c.remoteAddr
+}
+
+// writePump pumps messages from the hub to the websocket connection and pings
+// the client every pingPeriod
 func (c *Client) writePump() {
+	ticker := time.NewTicker(pingPeriod)
 	defer func() {
+		ticker.Stop()
 		c.conn.Close()
 	}()
 
 	for {
 		select {
 		case message, ok := <-c.send:
+			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
 			if !ok {
-				c.conn.WriteMessage(websocket.CloseMessage, []byte{})
+				// The hub closed the channel, with a reason if it dropped the client
+				code := c.closeCode
+				if code == 0 {
+					code = websocket.CloseNormalClosure
+				}
+				c.conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(code, c.closeReason))
 				return
 			}
 
-			w, err := c.conn.NextWriter(websocket.TextMessage)
-			if err != nil {
+			if err := c.conn.WriteMessage(websocket.TextMessage, message); err != nil {
 				return
 			}
-			w.Write(message)
-
-			if err := w.Close(); err != nil {
+			c.metrics.sent(len(message))
+		case <-ticker.C:
+			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
 				return
 			}
 		}
 	}
 }
 
-// Broadcast sends a message to all connected clients
-func (h *Hub) Broadcast(messageType string, data interface{}) {
-	msg := Message{
-		Type: messageType,
-		Data: data,
+// canJoin reports whether the client may subscribe to room. Any client may
+// follow an auction's overlay, and any but a spectator the auction itself;
+// team rooms are limited to that team and admins.
+func (c *Client) canJoin(room string) bool {
+	switch {
+	case strings.HasPrefix(room, "overlay:"):
+		_, err := uuid.Parse(strings.TrimPrefix(room, "overlay:"))
+		return err == nil
+	case c.Role == RoleSpectator:
+		return false
+	case room == AdminRoom:
+		return c.Role == "admin"
+	case strings.HasPrefix(room, "team:"):
+		return c.Role == "admin" || (c.Role == "team" && room == TeamRoom(c.TeamID))
+	case strings.HasPrefix(room, "auction:"):
+		_, err := uuid.Parse(strings.TrimPrefix(room, "auction:"))
+		return err == nil
+	}
+	return false
+}
+
+// roomRequest is the data of a subscribe or unsubscribe control message.
+// LastSeq is sent when resubscribing after a reconnect.
+type roomRequest struct {
+	Room    string  `json:"room"`
+	LastSeq *uint64 `json:"last_seq"`
+}
+
+// handleSubscribe joins the client to a room: {"type":"subscribe","data":{"room":"auction:{id}","last_seq":41}}
+func (h *Hub) handleSubscribe(client *Client, data json.RawMessage) error {
+	var req roomRequest
+	if err := json.Unmarshal(data, &req); err != nil || req.Room == "" {
+		return errors.New("Room is required")
+	}
+	if !client.canJoin(req.Room) {
+		return errors.New("Not allowed to subscribe to " + req.Room)
 	}
 
-	messageBytes, err := json.Marshal(msg)
+	h.joinRoom(client, req.Room, req.LastSeq)
+	return nil
+}
+
+// handleUnsubscribe removes the client from a room: {"type":"unsubscribe","data":{"room":"auction:{id}"}}
+func (h *Hub) handleUnsubscribe(client *Client, data json.RawMessage) error {
+	var req roomRequest
+	if err := json.Unmarshal(data, &req); err != nil || req.Room == "" {
+		return errors.New("Room is required")
+	}
+
+	h.membership <- membership{client: client, room: req.Room, join: false}
+	client.sendMessage("unsubscribed", gin.H{"room": req.Room})
+	return nil
+}
+
+// sendMessage sends a message to this client only. It goes through the hub,
+// which owns the send channel, but never through the broker since the client
+// is connected here.
+func (c *Client) sendMessage(messageType string, data interface{}) {
+	c.hub.broadcast <- delivery{event: Event{Type: messageType, Data: mustMarshal(data)}, client: c}
+}
+
+// Broadcast sends a message to all connected clients on every replica. Use it
+// only for events every client needs; anything scoped to an auction or team
+// goes to Publish.
+func (h *Hub) Broadcast(messageType string, data interface{}) {
+	h.publish("", messageType, data)
+}
+
+// Publish sends a message to the clients subscribed to room on every replica
+func (h *Hub) Publish(room, messageType string, data interface{}) {
+	h.publish(room, messageType, data)
+}
+
+// publish marshals the data and hands the event to the broker, which delivers
+// it back to Run on every hub
+func (h *Hub) publish(room, messageType string, data interface{}) {
+	raw, err := json.Marshal(data)
 	if err != nil {
 		log.Printf("Failed to marshal message: %v", err)
 		return
 	}
 
-	h.broadcast <- messageBytes
+	if err := h.broker.Publish(Event{Room: room, Type: messageType, Data: raw}); err != nil {
+		log.Printf("Failed to publish %s to %q: %v", messageType, room, err)
+	}
 }
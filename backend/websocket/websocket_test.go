@@ -0,0 +1,109 @@
+package websocket
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"auction-backend/middleware"
+
+	"github.com/google/uuid"
+)
+
+// heldBroker numbers events as they are published but, while held, keeps
+// them from the hub until released, as a slow broker connection would
+type heldBroker struct {
+	Broker
+
+	mu      sync.Mutex
+	hold    bool
+	pending []Event
+	handle  func(Event)
+}
+
+func (b *heldBroker) Subscribe(handle func(Event)) {
+	b.handle = handle
+	b.Broker.Subscribe(func(event Event) {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if b.hold {
+			b.pending = append(b.pending, event)
+			return
+		}
+		handle(event)
+	})
+}
+
+func (b *heldBroker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.hold = false
+	for _, event := range b.pending {
+		b.handle(event)
+	}
+	b.pending = nil
+}
+
+// receive reads the next message queued for client
+func receive(t *testing.T, client *Client) Message {
+	t.Helper()
+
+	select {
+	case raw := <-client.send:
+		var msg Message
+		if err := json.Unmarshal(raw, &msg); err != nil {
+			t.Fatal(err)
+		}
+		return msg
+	case <-time.After(time.Second):
+		t.Fatal("no message for client")
+	}
+	return Message{}
+}
+
+func TestSubscribeReplaysEventsInFlightOnlyOnce(t *testing.T) {
+	broker := &heldBroker{Broker: NewMemoryBroker(DefaultReplayBufferSize)}
+	hub := NewHub(broker)
+	go hub.Run()
+
+	client := &Client{
+		Identity:  middleware.Identity{UserID: "admin", Role: "admin"},
+		hub:       hub,
+		send:      make(chan []byte, sendBufferSize),
+		transport: TransportSSE,
+		rooms:     make(map[string]bool),
+		seen:      make(map[string]uint64),
+	}
+	hub.register <- client
+
+	room := AuctionRoom(uuid.New().String())
+	lastSeq, _ := broker.CurrentSeq(room)
+
+	// The events are numbered but still on their way to the hub when the
+	// client resubscribes, so it gets them in the replay and not again live
+	broker.mu.Lock()
+	broker.hold = true
+	broker.mu.Unlock()
+	for i := 1; i <= 3; i++ {
+		hub.Publish(room, "bid_placed", i)
+	}
+
+	hub.joinRoom(client, room, &lastSeq)
+	broker.release()
+	hub.Publish(room, "bid_placed", 4)
+
+	if msg := receive(t, client); msg.Type != "subscribed" {
+		t.Fatalf("first message = %+v, want subscribed", msg)
+	}
+	for want := lastSeq + 1; want <= lastSeq+4; want++ {
+		if msg := receive(t, client); msg.Type != "bid_placed" || msg.Seq != want {
+			t.Fatalf("message = %+v, want bid_placed with seq %d", msg, want)
+		}
+	}
+	select {
+	case raw := <-client.send:
+		t.Fatalf("unexpected message %s", raw)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
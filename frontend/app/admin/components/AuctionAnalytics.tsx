@@ -0,0 +1,112 @@
+'use client'
+
+import { useEffect, useState } from 'react'
+import { BarChart3 } from 'lucide-react'
+import { adminAPI, CategoryPrice, LotStats, TeamEfficiency } from '@/lib/api'
+
+const formatSeconds = (seconds: number) => {
+  const minutes = Math.floor(seconds / 60)
+  return minutes > 0 ? `${minutes}m ${Math.round(seconds % 60)}s` : `${Math.round(seconds)}s`
+}
+
+export default function AuctionAnalytics() {
+  const [prices, setPrices] = useState<CategoryPrice[]>([])
+  const [lots, setLots] = useState<LotStats[]>([])
+  const [efficiency, setEfficiency] = useState<TeamEfficiency[]>([])
+
+  useEffect(() => {
+    const load = async () => {
+      try {
+        const [pricesData, lotsData, efficiencyData] = await Promise.all([
+          adminAPI.getCategoryPrices(),
+          adminAPI.getContestedLots(5),
+          adminAPI.getBudgetEfficiency(),
+        ])
+        setPrices(pricesData)
+        setLots(lotsData)
+        setEfficiency(efficiencyData)
+      } catch (error) {
+        console.error('Error loading analytics:', error)
+      }
+    }
+    load()
+  }, [])
+
+  return (
+    <div className="bg-white rounded-xl shadow-lg p-6 space-y-6">
+      <h3 className="text-xl font-bold text-gray-900 flex items-center">
+        <BarChart3 className="h-5 w-5 mr-2" />
+        Auction Analytics
+      </h3>
+
+      <div className="grid grid-cols-1 lg:grid-cols-3 gap-6">
+        <div>
+          <h4 className="font-semibold text-gray-700 mb-2">Prices by Category</h4>
+          <table className="w-full text-sm">
+            <thead>
+              <tr className="text-left text-gray-500">
+                <th>Category</th><th className="text-right">Sold</th><th className="text-right">Avg</th>
+                <th className="text-right">Median</th><th className="text-right">Max</th>
+              </tr>
+            </thead>
+            <tbody>
+              {prices.map(price => (
+                <tr key={price.category} className="border-t">
+                  <td className="py-1">{price.category_name}</td>
+                  <td className="text-right">{price.sold}</td>
+                  <td className="text-right">{Math.round(price.average)}</td>
+                  <td className="text-right">{Math.round(price.median)}</td>
+                  <td className="text-right">{price.max}</td>
+                </tr>
+              ))}
+            </tbody>
+          </table>
+        </div>
+
+        <div>
+          <h4 className="font-semibold text-gray-700 mb-2">Most Contested Lots</h4>
+          <table className="w-full text-sm">
+            <thead>
+              <tr className="text-left text-gray-500">
+                <th>Player</th><th className="text-right">Teams</th><th className="text-right">Bids</th>
+                <th className="text-right">Final</th><th className="text-right">Time</th>
+              </tr>
+            </thead>
+            <tbody>
+              {lots.map(lot => (
+                <tr key={`${lot.auction_id}-${lot.player_id}`} className="border-t">
+                  <td className="py-1">{lot.player_name}</td>
+                  <td className="text-right">{lot.teams}</td>
+                  <td className="text-right">{lot.bids}</td>
+                  <td className="text-right">{lot.final_bid}</td>
+                  <td className="text-right">{formatSeconds(lot.seconds)}</td>
+                </tr>
+              ))}
+            </tbody>
+          </table>
+        </div>
+
+        <div>
+          <h4 className="font-semibold text-gray-700 mb-2">Budget Efficiency</h4>
+          <table className="w-full text-sm">
+            <thead>
+              <tr className="text-left text-gray-500">
+                <th>#</th><th>Team</th><th className="text-right">Spent</th><th className="text-right">Value</th>
+              </tr>
+            </thead>
+            <tbody>
+              {efficiency.map(team => (
+                <tr key={team.team_id} className="border-t">
+                  <td className="py-1">{team.rank}</td>
+                  <td>{team.team_name}</td>
+                  <td className="text-right">{team.spent}</td>
+                  <td className="text-right">{team.efficiency.toFixed(2)}x</td>
+                </tr>
+              ))}
+            </tbody>
+          </table>
+        </div>
+      </div>
+    </div>
+  )
+}
@@ -10,7 +10,8 @@ interface Player {
   id: string
   name: string
   gender: string
-  age: number
+  age?: number
+  player_category?: string
   playing_category: string
   player_category?: string
   accomplishments: string
@@ -68,6 +69,7 @@ export default function AuctionManager() {
         case 'auction_updated':
         case 'next_player':
         case 'auction_completed':
+        case 'resync_required':
           fetchAuctions()
           break
         case 'new_bid':
@@ -87,6 +89,14 @@ export default function AuctionManager() {
     }
   })
 
+  // Bids and player changes are only sent to clients subscribed to the auction's room
+  useEffect(() => {
+    if (!isConnected || !currentAuction) return
+    const room = `auction:${currentAuction.id}`
+    sendMessage({ type: 'subscribe', data: { room } })
+    return () => sendMessage({ type: 'unsubscribe', data: { room } })
+  }, [isConnected, currentAuction?.id])
+
   useEffect(() => {
     fetchAuctions()
     fetchPlayers()
@@ -277,11 +287,13 @@ export default function AuctionManager() {
     }
   }
 
-  const createAuction = async () => {
+  const createAuction = async (supplementary = false) => {
     setIsLoading(true)
     try {
-      // Create auction
-      const newAuction = await adminAPI.createAuction({ title: 'Player Auction' })
+      // Create auction; a supplementary one signs replacements for released players
+      const newAuction = await adminAPI.createAuction(
+        supplementary ? { title: 'Replacement Auction', supplementary: true } : { title: 'Player Auction' }
+      )
       
       // Immediately start the auction
       await adminAPI.startAuction(newAuction.id)
@@ -343,6 +355,16 @@ export default function AuctionManager() {
                 {isLoading ? 'Creating & Starting...' : 'Create & Start Auction'}
               </button>
             )}
+            {!currentAuction && (
+              <button
+                onClick={() => createAuction(true)}
+                disabled={isLoading}
+                title="Only teams with an open replacement slot can bid"
+                className="bg-gradient-to-r from-purple-500 to-purple-600 hover:from-purple-600 hover:to-purple-700 text-white px-6 py-3 rounded-lg font-semibold shadow-lg hover:shadow-xl transform hover:scale-105 transition-all duration-300 disabled:opacity-50 disabled:cursor-not-allowed"
+              >
+                Start Replacement Auction
+              </button>
+            )}
             {currentAuction && currentAuction.status === 'pending' && (
               <button
                 onClick={() => startAuction(currentAuction.id)}
@@ -0,0 +1,69 @@
+'use client'
+
+import { useState } from 'react'
+import { Download } from 'lucide-react'
+import { adminAPI, ExportFormat, ExportKind } from '@/lib/api'
+
+const exports: { kind: ExportKind; name: string }[] = [
+  { kind: 'results', name: 'Results' },
+  { kind: 'squads', name: 'Team squads' },
+  { kind: 'bids', name: 'Bid history' },
+]
+
+const formats: ExportFormat[] = ['csv', 'xlsx', 'pdf']
+
+export default function ExportMenu() {
+  const [isOpen, setIsOpen] = useState(false)
+  const [downloading, setDownloading] = useState('')
+
+  const download = async (kind: ExportKind, format: ExportFormat) => {
+    setDownloading(`${kind}.${format}`)
+    try {
+      const blob = await adminAPI.downloadExport(kind, format)
+      const url = URL.createObjectURL(blob)
+      const link = document.createElement('a')
+      link.href = url
+      link.download = `${kind}-${new Date().toISOString().slice(0, 10)}.${format}`
+      link.click()
+      URL.revokeObjectURL(url)
+    } catch (error) {
+      console.error('Error downloading export:', error)
+    } finally {
+      setDownloading('')
+    }
+  }
+
+  return (
+    <div className="relative">
+      <button
+        onClick={() => setIsOpen(!isOpen)}
+        className="btn-secondary flex items-center"
+      >
+        <Download className="h-4 w-4 mr-2" />
+        Export
+      </button>
+
+      {isOpen && (
+        <div className="absolute right-0 mt-2 w-72 bg-white rounded-lg shadow-lg border p-3 z-40 space-y-2">
+          {exports.map(({ kind, name }) => (
+            <div key={kind} className="flex items-center justify-between">
+              <span className="text-sm font-medium text-gray-700">{name}</span>
+              <div className="flex space-x-1">
+                {formats.map(format => (
+                  <button
+                    key={format}
+                    onClick={() => download(kind, format)}
+                    disabled={downloading !== ''}
+                    className="px-2 py-1 text-xs rounded border hover:bg-gray-100 uppercase disabled:opacity-50"
+                  >
+                    {downloading === `${kind}.${format}` ? '...' : format}
+                  </button>
+                ))}
+              </div>
+            </div>
+          ))}
+        </div>
+      )}
+    </div>
+  )
+}
@@ -0,0 +1,150 @@
+'use client'
+
+import { useState } from 'react'
+import { Upload, X, CheckCircle, AlertCircle } from 'lucide-react'
+import { adminAPI, ImportReport } from '@/lib/api'
+
+interface PlayerImporterProps {
+  onPlayersImported: () => void
+}
+
+export default function PlayerImporter({ onPlayersImported }: PlayerImporterProps) {
+  const [isOpen, setIsOpen] = useState(false)
+  const [isLoading, setIsLoading] = useState(false)
+  const [file, setFile] = useState<File | null>(null)
+  const [report, setReport] = useState<ImportReport | null>(null)
+  const [error, setError] = useState('')
+
+  const close = () => {
+    setIsOpen(false)
+    setFile(null)
+    setReport(null)
+    setError('')
+  }
+
+  const run = async (dryRun: boolean) => {
+    if (!file) return
+    setIsLoading(true)
+    setError('')
+
+    try {
+      const result = await adminAPI.importPlayers(file, dryRun)
+      setReport(result)
+      if (!dryRun && result.imported > 0) {
+        onPlayersImported()
+      }
+    } catch (err: any) {
+      setReport(null)
+      setError(err.response?.data?.error || 'Failed to import players')
+    } finally {
+      setIsLoading(false)
+    }
+  }
+
+  // Only a checked file without errors may be imported
+  const canImport = report?.dry_run && report.errors.length === 0
+
+  return (
+    <>
+      <button
+        onClick={() => setIsOpen(true)}
+        className="btn-secondary flex items-center"
+      >
+        <Upload className="h-4 w-4 mr-2" />
+        Import Players
+      </button>
+
+      {isOpen && (
+        <div className="fixed inset-0 bg-black bg-opacity-50 flex items-center justify-center z-50">
+          <div className="bg-white rounded-lg p-6 w-full max-w-2xl max-h-[90vh] overflow-y-auto">
+            <div className="flex justify-between items-center mb-4">
+              <h2 className="text-xl font-semibold">Import Players</h2>
+              <button
+                onClick={close}
+                className="text-gray-400 hover:text-gray-600"
+              >
+                <X className="h-5 w-5" />
+              </button>
+            </div>
+
+            <p className="text-sm text-gray-600 mb-4">
+              Upload a CSV or XLSX file with the columns name, gender, date_of_birth, mobile and
+              playing_category, and optionally accomplishments and email. The file is checked first;
+              players are imported only when every row is valid.
+            </p>
+
+            <input
+              type="file"
+              accept=".csv,.xlsx,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+              onChange={(e) => {
+                setFile(e.target.files?.[0] ?? null)
+                setReport(null)
+                setError('')
+              }}
+              className="input-field w-full"
+            />
+
+            {error && (
+              <div className="mt-4 p-3 bg-red-50 text-red-700 rounded-lg text-sm">{error}</div>
+            )}
+
+            {report && (
+              <div className="mt-4">
+                {report.errors.length === 0 ? (
+                  <div className="flex items-center p-3 bg-green-50 text-green-700 rounded-lg text-sm">
+                    <CheckCircle className="h-4 w-4 mr-2" />
+                    {report.dry_run
+                      ? `All ${report.valid_rows} rows are valid and ready to import.`
+                      : `Imported ${report.imported} players.`}
+                  </div>
+                ) : (
+                  <>
+                    <div className="flex items-center p-3 bg-red-50 text-red-700 rounded-lg text-sm mb-2">
+                      <AlertCircle className="h-4 w-4 mr-2" />
+                      {report.errors.length} problem(s) in {report.total_rows} rows; nothing was imported.
+                    </div>
+                    <table className="w-full text-sm">
+                      <thead>
+                        <tr className="text-left text-gray-500">
+                          <th className="py-1 pr-4">Row</th>
+                          <th className="py-1 pr-4">Column</th>
+                          <th className="py-1">Problem</th>
+                        </tr>
+                      </thead>
+                      <tbody>
+                        {report.errors.map((rowError, index) => (
+                          <tr key={index} className="border-t">
+                            <td className="py-1 pr-4">{rowError.row || '-'}</td>
+                            <td className="py-1 pr-4">{rowError.field || '-'}</td>
+                            <td className="py-1">{rowError.message}</td>
+                          </tr>
+                        ))}
+                      </tbody>
+                    </table>
+                  </>
+                )}
+              </div>
+            )}
+
+            <div className="flex space-x-3 pt-4">
+              <button
+                onClick={() => run(true)}
+                disabled={!file || isLoading}
+                className="btn-secondary flex-1"
+              >
+                {isLoading ? 'Working...' : 'Check File'}
+              </button>
+              <button
+                onClick={() => run(false)}
+                disabled={!canImport || isLoading}
+                className="btn-primary flex-1"
+              >
+                Import
+              </button>
+            </div>
+          </div>
+        </div>
+      )}
+    </>
+  )
+}
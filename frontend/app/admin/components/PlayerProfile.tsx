@@ -7,7 +7,8 @@ interface Player {
   id: string
   name: string
   gender: string
-  age: number
+  age?: number
+  player_category?: string
   playing_category: string
   player_category?: string
   accomplishments: string
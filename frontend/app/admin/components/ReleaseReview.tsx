@@ -0,0 +1,143 @@
+'use client'
+
+import { useCallback, useEffect, useState } from 'react'
+import { UserMinus } from 'lucide-react'
+import { adminAPI, Release } from '@/lib/api'
+import { useWebSocket } from '@/lib/websocket'
+
+interface ReleaseReviewProps {
+  onSettled: () => void
+}
+
+export default function ReleaseReview({ onSettled }: ReleaseReviewProps) {
+  const [releases, setReleases] = useState<Release[]>([])
+  const [reasons, setReasons] = useState<Record<string, string>>({})
+  const [percents, setPercents] = useState<Record<string, string>>({})
+  const [error, setError] = useState<string | null>(null)
+
+  const fetchReleases = useCallback(async () => {
+    try {
+      setReleases(await adminAPI.getReleases())
+    } catch (error) {
+      console.error('Error fetching releases:', error)
+    }
+  }, [])
+
+  useEffect(() => {
+    fetchReleases()
+  }, [fetchReleases])
+
+  useWebSocket({
+    onMessage: useCallback((message: any) => {
+      if (message.type === 'release_updated') {
+        fetchReleases()
+      }
+    }, [fetchReleases])
+  })
+
+  const review = async (release: Release, approved: boolean) => {
+    setError(null)
+    // An empty percentage falls back to the server's configured default
+    const percent = percents[release.id]?.trim()
+    try {
+      await adminAPI.reviewRelease(release.id, {
+        approved,
+        reason: reasons[release.id],
+        refund_percent: approved && percent ? parseInt(percent, 10) : undefined,
+      })
+      fetchReleases()
+      if (approved) {
+        onSettled()
+      }
+    } catch (error: any) {
+      setError(error.response?.data?.error || 'Failed to review release')
+    }
+  }
+
+  const pending = releases.filter(release => release.status === 'requested')
+  const history = releases.filter(release => release.status !== 'requested').slice(0, 10)
+
+  return (
+    <div className="bg-white rounded-xl shadow-lg p-6 space-y-6">
+      <h3 className="text-xl font-bold text-gray-900 flex items-center">
+        <UserMinus className="h-5 w-5 mr-2" />
+        Releases
+      </h3>
+
+      <div>
+        <h4 className="font-semibold text-gray-700 mb-2">Awaiting Approval</h4>
+        {pending.length === 0 ? (
+          <p className="text-sm text-gray-500">No releases are waiting for review.</p>
+        ) : (
+          <div className="space-y-3">
+            {pending.map(release => (
+              <div key={release.id} className="p-4 bg-gray-50 rounded-lg space-y-2">
+                <p className="font-medium text-gray-900">
+                  {release.team_name} releases {release.player_name}
+                </p>
+                <p className="text-sm text-gray-600">{release.reason}</p>
+                <div className="flex flex-col md:flex-row gap-2">
+                  <input
+                    type="number"
+                    min={0}
+                    max={100}
+                    value={percents[release.id] || ''}
+                    onChange={(e) => setPercents({ ...percents, [release.id]: e.target.value })}
+                    placeholder="Refund % (default)"
+                    className="input-field md:w-44"
+                  />
+                  <input
+                    value={reasons[release.id] || ''}
+                    onChange={(e) => setReasons({ ...reasons, [release.id]: e.target.value })}
+                    placeholder="Reason (required to reject)"
+                    className="input-field flex-1"
+                  />
+                </div>
+                <div className="flex space-x-2">
+                  <button onClick={() => review(release, true)} className="btn-primary text-sm">Approve</button>
+                  <button
+                    onClick={() => review(release, false)}
+                    disabled={!reasons[release.id]?.trim()}
+                    className="btn-secondary text-sm disabled:opacity-50"
+                  >
+                    Reject
+                  </button>
+                </div>
+              </div>
+            ))}
+          </div>
+        )}
+        {error && <p className="text-sm text-red-600 mt-3">{error}</p>}
+      </div>
+
+      {history.length > 0 && (
+        <div>
+          <h4 className="font-semibold text-gray-700 mb-2">Recently Reviewed</h4>
+          <div className="space-y-2">
+            {history.map(release => (
+              <div key={release.id} className="p-3 border rounded-lg">
+                <div className="flex items-center justify-between">
+                  <p className="font-medium text-gray-900">{release.team_name}: {release.player_name}</p>
+                  <span className={`text-xs font-medium rounded px-2 py-0.5 ${
+                    release.status === 'approved' ? 'bg-green-100 text-green-800' : 'bg-red-100 text-red-800'
+                  }`}>
+                    {release.status}
+                  </span>
+                </div>
+                {release.status === 'approved' && (
+                  <p className="text-sm text-gray-600">
+                    Refunded {release.refund_amount} pts ({release.refund_percent}% of {release.price})
+                    {release.replacement_player_id
+                      ? ` • replaced by ${release.replacement_name} for ${release.replacement_price} pts`
+                      : ' • replacement slot open'}
+                  </p>
+                )}
+                {release.review_reason && <p className="text-xs text-gray-500 mt-1">{release.review_reason}</p>}
+              </div>
+            ))}
+          </div>
+        </div>
+      )}
+    </div>
+  )
+}
@@ -0,0 +1,132 @@
+'use client'
+
+import { useCallback, useEffect, useState } from 'react'
+import { ArrowLeftRight } from 'lucide-react'
+import { adminAPI, Trade } from '@/lib/api'
+import { useWebSocket } from '@/lib/websocket'
+
+interface TradeReviewProps {
+  onSettled: () => void
+}
+
+export default function TradeReview({ onSettled }: TradeReviewProps) {
+  const [trades, setTrades] = useState<Trade[]>([])
+  const [reasons, setReasons] = useState<Record<string, string>>({})
+  const [error, setError] = useState<string | null>(null)
+
+  const fetchTrades = useCallback(async () => {
+    try {
+      setTrades(await adminAPI.getTrades())
+    } catch (error) {
+      console.error('Error fetching trades:', error)
+    }
+  }, [])
+
+  useEffect(() => {
+    fetchTrades()
+  }, [fetchTrades])
+
+  useWebSocket({
+    onMessage: useCallback((message: any) => {
+      if (message.type === 'trade_updated') {
+        fetchTrades()
+      }
+    }, [fetchTrades])
+  })
+
+  const review = async (trade: Trade, approved: boolean) => {
+    setError(null)
+    try {
+      await adminAPI.reviewTrade(trade.id, approved, reasons[trade.id])
+      fetchTrades()
+      if (approved) {
+        onSettled()
+      }
+    } catch (error: any) {
+      setError(error.response?.data?.error || 'Failed to review trade')
+    }
+  }
+
+  const pending = trades.filter(trade => trade.status === 'accepted')
+  const history = trades.filter(trade => trade.status === 'completed' || trade.status === 'rejected').slice(0, 10)
+
+  const describe = (trade: Trade) => (
+    <ul className="text-sm text-gray-600 space-y-0.5">
+      {trade.items.map(item => (
+        <li key={item.id}>
+          {item.player_name} ({item.price} pts) to {item.to_team_id === trade.receiver_team_id ? trade.receiver_team_name : trade.proposer_team_name}
+        </li>
+      ))}
+      {trade.points !== 0 && (
+        <li>
+          {trade.points > 0 ? trade.proposer_team_name : trade.receiver_team_name} pays {Math.abs(trade.points)} pts
+        </li>
+      )}
+    </ul>
+  )
+
+  return (
+    <div className="bg-white rounded-xl shadow-lg p-6 space-y-6">
+      <h3 className="text-xl font-bold text-gray-900 flex items-center">
+        <ArrowLeftRight className="h-5 w-5 mr-2" />
+        Trades
+      </h3>
+
+      <div>
+        <h4 className="font-semibold text-gray-700 mb-2">Awaiting Approval</h4>
+        {pending.length === 0 ? (
+          <p className="text-sm text-gray-500">No trades are waiting for review.</p>
+        ) : (
+          <div className="space-y-3">
+            {pending.map(trade => (
+              <div key={trade.id} className="p-4 bg-gray-50 rounded-lg space-y-2">
+                <p className="font-medium text-gray-900">{trade.proposer_team_name} ⇄ {trade.receiver_team_name}</p>
+                {describe(trade)}
+                {trade.note && <p className="text-xs text-gray-500">{trade.note}</p>}
+                <input
+                  value={reasons[trade.id] || ''}
+                  onChange={(e) => setReasons({ ...reasons, [trade.id]: e.target.value })}
+                  placeholder="Reason (required to reject)"
+                  className="input-field w-full"
+                />
+                <div className="flex space-x-2">
+                  <button onClick={() => review(trade, true)} className="btn-primary text-sm">Approve</button>
+                  <button
+                    onClick={() => review(trade, false)}
+                    disabled={!reasons[trade.id]?.trim()}
+                    className="btn-secondary text-sm disabled:opacity-50"
+                  >
+                    Reject
+                  </button>
+                </div>
+              </div>
+            ))}
+          </div>
+        )}
+        {error && <p className="text-sm text-red-600 mt-3">{error}</p>}
+      </div>
+
+      {history.length > 0 && (
+        <div>
+          <h4 className="font-semibold text-gray-700 mb-2">Recently Reviewed</h4>
+          <div className="space-y-2">
+            {history.map(trade => (
+              <div key={trade.id} className="p-3 border rounded-lg">
+                <div className="flex items-center justify-between">
+                  <p className="font-medium text-gray-900">{trade.proposer_team_name} ⇄ {trade.receiver_team_name}</p>
+                  <span className={`text-xs font-medium rounded px-2 py-0.5 ${
+                    trade.status === 'completed' ? 'bg-green-100 text-green-800' : 'bg-red-100 text-red-800'
+                  }`}>
+                    {trade.status}
+                  </span>
+                </div>
+                {describe(trade)}
+                {trade.review_reason && <p className="text-xs text-gray-500 mt-1">{trade.review_reason}</p>}
+              </div>
+            ))}
+          </div>
+        </div>
+      )}
+    </div>
+  )
+}
@@ -21,7 +21,12 @@ import {
 import { useWebSocket } from '@/lib/websocket'
 import { adminAPI, generalAPI, Player } from '@/lib/api'
 import PlayerSeeder from './components/PlayerSeeder'
+import PlayerImporter from './components/PlayerImporter'
 import AuctionManager from './components/AuctionManager'
+import ExportMenu from './components/ExportMenu'
+import AuctionAnalytics from './components/AuctionAnalytics'
+import TradeReview from './components/TradeReview'
+import ReleaseReview from './components/ReleaseReview'
 import AuthGuard from '@/components/AuthGuard'
 
 // Player Categories View Component
@@ -1019,6 +1024,8 @@ function AdminDashboardContent() {
                 </div>
               </div>
             </div>
+
+            <AuctionAnalytics />
           </div>
         )}
 
@@ -1026,6 +1033,7 @@ function AdminDashboardContent() {
           <div className="space-y-6">
             <div className="flex justify-between items-center">
               <h2 className="text-2xl font-bold text-gray-900">Auction Management</h2>
+              <ExportMenu />
             </div>
 
             <AuctionManager />
@@ -1093,6 +1101,10 @@ function AdminDashboardContent() {
                 </div>
               ))}
             </div>
+
+            <TradeReview onSettled={fetchDashboardData} />
+
+            <ReleaseReview onSettled={fetchDashboardData} />
           </div>
         )}
 
@@ -1100,7 +1112,10 @@ function AdminDashboardContent() {
           <div className="space-y-6">
             <div className="flex justify-between items-center">
               <h2 className="text-2xl font-bold text-gray-900">Player Management</h2>
-              <PlayerSeeder onPlayerAdded={fetchDashboardData} />
+              <div className="flex space-x-2">
+                <PlayerImporter onPlayersImported={fetchDashboardData} />
+                <PlayerSeeder onPlayerAdded={fetchDashboardData} />
+              </div>
             </div>
 
             <PlayerCategoriesView onAssignPlayerToTeam={handleAssignPlayerToTeamFromPlayerCard} />
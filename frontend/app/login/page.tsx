@@ -11,6 +11,9 @@ export default function LoginPage() {
   const [showPassword, setShowPassword] = useState(false)
   const [isLoading, setIsLoading] = useState(false)
   const [error, setError] = useState('')
+  // Players create their own account; admins and teams are set up by an admin
+  const [isSignUp, setIsSignUp] = useState(false)
+  const [username, setUsername] = useState('')
   const router = useRouter()
 
   // Check if user is already authenticated
@@ -24,6 +27,8 @@ export default function LoginPage() {
         router.push('/admin')
       } else if (userRole === 'team') {
         router.push('/team')
+      } else if (userRole === 'player') {
+        router.push('/player')
       }
     }
   }, [router])
@@ -34,6 +39,9 @@ export default function LoginPage() {
     setError('')
 
     try {
+      if (isSignUp) {
+        await authAPI.register(username, email, password, 'player')
+      }
       const response = await authAPI.login(email, password)
       
       if (response.success) {
@@ -47,6 +55,8 @@ export default function LoginPage() {
           router.push('/admin')
         } else if (response.data.user.role === 'team') {
           router.push('/team')
+        } else if (response.data.user.role === 'player') {
+          router.push('/player')
         } else {
           router.push('/')
         }
@@ -67,16 +77,37 @@ export default function LoginPage() {
           <Trophy className="h-12 w-12 text-primary-600" />
         </div>
         <h2 className="mt-6 text-center text-3xl font-extrabold text-gray-900">
-          Sign in to your account
+          {isSignUp ? 'Register as a player' : 'Sign in to your account'}
         </h2>
         <p className="mt-2 text-center text-sm text-gray-600">
-          Access your auction dashboard
+          {isSignUp ? 'Create an account, then submit your profile for review' : 'Access your auction dashboard'}
         </p>
       </div>
 
       <div className="mt-8 sm:mx-auto sm:w-full sm:max-w-md">
         <div className="bg-white py-8 px-4 shadow sm:rounded-lg sm:px-10">
           <form className="space-y-6" onSubmit={handleSubmit}>
+            {isSignUp && (
+              <div>
+                <label htmlFor="username" className="block text-sm font-medium text-gray-700">
+                  Username
+                </label>
+                <div className="mt-1">
+                  <input
+                    id="username"
+                    name="username"
+                    type="text"
+                    autoComplete="username"
+                    required
+                    value={username}
+                    onChange={(e) => setUsername(e.target.value)}
+                    className="input-field"
+                    placeholder="Choose a username"
+                  />
+                </div>
+              </div>
+            )}
+
             <div>
               <label htmlFor="email" className="block text-sm font-medium text-gray-700">
                 Email address
@@ -138,11 +169,24 @@ export default function LoginPage() {
                 disabled={isLoading}
                 className="btn-primary w-full flex justify-center py-2 px-4 border border-transparent rounded-md shadow-sm text-sm font-medium text-white bg-primary-600 hover:bg-primary-700 focus:outline-none focus:ring-2 focus:ring-offset-2 focus:ring-primary-500 disabled:opacity-50 disabled:cursor-not-allowed"
               >
-                {isLoading ? 'Signing in...' : 'Sign in'}
+                {isLoading ? 'Signing in...' : isSignUp ? 'Create account' : 'Sign in'}
               </button>
             </div>
           </form>
 
+          <p className="mt-6 text-center text-sm text-gray-600">
+            <button
+              type="button"
+              className="font-medium text-primary-600 hover:text-primary-500"
+              onClick={() => {
+                setIsSignUp(!isSignUp)
+                setError('')
+              }}
+            >
+              {isSignUp ? 'Already have an account? Sign in' : 'New player? Register here'}
+            </button>
+          </p>
+
 
         </div>
       </div>
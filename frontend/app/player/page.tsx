@@ -0,0 +1,217 @@
+'use client'
+
+import { useState, useEffect } from 'react'
+import { LogOut, Trophy } from 'lucide-react'
+import AuthGuard from '@/components/AuthGuard'
+import { playerAPI, Player, PlayerOutcome, PlayerProfile } from '@/lib/api'
+
+const emptyProfile: PlayerProfile = {
+  name: '',
+  gender: 'male',
+  date_of_birth: '',
+  mobile: '',
+  playing_category: 'singles',
+  accomplishments: '',
+}
+
+const outcomeMessages: Record<PlayerOutcome['status'], string> = {
+  pending: 'Your registration is waiting for review.',
+  rejected: 'Your registration was not approved. Correct your profile and submit it again.',
+  in_pool: 'You are approved and in the auction pool.',
+  up_for_bidding: 'You are up for bidding right now!',
+  sold: 'You have been sold.',
+  retained: 'You have been retained.',
+}
+
+function PlayerPortal() {
+  const [player, setPlayer] = useState<Player | null>(null)
+  const [outcome, setOutcome] = useState<PlayerOutcome | null>(null)
+  const [formData, setFormData] = useState<PlayerProfile>(emptyProfile)
+  const [isLoading, setIsLoading] = useState(false)
+  const [error, setError] = useState('')
+
+  const loadProfile = async () => {
+    try {
+      const profile = await playerAPI.getProfile()
+      setPlayer(profile)
+      setFormData({
+        name: profile.name,
+        gender: profile.gender,
+        date_of_birth: profile.date_of_birth?.slice(0, 10) ?? '',
+        mobile: profile.mobile ?? '',
+        playing_category: profile.playing_category,
+        accomplishments: profile.accomplishments,
+      })
+      setOutcome(await playerAPI.getOutcome())
+    } catch (error: any) {
+      // 404 means no profile has been submitted yet
+      if (error.response?.status !== 404) {
+        setError(error.response?.data?.error || 'Failed to load profile')
+      }
+    }
+  }
+
+  useEffect(() => {
+    loadProfile()
+  }, [])
+
+  const handleInputChange = (field: keyof PlayerProfile, value: string) => {
+    setFormData(prev => ({ ...prev, [field]: value }))
+  }
+
+  const handleSubmit = async (e: React.FormEvent) => {
+    e.preventDefault()
+    setIsLoading(true)
+    setError('')
+    try {
+      await playerAPI.submitProfile(formData)
+      await loadProfile()
+    } catch (error: any) {
+      setError(error.response?.data?.error || 'Failed to submit profile')
+    } finally {
+      setIsLoading(false)
+    }
+  }
+
+  const handleLogout = () => {
+    localStorage.removeItem('auth_token')
+    localStorage.removeItem('user_role')
+    localStorage.removeItem('user_id')
+    window.location.href = '/login'
+  }
+
+  const canEdit = !player || player.registration_status !== 'approved'
+
+  return (
+    <div className="min-h-screen bg-gray-50">
+      <header className="bg-white shadow-sm border-b">
+        <div className="max-w-3xl mx-auto px-4 py-4 flex items-center justify-between">
+          <div className="flex items-center">
+            <Trophy className="h-6 w-6 text-primary-600 mr-2" />
+            <h1 className="text-xl font-bold text-gray-900">Player Portal</h1>
+          </div>
+          <button
+            onClick={handleLogout}
+            className="btn-secondary flex items-center text-red-600 hover:text-red-700 hover:bg-red-50"
+          >
+            <LogOut className="h-4 w-4 mr-2" />
+            Logout
+          </button>
+        </div>
+      </header>
+
+      <main className="max-w-3xl mx-auto px-4 py-8 space-y-6">
+        {outcome && (
+          <div className="card">
+            <h2 className="text-lg font-semibold text-gray-900 mb-2">Auction Status</h2>
+            <p className="text-gray-700">{outcomeMessages[outcome.status]}</p>
+            {outcome.review_reason && (
+              <p className="text-sm text-gray-500 mt-2">Reviewer note: {outcome.review_reason}</p>
+            )}
+            {outcome.team && (
+              <p className="text-sm text-gray-700 mt-2">
+                Team: <span className="font-medium">{outcome.team.name}</span> • ₹{outcome.price}
+              </p>
+            )}
+          </div>
+        )}
+
+        <div className="card">
+          <h2 className="text-lg font-semibold text-gray-900 mb-4">
+            {player ? 'Your Profile' : 'Register for the Auction'}
+          </h2>
+
+          {error && <p className="text-sm text-red-600 mb-4">{error}</p>}
+
+          <form onSubmit={handleSubmit} className="space-y-4">
+            <fieldset disabled={!canEdit} className="space-y-4">
+              <div>
+                <label className="block text-sm font-medium text-gray-700 mb-1">Name *</label>
+                <input
+                  type="text"
+                  required
+                  value={formData.name}
+                  onChange={(e) => handleInputChange('name', e.target.value)}
+                  className="input-field"
+                />
+              </div>
+
+              <div>
+                <label className="block text-sm font-medium text-gray-700 mb-1">Gender *</label>
+                <select
+                  value={formData.gender}
+                  onChange={(e) => handleInputChange('gender', e.target.value)}
+                  className="input-field"
+                >
+                  <option value="male">Male</option>
+                  <option value="female">Female</option>
+                </select>
+              </div>
+
+              <div>
+                <label className="block text-sm font-medium text-gray-700 mb-1">Date of Birth *</label>
+                <input
+                  type="date"
+                  required
+                  value={formData.date_of_birth}
+                  onChange={(e) => handleInputChange('date_of_birth', e.target.value)}
+                  className="input-field"
+                />
+              </div>
+
+              <div>
+                <label className="block text-sm font-medium text-gray-700 mb-1">Mobile *</label>
+                <input
+                  type="tel"
+                  required
+                  value={formData.mobile}
+                  onChange={(e) => handleInputChange('mobile', e.target.value)}
+                  className="input-field"
+                />
+              </div>
+
+              <div>
+                <label className="block text-sm font-medium text-gray-700 mb-1">Playing Category *</label>
+                <select
+                  value={formData.playing_category}
+                  onChange={(e) => handleInputChange('playing_category', e.target.value)}
+                  className="input-field"
+                >
+                  <option value="singles">Singles</option>
+                  <option value="doubles">Doubles</option>
+                  <option value="both">Both</option>
+                </select>
+              </div>
+
+              <div>
+                <label className="block text-sm font-medium text-gray-700 mb-1">Accomplishments</label>
+                <textarea
+                  value={formData.accomplishments}
+                  onChange={(e) => handleInputChange('accomplishments', e.target.value)}
+                  className="input-field"
+                  rows={3}
+                />
+              </div>
+            </fieldset>
+
+            {canEdit ? (
+              <button type="submit" disabled={isLoading} className="btn-primary w-full">
+                {isLoading ? 'Submitting...' : player ? 'Resubmit for Review' : 'Submit for Review'}
+              </button>
+            ) : (
+              <p className="text-sm text-gray-500">Your profile is approved. Contact an admin to change it.</p>
+            )}
+          </form>
+        </div>
+      </main>
+    </div>
+  )
+}
+
+export default function PlayerPage() {
+  return (
+    <AuthGuard requiredRole="player">
+      <PlayerPortal />
+    </AuthGuard>
+  )
+}
@@ -0,0 +1,112 @@
+'use client'
+
+import { useEffect, useState } from 'react'
+import { Calculator } from 'lucide-react'
+import { teamAPI, BudgetPlan } from '@/lib/api'
+
+export default function BudgetPlanner() {
+  const [plan, setPlan] = useState<BudgetPlan | null>(null)
+  const [whatIf, setWhatIf] = useState('')
+  const [error, setError] = useState('')
+
+  const loadPlan = async (prices: number[] = []) => {
+    try {
+      setError('')
+      setPlan(await teamAPI.getBudgetPlan(prices))
+    } catch (err: any) {
+      setError(err.response?.data?.error || 'Failed to load budget plan')
+    }
+  }
+
+  useEffect(() => {
+    loadPlan()
+  }, [])
+
+  const project = () => {
+    const prices = whatIf.split(',').map(value => parseInt(value.trim(), 10)).filter(value => !isNaN(value))
+    loadPlan(prices)
+  }
+
+  if (!plan) {
+    return error ? <div className="card text-red-600">{error}</div> : null
+  }
+
+  return (
+    <div className="card">
+      <h3 className="text-lg font-semibold text-gray-900 mb-4 flex items-center">
+        <Calculator className="h-5 w-5 mr-2" />
+        Bid Planner
+      </h3>
+
+      <div className="grid grid-cols-1 md:grid-cols-3 gap-4 mb-6">
+        <div className="bg-gray-50 rounded-lg p-4">
+          <h4 className="font-medium text-gray-900 mb-2">Max Bid Now</h4>
+          <p className="text-2xl font-bold text-primary-600">{Math.max(0, plan.max_bid).toLocaleString()}</p>
+          {plan.current_lot && (
+            <p className="text-sm text-gray-500">
+              Next bid {plan.current_lot.minimum_bid.toLocaleString()}:{' '}
+              {plan.current_lot.winning
+                ? 'you are winning'
+                : plan.current_lot.can_bid
+                  ? 'affordable'
+                  : plan.current_lot.reason === 'no_replacement_slot'
+                    ? 'no open replacement slot'
+                    : 'over your limit'}
+            </p>
+          )}
+        </div>
+        <div className="bg-gray-50 rounded-lg p-4">
+          <h4 className="font-medium text-gray-900 mb-2">Reserved</h4>
+          <p className="text-2xl font-bold text-orange-600">{plan.reserved_points.toLocaleString()}</p>
+          <p className="text-sm text-gray-500">
+            {plan.unfilled_slots} slots x {plan.reserved_per_slot} points
+          </p>
+        </div>
+        <div className="bg-gray-50 rounded-lg p-4">
+          <h4 className="font-medium text-gray-900 mb-2">Squad</h4>
+          <p className="text-2xl font-bold text-green-600">{plan.player_count}/{plan.min_players}</p>
+          <p className="text-sm text-gray-500">minimum players</p>
+        </div>
+      </div>
+
+      <div className="flex space-x-2 mb-4">
+        <input
+          type="text"
+          value={whatIf}
+          onChange={(e) => setWhatIf(e.target.value)}
+          placeholder="What if I buy at... e.g. 1500, 800"
+          className="input-field flex-1"
+        />
+        <button onClick={project} className="btn-primary">Project</button>
+      </div>
+      {error && <p className="text-sm text-red-600 mb-2">{error}</p>}
+
+      {plan.projections.length > 0 && (
+        <table className="w-full text-sm">
+          <thead>
+            <tr className="text-left text-gray-500">
+              <th>Buy at</th><th className="text-right">Remaining</th><th className="text-right">Squad</th>
+              <th className="text-right">Max next bid</th>
+            </tr>
+          </thead>
+          <tbody>
+            {plan.projections.map((projection, index) => (
+              <tr key={index} className={`border-t ${projection.allowed ? '' : 'text-red-600'}`}>
+                <td className="py-1">{projection.price.toLocaleString()}</td>
+                {projection.allowed ? (
+                  <>
+                    <td className="text-right">{projection.remaining_points.toLocaleString()}</td>
+                    <td className="text-right">{projection.player_count}</td>
+                    <td className="text-right">{Math.max(0, projection.max_bid).toLocaleString()}</td>
+                  </>
+                ) : (
+                  <td colSpan={3} className="text-right">{projection.error}</td>
+                )}
+              </tr>
+            ))}
+          </tbody>
+        </table>
+      )}
+    </div>
+  )
+}
@@ -2,7 +2,7 @@
 
 import { useState, useEffect } from 'react'
 import { Gavel, DollarSign, Users, Clock, TrendingUp, AlertCircle, Trophy } from 'lucide-react'
-import { teamAPI } from '@/lib/api'
+import { teamAPI, playerAgeLabel, mediaURL } from '@/lib/api'
 import { useWebSocket } from '@/lib/websocket'
 import PlayerProfile from '../../admin/components/PlayerProfile'
 
@@ -16,12 +16,14 @@ interface Player {
   id: string
   name: string
   gender: string
-  age: number
+  age?: number
+  player_category?: string
   playing_category: string
   accomplishments: string
   base_price: number
   current_price?: number
   is_sold: boolean
+  photo_card_url?: string
   current_team_id?: string
   current_team?: {
     id: string
@@ -58,6 +60,7 @@ export default function LiveAuction({ teamId, dashboard, remainingPoints: propRe
   const [bidAmount, setBidAmount] = useState(0)
   const [isBidding, setIsBidding] = useState(false)
   const [remainingPoints, setRemainingPoints] = useState(propRemainingPoints || 0)
+  const [watchAlert, setWatchAlert] = useState<{ player_id: string; priority: number; target_price: number; notes: string } | null>(null)
 
   // WebSocket connection for real-time updates
   const { sendMessage, isConnected } = useWebSocket({
@@ -69,8 +72,13 @@ export default function LiveAuction({ teamId, dashboard, remainingPoints: propRe
         case 'auction_updated':
         case 'next_player':
         case 'auction_completed':
+        case 'resync_required':
           fetchCurrentAuction()
           break
+        case 'watched_player_up':
+          // Sent only to this team, when a player on its watchlist comes up
+          setWatchAlert({ ...message.data, player_id: message.data.player.id })
+          break
         case 'new_bid':
         case 'bid_placed':
           if (currentAuction) {
@@ -88,6 +96,14 @@ export default function LiveAuction({ teamId, dashboard, remainingPoints: propRe
     }
   })
 
+  // Bids and player changes are only sent to clients subscribed to the auction's room
+  useEffect(() => {
+    if (!isConnected || !currentAuction) return
+    const room = `auction:${currentAuction.id}`
+    sendMessage({ type: 'subscribe', data: { room } })
+    return () => sendMessage({ type: 'unsubscribe', data: { room } })
+  }, [isConnected, currentAuction?.id])
+
   useEffect(() => {
     fetchCurrentAuction()
   }, [])
@@ -285,6 +301,14 @@ export default function LiveAuction({ teamId, dashboard, remainingPoints: propRe
         </div>
       </div>
 
+      {watchAlert && watchAlert.player_id === currentAuction.current_player?.id && (
+        <div className="mb-4 p-4 bg-yellow-50 border border-yellow-300 rounded-lg text-sm text-yellow-900">
+          <span className="font-semibold">On your watchlist (priority {watchAlert.priority})</span>
+          {watchAlert.target_price > 0 && <span> · target {watchAlert.target_price.toLocaleString()} pts</span>}
+          {watchAlert.notes && <p className="mt-1">{watchAlert.notes}</p>}
+        </div>
+      )}
+
       {/* Main Content */}
       <div className="flex-1 grid grid-cols-1 lg:grid-cols-3 gap-6">
         {/* Left Column - Player Info */}
@@ -295,17 +319,25 @@ export default function LiveAuction({ teamId, dashboard, remainingPoints: propRe
                           <div className="absolute inset-0 bg-gradient-to-br from-transparent via-white to-transparent opacity-10 animate-pulse" style={{ animationDuration: '4s' }}></div>
                           <div className="relative z-10 flex items-center justify-between">
                 <div className="flex items-center space-x-4">
-                  <div className="w-16 h-16 bg-white bg-opacity-20 rounded-full flex items-center justify-center">
-                    <span className="text-2xl font-bold">
-                      {currentAuction.current_player.name.split(' ').map(n => n[0]).join('')}
-                    </span>
-                  </div>
+                  {currentAuction.current_player.photo_card_url ? (
+                    <img
+                      src={mediaURL(currentAuction.current_player.photo_card_url)}
+                      alt={currentAuction.current_player.name}
+                      className="w-16 h-20 rounded-lg object-cover border-2 border-white border-opacity-40"
+                    />
+                  ) : (
+                    <div className="w-16 h-16 bg-white bg-opacity-20 rounded-full flex items-center justify-center">
+                      <span className="text-2xl font-bold">
+                        {currentAuction.current_player.name.split(' ').map(n => n[0]).join('')}
+                      </span>
+                    </div>
+                  )}
                   <div>
                     <h2 className="text-2xl font-bold">{currentAuction.current_player.name}</h2>
                     <div className="flex items-center space-x-4 text-sm opacity-90 mt-1">
                       <span className="flex items-center">
                         <Clock className="h-4 w-4 mr-2" />
-                        {currentAuction.current_player.age} years
+                        {playerAgeLabel(currentAuction.current_player)}
                       </span>
                       <span className="flex items-center">
                         <Users className="h-4 w-4 mr-2" />
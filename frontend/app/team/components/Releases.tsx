@@ -0,0 +1,163 @@
+'use client'
+
+import { useCallback, useEffect, useState } from 'react'
+import { UserMinus } from 'lucide-react'
+import { teamAPI, Player, Release } from '@/lib/api'
+import { useWebSocket } from '@/lib/websocket'
+
+type RosterPlayer = Pick<Player, 'id' | 'name' | 'current_price'>
+
+interface ReleasesProps {
+  roster: RosterPlayer[]
+  onChange: () => void
+}
+
+const statusStyles: Record<string, string> = {
+  requested: 'bg-blue-100 text-blue-800',
+  approved: 'bg-green-100 text-green-800',
+  rejected: 'bg-red-100 text-red-800',
+}
+
+// Replacements are picked at the player's base price, never below the 200 point minimum
+const pickPrice = (player: Player) => Math.max(player.base_price, 200)
+
+export default function Releases({ roster, onChange }: ReleasesProps) {
+  const [releases, setReleases] = useState<Release[]>([])
+  const [pool, setPool] = useState<Player[]>([])
+  const [playerId, setPlayerId] = useState('')
+  const [reason, setReason] = useState('')
+  const [picks, setPicks] = useState<Record<string, string>>({})
+  const [error, setError] = useState<string | null>(null)
+
+  const fetchReleases = useCallback(async () => {
+    try {
+      const [releasesData, poolData] = await Promise.all([
+        teamAPI.getReleases(),
+        teamAPI.getReplacementPool(),
+      ])
+      setReleases(releasesData)
+      setPool(poolData)
+    } catch (error) {
+      console.error('Error fetching releases:', error)
+    }
+  }, [])
+
+  useEffect(() => {
+    fetchReleases()
+  }, [fetchReleases])
+
+  useWebSocket({
+    onMessage: useCallback((message: any) => {
+      if (message.type === 'release_updated') {
+        fetchReleases()
+        onChange()
+      }
+    }, [fetchReleases, onChange])
+  })
+
+  const requestRelease = async () => {
+    setError(null)
+    try {
+      await teamAPI.requestRelease(playerId, reason)
+      setPlayerId('')
+      setReason('')
+      fetchReleases()
+    } catch (error: any) {
+      setError(error.response?.data?.error || 'Failed to request release')
+    }
+  }
+
+  const pick = async (releaseId: string) => {
+    setError(null)
+    try {
+      await teamAPI.pickReplacement(releaseId, picks[releaseId])
+      fetchReleases()
+      onChange()
+    } catch (error: any) {
+      setError(error.response?.data?.error || 'Failed to pick replacement')
+    }
+  }
+
+  return (
+    <div className="card">
+      <h3 className="text-lg font-semibold text-gray-900 mb-4 flex items-center">
+        <UserMinus className="h-5 w-5 mr-2 text-red-500" />
+        Releases & Replacements
+      </h3>
+      <p className="text-sm text-gray-500 mb-4">
+        Ask the admins to release a player, for example after an injury. If approved, you get part of the price back and an open slot to sign a replacement, in a replacement auction or by picking a free player at their base price.
+      </p>
+
+      <div className="flex flex-col md:flex-row gap-2 mb-4">
+        <select value={playerId} onChange={(e) => setPlayerId(e.target.value)} className="input-field md:w-64">
+          <option value="">Choose a player to release</option>
+          {roster.map(player => (
+            <option key={player.id} value={player.id}>{player.name} ({player.current_price} pts)</option>
+          ))}
+        </select>
+        <input
+          value={reason}
+          onChange={(e) => setReason(e.target.value)}
+          placeholder="Reason, e.g. injury"
+          className="input-field flex-1"
+        />
+        <button
+          onClick={requestRelease}
+          disabled={!playerId || !reason.trim()}
+          className="btn-secondary disabled:opacity-50"
+        >
+          Request Release
+        </button>
+      </div>
+      {error && <p className="text-sm text-red-600 mb-3">{error}</p>}
+
+      {releases.length > 0 && (
+        <div className="space-y-3">
+          {releases.map(release => (
+            <div key={release.id} className="p-3 bg-gray-50 rounded-lg">
+              <div className="flex items-center justify-between">
+                <p className="font-medium text-gray-900">{release.player_name}</p>
+                <span className={`text-xs font-medium rounded px-2 py-0.5 ${statusStyles[release.status]}`}>
+                  {release.status}
+                </span>
+              </div>
+              <p className="text-sm text-gray-500">{release.reason}</p>
+              {release.status === 'approved' && (
+                <p className="text-sm text-gray-600">
+                  Refunded {release.refund_amount} pts ({release.refund_percent}% of {release.price})
+                </p>
+              )}
+              {release.review_reason && <p className="text-xs text-gray-500 mt-1">Admin: {release.review_reason}</p>}
+              {release.status === 'approved' && release.replacement_player_id && (
+                <p className="text-sm text-green-700 mt-1">
+                  Replaced by {release.replacement_name} for {release.replacement_price} pts
+                </p>
+              )}
+              {release.status === 'approved' && !release.replacement_player_id && (
+                <div className="flex gap-2 mt-2">
+                  <select
+                    value={picks[release.id] || ''}
+                    onChange={(e) => setPicks({ ...picks, [release.id]: e.target.value })}
+                    className="input-field flex-1"
+                  >
+                    <option value="">Pick a replacement</option>
+                    {pool.filter(player => player.id !== release.player_id).map(player => (
+                      <option key={player.id} value={player.id}>{player.name} ({pickPrice(player)} pts)</option>
+                    ))}
+                  </select>
+                  <button
+                    onClick={() => pick(release.id)}
+                    disabled={!picks[release.id]}
+                    className="btn-primary text-sm disabled:opacity-50"
+                  >
+                    Sign
+                  </button>
+                </div>
+              )}
+            </div>
+          ))}
+        </div>
+      )}
+    </div>
+  )
+}
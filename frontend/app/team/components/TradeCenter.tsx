@@ -0,0 +1,233 @@
+'use client'
+
+import { useCallback, useEffect, useState } from 'react'
+import { ArrowLeftRight } from 'lucide-react'
+import { teamAPI, adminAPI, generalAPI, Player, Team, Trade } from '@/lib/api'
+import { useWebSocket } from '@/lib/websocket'
+
+type TradePlayer = Pick<Player, 'id' | 'name' | 'current_price'>
+
+interface TradeCenterProps {
+  teamId: string
+  roster: TradePlayer[]
+  onChange: () => void
+}
+
+const statusStyles: Record<string, string> = {
+  proposed: 'bg-blue-100 text-blue-800',
+  accepted: 'bg-yellow-100 text-yellow-800',
+  completed: 'bg-green-100 text-green-800',
+  declined: 'bg-gray-100 text-gray-700',
+  cancelled: 'bg-gray-100 text-gray-700',
+  rejected: 'bg-red-100 text-red-800',
+}
+
+export default function TradeCenter({ teamId, roster, onChange }: TradeCenterProps) {
+  const [trades, setTrades] = useState<Trade[]>([])
+  const [teams, setTeams] = useState<Team[]>([])
+  const [partnerId, setPartnerId] = useState('')
+  const [partnerPlayers, setPartnerPlayers] = useState<Player[]>([])
+  const [offered, setOffered] = useState<string[]>([])
+  const [requested, setRequested] = useState<string[]>([])
+  const [points, setPoints] = useState(0)
+  const [note, setNote] = useState('')
+  const [error, setError] = useState<string | null>(null)
+
+  const fetchTrades = useCallback(async () => {
+    try {
+      setTrades(await teamAPI.getTrades())
+    } catch (error) {
+      console.error('Error fetching trades:', error)
+    }
+  }, [])
+
+  useEffect(() => {
+    fetchTrades()
+    generalAPI.getTeams()
+      .then(all => setTeams(all.filter(team => team.id !== teamId)))
+      .catch(error => console.error('Error fetching teams:', error))
+  }, [fetchTrades, teamId])
+
+  useEffect(() => {
+    setRequested([])
+    if (!partnerId) {
+      setPartnerPlayers([])
+      return
+    }
+    adminAPI.getTeamPlayers(partnerId)
+      .then(setPartnerPlayers)
+      .catch(error => console.error('Error fetching team players:', error))
+  }, [partnerId])
+
+  useWebSocket({
+    onMessage: useCallback((message: any) => {
+      if (message.type === 'trade_updated') {
+        fetchTrades()
+        if (message.data?.status === 'completed') {
+          onChange()
+        }
+      }
+    }, [fetchTrades, onChange])
+  })
+
+  const toggle = (ids: string[], setIds: (ids: string[]) => void, id: string) =>
+    setIds(ids.includes(id) ? ids.filter(x => x !== id) : [...ids, id])
+
+  const propose = async () => {
+    setError(null)
+    try {
+      await teamAPI.proposeTrade({
+        receiver_team_id: partnerId,
+        offered_player_ids: offered,
+        requested_player_ids: requested,
+        points,
+        note,
+      })
+      setOffered([])
+      setRequested([])
+      setPoints(0)
+      setNote('')
+      fetchTrades()
+    } catch (error: any) {
+      setError(error.response?.data?.error || 'Failed to propose trade')
+    }
+  }
+
+  const respond = async (tradeId: string, action: 'accept' | 'decline' | 'cancel') => {
+    setError(null)
+    try {
+      await teamAPI.respondToTrade(tradeId, action)
+      fetchTrades()
+    } catch (error: any) {
+      setError(error.response?.data?.error || 'Failed to update trade')
+    }
+  }
+
+  const playerList = (players: TradePlayer[], selected: string[], setSelected: (ids: string[]) => void) => (
+    <div className="max-h-64 overflow-y-auto space-y-1">
+      {players.length === 0 && <p className="text-sm text-gray-500">No players</p>}
+      {players.map(player => (
+        <label key={player.id} className="flex items-center justify-between p-2 bg-gray-50 rounded cursor-pointer">
+          <span className="flex items-center text-sm text-gray-900">
+            <input
+              type="checkbox"
+              checked={selected.includes(player.id)}
+              onChange={() => toggle(selected, setSelected, player.id)}
+              className="mr-2"
+            />
+            {player.name}
+          </span>
+          <span className="text-xs text-gray-500">{player.current_price} pts</span>
+        </label>
+      ))}
+    </div>
+  )
+
+  const pointsLabel = (trade: Trade) => {
+    if (trade.points === 0) return null
+    const payer = trade.points > 0 ? trade.proposer_team_name : trade.receiver_team_name
+    return `${payer} pays ${Math.abs(trade.points)} pts`
+  }
+
+  return (
+    <div className="space-y-6">
+      <div className="card">
+        <h3 className="text-lg font-semibold text-gray-900 mb-4 flex items-center">
+          <ArrowLeftRight className="h-5 w-5 mr-2 text-primary-600" />
+          Propose a Trade
+        </h3>
+        <p className="text-sm text-gray-500 mb-4">
+          Trades open once the auction is over. The other team must accept, then an admin approves before players and points move.
+        </p>
+        <select value={partnerId} onChange={(e) => setPartnerId(e.target.value)} className="input-field w-full mb-4">
+          <option value="">Choose a team to trade with</option>
+          {teams.map(team => <option key={team.id} value={team.id}>{team.name}</option>)}
+        </select>
+        {partnerId && (
+          <div className="space-y-4">
+            <div className="grid grid-cols-1 md:grid-cols-2 gap-4">
+              <div>
+                <p className="text-sm font-medium text-gray-700 mb-2">You give</p>
+                {playerList(roster, offered, setOffered)}
+              </div>
+              <div>
+                <p className="text-sm font-medium text-gray-700 mb-2">You receive</p>
+                {playerList(partnerPlayers, requested, setRequested)}
+              </div>
+            </div>
+            <div className="flex flex-col md:flex-row md:items-center gap-2">
+              <label className="text-sm text-gray-700">Points you pay (negative if they pay you)</label>
+              <input
+                type="number"
+                value={points}
+                onChange={(e) => setPoints(parseInt(e.target.value, 10) || 0)}
+                className="input-field w-40"
+              />
+            </div>
+            <textarea
+              value={note}
+              onChange={(e) => setNote(e.target.value)}
+              placeholder="Note to the other team"
+              className="input-field w-full"
+              rows={2}
+            />
+            <button
+              onClick={propose}
+              disabled={offered.length === 0 && requested.length === 0}
+              className="btn-primary disabled:opacity-50"
+            >
+              Propose Trade
+            </button>
+          </div>
+        )}
+        {error && <p className="text-sm text-red-600 mt-3">{error}</p>}
+      </div>
+
+      <div className="card">
+        <h3 className="text-lg font-semibold text-gray-900 mb-4">Your Trades</h3>
+        {trades.length === 0 ? (
+          <p className="text-sm text-gray-500">No trades yet.</p>
+        ) : (
+          <div className="space-y-3">
+            {trades.map(trade => {
+              const received = trade.receiver_team_id === teamId
+              return (
+                <div key={trade.id} className="p-3 bg-gray-50 rounded-lg">
+                  <div className="flex items-center justify-between mb-2">
+                    <p className="font-medium text-gray-900">
+                      {trade.proposer_team_name} → {trade.receiver_team_name}
+                    </p>
+                    <span className={`text-xs font-medium rounded px-2 py-0.5 ${statusStyles[trade.status]}`}>
+                      {trade.status}
+                    </span>
+                  </div>
+                  <ul className="text-sm text-gray-600 space-y-0.5">
+                    {trade.items.map(item => (
+                      <li key={item.id}>
+                        {item.player_name} ({item.price} pts) to {item.to_team_id === trade.receiver_team_id ? trade.receiver_team_name : trade.proposer_team_name}
+                      </li>
+                    ))}
+                    {pointsLabel(trade) && <li>{pointsLabel(trade)}</li>}
+                  </ul>
+                  {trade.note && <p className="text-xs text-gray-500 mt-1">{trade.note}</p>}
+                  {trade.review_reason && <p className="text-xs text-red-600 mt-1">Admin: {trade.review_reason}</p>}
+                  <div className="flex space-x-2 mt-2">
+                    {received && trade.status === 'proposed' && (
+                      <>
+                        <button onClick={() => respond(trade.id, 'accept')} className="btn-primary text-sm">Accept</button>
+                        <button onClick={() => respond(trade.id, 'decline')} className="btn-secondary text-sm">Decline</button>
+                      </>
+                    )}
+                    {!received && (trade.status === 'proposed' || trade.status === 'accepted') && (
+                      <button onClick={() => respond(trade.id, 'cancel')} className="btn-secondary text-sm">Cancel</button>
+                    )}
+                  </div>
+                </div>
+              )
+            })}
+          </div>
+        )}
+      </div>
+    </div>
+  )
+}
@@ -0,0 +1,108 @@
+'use client'
+
+import { useState } from 'react'
+import { Star, Trash2 } from 'lucide-react'
+import { teamAPI, WatchlistItem } from '@/lib/api'
+
+interface WatchlistProps {
+  items: WatchlistItem[]
+  onChange: () => void
+}
+
+export default function Watchlist({ items, onChange }: WatchlistProps) {
+  const [editing, setEditing] = useState<string | null>(null)
+  const [draft, setDraft] = useState({ priority: 3, target_price: 0, notes: '' })
+
+  const startEdit = (item: WatchlistItem) => {
+    setEditing(item.player_id)
+    setDraft({ priority: item.priority, target_price: item.target_price, notes: item.notes })
+  }
+
+  const save = async (playerId: string) => {
+    try {
+      await teamAPI.saveWatchlistEntry(playerId, draft)
+      setEditing(null)
+      onChange()
+    } catch (error) {
+      console.error('Error saving watchlist entry:', error)
+    }
+  }
+
+  const remove = async (playerId: string) => {
+    try {
+      await teamAPI.removeWatchlistEntry(playerId)
+      onChange()
+    } catch (error) {
+      console.error('Error removing watchlist entry:', error)
+    }
+  }
+
+  return (
+    <div className="card">
+      <h3 className="text-lg font-semibold text-gray-900 mb-4 flex items-center">
+        <Star className="h-5 w-5 mr-2 text-yellow-500" />
+        Watchlist
+      </h3>
+      {items.length === 0 ? (
+        <p className="text-sm text-gray-500">Watch players from the All Players tab to track your targets.</p>
+      ) : (
+        <div className="space-y-3">
+          {items.map(item => (
+            <div key={item.id} className="p-3 bg-gray-50 rounded-lg">
+              {editing === item.player_id ? (
+                <div className="space-y-2">
+                  <p className="font-medium text-gray-900">{item.player.name}</p>
+                  <div className="flex space-x-2">
+                    <select
+                      value={draft.priority}
+                      onChange={(e) => setDraft({ ...draft, priority: parseInt(e.target.value, 10) })}
+                      className="input-field w-32"
+                    >
+                      {[1, 2, 3, 4, 5].map(p => <option key={p} value={p}>Priority {p}</option>)}
+                    </select>
+                    <input
+                      type="number"
+                      min={0}
+                      value={draft.target_price}
+                      onChange={(e) => setDraft({ ...draft, target_price: parseInt(e.target.value, 10) || 0 })}
+                      placeholder="Target price"
+                      className="input-field w-32"
+                    />
+                  </div>
+                  <textarea
+                    value={draft.notes}
+                    onChange={(e) => setDraft({ ...draft, notes: e.target.value })}
+                    placeholder="Private notes"
+                    className="input-field w-full"
+                    rows={2}
+                  />
+                  <div className="flex space-x-2">
+                    <button onClick={() => save(item.player_id)} className="btn-primary text-sm">Save</button>
+                    <button onClick={() => setEditing(null)} className="btn-secondary text-sm">Cancel</button>
+                  </div>
+                </div>
+              ) : (
+                <div className="flex items-start justify-between">
+                  <button onClick={() => startEdit(item)} className="text-left flex-1">
+                    <p className="font-medium text-gray-900">
+                      <span className="text-xs font-bold text-yellow-700 bg-yellow-100 rounded px-1.5 py-0.5 mr-2">P{item.priority}</span>
+                      {item.player.name}
+                    </p>
+                    <p className="text-sm text-gray-500">
+                      {item.player.is_sold ? `Sold for ${item.player.current_price} pts` : 'Available'}
+                      {item.target_price > 0 && ` · target ${item.target_price} pts`}
+                    </p>
+                    {item.notes && <p className="text-xs text-gray-500 mt-1">{item.notes}</p>}
+                  </button>
+                  <button onClick={() => remove(item.player_id)} className="text-gray-400 hover:text-red-600">
+                    <Trash2 className="h-4 w-4" />
+                  </button>
+                </div>
+              )}
+            </div>
+          ))}
+        </div>
+      )}
+    </div>
+  )
+}
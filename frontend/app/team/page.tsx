@@ -17,10 +17,15 @@ import {
   UserCheck,
   Calendar,
   Zap,
-  LogOut
+  LogOut,
+  ArrowLeftRight
 } from 'lucide-react'
-import { teamAPI, adminAPI, generalAPI } from '@/lib/api'
+import { teamAPI, adminAPI, generalAPI, playerAgeLabel } from '@/lib/api'
 import LiveAuction from './components/LiveAuction'
+import BudgetPlanner from './components/BudgetPlanner'
+import Watchlist from './components/Watchlist'
+import TradeCenter from './components/TradeCenter'
+import Releases from './components/Releases'
 import { useWebSocket } from '@/lib/websocket'
 import AuthGuard from '@/components/AuthGuard'
 
@@ -32,8 +37,8 @@ function TeamRosterView({ players }: { players: Player[] }) {
   // Categorize players
   const categorizedPlayers = {
     women: players.filter(player => player.gender === 'female'),
-    men_under_35: players.filter(player => player.gender === 'male' && player.age < 35),
-    men_35_plus: players.filter(player => player.gender === 'male' && player.age >= 35)
+    men_under_35: players.filter(player => player.player_category === 'men_under_35'),
+    men_35_plus: players.filter(player => player.player_category === 'men_35_plus')
   }
 
   const categories = [
@@ -158,7 +163,7 @@ function TeamRosterView({ players }: { players: Player[] }) {
                 <div className="space-y-3">
                   <div className="flex justify-between text-sm">
                     <span className="text-gray-500">Age:</span>
-                    <span className="font-medium">{player.age} years</span>
+                    <span className="font-medium">{playerAgeLabel(player)}</span>
                   </div>
                   
                   <div className="flex justify-between text-sm">
@@ -203,7 +208,8 @@ interface Player {
   id: string
   name: string
   gender: string
-  age: number
+  age?: number
+  player_category?: string
   playing_category: string
   accomplishments: string
   base_price: number
@@ -342,6 +348,22 @@ function TeamDashboardContent() {
     }
   }
 
+  const isWatched = (playerId: string) =>
+    (dashboard?.watchlist || []).some(item => item.player_id === playerId)
+
+  const toggleWatch = async (playerId: string) => {
+    try {
+      if (isWatched(playerId)) {
+        await teamAPI.removeWatchlistEntry(playerId)
+      } else {
+        await teamAPI.saveWatchlistEntry(playerId, {})
+      }
+      fetchTeamData()
+    } catch (error) {
+      console.error('Error updating watchlist:', error)
+    }
+  }
+
   const fetchAllPlayers = async () => {
     try {
       setPlayersLoading(true)
@@ -460,6 +482,7 @@ function TeamDashboardContent() {
                 { id: 'auction', name: 'Live Auction', icon: Gavel },
                 { id: 'roster', name: 'Roster', icon: Users },
                 { id: 'budget', name: 'Budget', icon: DollarSign },
+                { id: 'trades', name: 'Trades', icon: ArrowLeftRight },
                 { id: 'players', name: 'All Players', icon: Users }
               ].map((tab) => (
                 <button
@@ -623,6 +646,8 @@ function TeamDashboardContent() {
                 </div>
               </div>
             </div>
+
+            <Watchlist items={dashboard.watchlist || []} onChange={fetchTeamData} />
           </div>
         )}
 
@@ -648,6 +673,8 @@ function TeamDashboardContent() {
             </div>
 
             <TeamRosterView players={dashboard.players} />
+
+            <Releases roster={dashboard.players} onChange={fetchTeamData} />
           </div>
         )}
 
@@ -719,6 +746,15 @@ function TeamDashboardContent() {
                 </div>
               </div>
             </div>
+
+            <BudgetPlanner />
+          </div>
+        )}
+
+        {activeTab === 'trades' && (
+          <div className="space-y-6">
+            <h2 className="text-2xl font-bold text-gray-900">Trades</h2>
+            <TradeCenter teamId={dashboard.team_id} roster={dashboard.players} onChange={fetchTeamData} />
           </div>
         )}
 
@@ -760,17 +796,25 @@ function TeamDashboardContent() {
                         <h3 className="text-lg font-semibold text-gray-900">{player.name}</h3>
                         <p className="text-sm text-gray-500">{player.playing_category}</p>
                       </div>
-                      <div className="text-right">
+                      <div className="text-right space-y-1">
                         <span className={`badge ${player.is_sold ? 'badge-success' : 'badge-warning'}`}>
                           {player.is_sold ? 'Sold' : 'Available'}
                         </span>
+                        {!player.is_sold && (
+                          <button
+                            onClick={() => toggleWatch(player.id)}
+                            className="block ml-auto text-xs text-yellow-700 hover:underline"
+                          >
+                            {isWatched(player.id) ? 'Unwatch' : 'Watch'}
+                          </button>
+                        )}
                       </div>
                     </div>
                     
                     <div className="space-y-2 text-sm">
                       <div className="flex justify-between">
                         <span className="text-gray-500">Age:</span>
-                        <span className="font-medium">{player.age} years</span>
+                        <span className="font-medium">{playerAgeLabel(player)}</span>
                       </div>
                       <div className="flex justify-between">
                         <span className="text-gray-500">Gender:</span>
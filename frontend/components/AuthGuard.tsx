@@ -5,7 +5,7 @@ import { useRouter } from 'next/navigation'
 
 interface AuthGuardProps {
   children: React.ReactNode
-  requiredRole?: 'admin' | 'team'
+  requiredRole?: 'admin' | 'team' | 'player'
 }
 
 export default function AuthGuard({ children, requiredRole }: AuthGuardProps) {
@@ -30,6 +30,8 @@ export default function AuthGuard({ children, requiredRole }: AuthGuardProps) {
           router.push('/admin')
         } else if (userRole === 'team') {
           router.push('/team')
+        } else if (userRole === 'player') {
+          router.push('/player')
         } else {
           router.push('/login')
         }
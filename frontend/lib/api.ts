@@ -58,6 +58,83 @@ export interface DashboardStats {
   pending_approvals: number
 }
 
+export interface CategoryPrice {
+  category: string
+  category_name: string
+  sold: number
+  total_spent: number
+  average: number
+  median: number
+  max: number
+}
+
+export interface SpendCurve {
+  team_id: string
+  team_name: string
+  points: { time: string; amount: number; spent: number }[]
+}
+
+export interface LotStats {
+  auction_id: string
+  auction_title: string
+  player_id: string
+  player_name: string
+  bids: number
+  teams: number
+  final_bid: number
+  opened_at: string
+  first_bid_at: string
+  last_bid_at: string
+  sold_at?: string
+  seconds: number
+}
+
+export interface TeamEfficiency {
+  rank: number
+  team_id: string
+  team_name: string
+  players: number
+  spent: number
+  remaining_points: number
+  average_price: number
+  market_value: number
+  efficiency: number
+}
+
+export interface BudgetProjection {
+  price: number
+  allowed: boolean
+  reason?: string
+  error?: string
+  remaining_points: number
+  player_count: number
+  reserved_points: number
+  max_bid: number
+}
+
+export interface BudgetPlan {
+  total_points: number
+  used_points: number
+  remaining_points: number
+  player_count: number
+  min_players: number
+  unfilled_slots: number
+  reserved_per_slot: number
+  reserved_points: number
+  max_bid: number
+  current_lot: {
+    auction_id: string
+    player_id: string
+    current_bid: number
+    minimum_bid: number
+    winning: boolean
+    can_bid: boolean
+    // The code the minimum bid would be refused with, e.g. insufficient_points
+    reason?: string
+  } | null
+  projections: BudgetProjection[]
+}
+
 export interface TeamDashboard {
   team_id: string
   team_name: string
@@ -69,19 +146,174 @@ export interface TeamDashboard {
   max_players: number
   players: Player[]
   recent_bids: Bid[]
+  watchlist: WatchlistItem[]
+}
+
+export interface WatchlistItem {
+  id: string
+  team_id: string
+  player_id: string
+  priority: number
+  target_price: number
+  notes: string
+  created_at: string
+  updated_at: string
+  player: Player
+}
+
+export type TradeStatus = 'proposed' | 'accepted' | 'completed' | 'declined' | 'cancelled' | 'rejected'
+
+export interface TradeItem {
+  id: string
+  trade_id: string
+  player_id: string
+  player_name: string
+  from_team_id: string
+  to_team_id: string
+  // The settled price once the trade completes, the player's current price before
+  price: number
+}
+
+export interface Trade {
+  id: string
+  proposer_team_id: string
+  proposer_team_name: string
+  receiver_team_id: string
+  receiver_team_name: string
+  status: TradeStatus
+  // Paid by the proposing team to the receiving team; negative when the receiver pays
+  points: number
+  note: string
+  responded_at: string | null
+  reviewed_by: string
+  review_reason: string
+  completed_at: string | null
+  created_at: string
+  items: TradeItem[]
+}
+
+export type ReleaseStatus = 'requested' | 'approved' | 'rejected'
+
+export interface Release {
+  id: string
+  team_id: string
+  team_name: string
+  player_id: string
+  player_name: string
+  status: ReleaseStatus
+  reason: string
+  // The player's price when released, and the share of it refunded
+  price: number
+  refund_percent: number
+  refund_amount: number
+  review_reason: string
+  reviewed_at: string | null
+  // Set once a replacement fills the slot the release opened
+  replacement_player_id: string | null
+  replacement_name?: string
+  replacement_price: number
+  replaced_at: string | null
+  created_at: string
+}
+
+export interface TradeProposal {
+  receiver_team_id: string
+  offered_player_ids: string[]
+  requested_player_ids: string[]
+  points: number
+  note?: string
 }
 
 export interface Player {
   id: string
   name: string
   gender: string
-  age: number
+  // age, date_of_birth and mobile are only sent to admins and the player's own team
+  age?: number
+  date_of_birth?: string
+  mobile?: string
+  player_category: string
   playing_category: string
   accomplishments: string
   base_price: number
   current_price: number
   is_sold: boolean
   current_team_id?: string
+  // Empty until a photo is uploaded
+  photo_thumb_url: string
+  photo_card_url: string
+  // Review fields are only sent to admins and the player
+  registration_status?: RegistrationStatus
+  review_reason?: string
+  reviewed_at?: string
+}
+
+export type RegistrationStatus = 'pending' | 'approved' | 'rejected'
+
+export interface PlayerProfile {
+  name: string
+  gender: string
+  date_of_birth: string
+  mobile: string
+  playing_category: string
+  accomplishments: string
+}
+
+export interface PlayerOutcome {
+  status: 'pending' | 'rejected' | 'in_pool' | 'up_for_bidding' | 'sold' | 'retained'
+  registration_status: RegistrationStatus
+  review_reason?: string
+  team: { id: string; name: string } | null
+  price: number
+}
+
+const playerCategoryLabels: Record<string, string> = {
+  women: 'Women',
+  men_under_35: 'Men under 35',
+  men_35_plus: 'Men 35+',
+}
+
+// playerAgeLabel shows a player's age when the viewer may see it, and the age category otherwise
+export function playerAgeLabel(player: { age?: number; player_category?: string }): string {
+  if (player.age !== undefined) {
+    return `${player.age} years`
+  }
+  return playerCategoryLabels[player.player_category ?? ''] ?? 'Age not shared'
+}
+
+// mediaURL makes a stored file's URL absolute; local storage links files on the API server
+export function mediaURL(url?: string): string | undefined {
+  if (!url) return undefined
+  return url.startsWith('/') ? `${API_BASE_URL}${url}` : url
+}
+
+export interface PlayerDocument {
+  id: string
+  player_id: string
+  kind: 'id_proof' | 'age_proof' | 'other'
+  file_name: string
+  content_type: string
+  size: number
+  uploaded_by: string
+  created_at: string
+}
+
+export type ExportKind = 'results' | 'squads' | 'bids'
+export type ExportFormat = 'csv' | 'xlsx' | 'pdf'
+
+export interface ImportRowError {
+  row: number
+  field?: string
+  message: string
+}
+
+export interface ImportReport {
+  dry_run: boolean
+  total_rows: number
+  valid_rows: number
+  imported: number
+  errors: ImportRowError[]
+  players: Player[]
 }
 
 export interface Team {
@@ -104,6 +336,8 @@ export interface Auction {
   winning_team: any
   start_time: string
   end_time?: string
+  // Supplementary auctions sign replacements; only teams with an open replacement slot may bid
+  supplementary?: boolean
 }
 
 export interface Bid {
@@ -146,6 +380,31 @@ export const adminAPI = {
     return response.data.data
   },
 
+  getCategoryPrices: async (): Promise<CategoryPrice[]> => {
+    const response = await api.get('/api/v1/admin/analytics/prices')
+    return response.data.data
+  },
+
+  getSpendCurves: async (): Promise<SpendCurve[]> => {
+    const response = await api.get('/api/v1/admin/analytics/spend')
+    return response.data.data
+  },
+
+  getContestedLots: async (limit = 10): Promise<LotStats[]> => {
+    const response = await api.get('/api/v1/admin/analytics/lots/contested', { params: { limit } })
+    return response.data.data
+  },
+
+  getLotTimes: async (): Promise<LotStats[]> => {
+    const response = await api.get('/api/v1/admin/analytics/lots/times')
+    return response.data.data
+  },
+
+  getBudgetEfficiency: async (): Promise<TeamEfficiency[]> => {
+    const response = await api.get('/api/v1/admin/analytics/efficiency')
+    return response.data.data
+  },
+
   getAuctions: async (status?: string): Promise<Auction[]> => {
     const params = status ? { status } : {}
     const response = await api.get('/api/v1/auctions', { params })
@@ -191,8 +450,14 @@ export const adminAPI = {
     return response.data.data
   },
 
-  approvePlayer: async (playerId: string, approved: boolean): Promise<Player> => {
-    const response = await api.post('/api/v1/admin/players/approve', { player_id: playerId, approved })
+  // A reason is required when rejecting; the player sees it
+  approvePlayer: async (playerId: string, approved: boolean, reason?: string): Promise<Player> => {
+    const response = await api.post('/api/v1/admin/players/approve', { player_id: playerId, approved, reason })
+    return response.data.data
+  },
+
+  getPlayerRegistrations: async (status: RegistrationStatus = 'pending'): Promise<Player[]> => {
+    const response = await api.get('/api/v1/admin/players/registrations', { params: { status } })
     return response.data.data
   },
 
@@ -211,6 +476,27 @@ export const adminAPI = {
     return response.data.data
   },
 
+  // downloadExport fetches a results, squads or bid history export as a file
+  downloadExport: async (kind: ExportKind, format: ExportFormat, auctionId?: string): Promise<Blob> => {
+    const params: any = { format }
+    if (auctionId) params.auction_id = auctionId
+    const response = await api.get(`/api/v1/admin/exports/${kind}`, { params, responseType: 'blob' })
+    return response.data
+  },
+
+  // importPlayers checks a CSV or XLSX file, and imports it unless dryRun is set.
+  // A file with errors comes back as a report with status 422, not an exception.
+  importPlayers: async (file: File, dryRun: boolean): Promise<ImportReport> => {
+    const form = new FormData()
+    form.append('file', file)
+    const response = await api.post('/api/v1/admin/players/import', form, {
+      params: { dry_run: dryRun },
+      headers: { 'Content-Type': 'multipart/form-data' },
+      validateStatus: status => status < 300 || status === 422,
+    })
+    return response.data.data
+  },
+
   getTeamPlayers: async (teamId: string): Promise<Player[]> => {
     const response = await api.get(`/api/v1/teams/${teamId}/players`)
     return response.data.data
@@ -233,6 +519,26 @@ export const adminAPI = {
     const response = await api.get('/api/v1/admin/available-players')
     return response.data.data
   },
+
+  getTrades: async (status?: TradeStatus): Promise<Trade[]> => {
+    const response = await api.get('/api/v1/admin/trades', { params: status ? { status } : {} })
+    return response.data.data
+  },
+
+  reviewTrade: async (tradeId: string, approved: boolean, reason?: string): Promise<Trade> => {
+    const response = await api.post(`/api/v1/admin/trades/${tradeId}/review`, { approved, reason })
+    return response.data.data
+  },
+
+  getReleases: async (status?: ReleaseStatus): Promise<Release[]> => {
+    const response = await api.get('/api/v1/admin/releases', { params: status ? { status } : {} })
+    return response.data.data
+  },
+
+  reviewRelease: async (releaseId: string, review: { approved: boolean; reason?: string; refund_percent?: number }): Promise<Release> => {
+    const response = await api.post(`/api/v1/admin/releases/${releaseId}/review`, review)
+    return response.data.data
+  },
 }
 
 // Team API
@@ -252,6 +558,61 @@ export const teamAPI = {
     return response.data.data
   },
 
+  getBudgetPlan: async (whatIf: number[] = []): Promise<BudgetPlan> => {
+    const params = whatIf.length > 0 ? { what_if: whatIf.join(',') } : {}
+    const response = await api.get('/api/v1/team/budget/plan', { params })
+    return response.data.data
+  },
+
+  getWatchlist: async (): Promise<WatchlistItem[]> => {
+    const response = await api.get('/api/v1/team/watchlist')
+    return response.data.data
+  },
+
+  saveWatchlistEntry: async (playerId: string, entry: { priority?: number; target_price?: number; notes?: string }): Promise<WatchlistItem> => {
+    const response = await api.put(`/api/v1/team/watchlist/${playerId}`, entry)
+    return response.data.data
+  },
+
+  removeWatchlistEntry: async (playerId: string): Promise<void> => {
+    await api.delete(`/api/v1/team/watchlist/${playerId}`)
+  },
+
+  getTrades: async (status?: TradeStatus): Promise<Trade[]> => {
+    const response = await api.get('/api/v1/team/trades', { params: status ? { status } : {} })
+    return response.data.data
+  },
+
+  proposeTrade: async (proposal: TradeProposal): Promise<Trade> => {
+    const response = await api.post('/api/v1/team/trades', proposal)
+    return response.data.data
+  },
+
+  respondToTrade: async (tradeId: string, action: 'accept' | 'decline' | 'cancel'): Promise<Trade> => {
+    const response = await api.post(`/api/v1/team/trades/${tradeId}/${action}`)
+    return response.data.data
+  },
+
+  getReleases: async (): Promise<Release[]> => {
+    const response = await api.get('/api/v1/team/releases')
+    return response.data.data
+  },
+
+  requestRelease: async (playerId: string, reason: string): Promise<Release> => {
+    const response = await api.post('/api/v1/team/releases', { player_id: playerId, reason })
+    return response.data.data
+  },
+
+  getReplacementPool: async (): Promise<Player[]> => {
+    const response = await api.get('/api/v1/team/replacement-pool')
+    return response.data.data
+  },
+
+  pickReplacement: async (releaseId: string, playerId: string): Promise<Release> => {
+    const response = await api.post(`/api/v1/team/releases/${releaseId}/pick`, { player_id: playerId })
+    return response.data.data
+  },
+
   retainPlayer: async (playerId: string): Promise<any> => {
     const response = await api.post('/api/v1/team/retain-player', { player_id: playerId })
     return response.data.data
@@ -273,6 +634,54 @@ export const teamAPI = {
   },
 }
 
+// Player API
+export const playerAPI = {
+  getProfile: async (): Promise<Player> => {
+    const response = await api.get('/api/v1/player/profile')
+    return response.data.data
+  },
+
+  submitProfile: async (profile: PlayerProfile): Promise<Player> => {
+    const response = await api.put('/api/v1/player/profile', profile)
+    return response.data.data
+  },
+
+  getOutcome: async (): Promise<PlayerOutcome> => {
+    const response = await api.get('/api/v1/player/outcome')
+    return response.data.data
+  },
+
+  // Photo and document uploads are open to admins for any player, and to players for themselves
+  uploadPhoto: async (playerId: string, photo: File): Promise<Player> => {
+    const form = new FormData()
+    form.append('photo', photo)
+    const response = await api.post(`/api/v1/players/${playerId}/photo`, form, {
+      headers: { 'Content-Type': 'multipart/form-data' },
+    })
+    return response.data.data
+  },
+
+  uploadDocument: async (playerId: string, document: File, kind: PlayerDocument['kind']): Promise<PlayerDocument> => {
+    const form = new FormData()
+    form.append('document', document)
+    form.append('kind', kind)
+    const response = await api.post(`/api/v1/players/${playerId}/documents`, form, {
+      headers: { 'Content-Type': 'multipart/form-data' },
+    })
+    return response.data.data
+  },
+
+  getDocuments: async (playerId: string): Promise<PlayerDocument[]> => {
+    const response = await api.get(`/api/v1/players/${playerId}/documents`)
+    return response.data.data
+  },
+
+  downloadDocument: async (playerId: string, documentId: string): Promise<Blob> => {
+    const response = await api.get(`/api/v1/players/${playerId}/documents/${documentId}`, { responseType: 'blob' })
+    return response.data
+  },
+}
+
 // General API
 export const generalAPI = {
   getPlayers: async (status?: string, category?: string): Promise<Player[]> => {
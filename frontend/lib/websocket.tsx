@@ -5,6 +5,8 @@ import { useEffect, useRef, useCallback, useState, createContext, useContext } f
 interface WebSocketMessage {
   type: string
   data: any
+  room?: string
+  seq?: number
   user_id?: string
   team_id?: string
 }
@@ -33,6 +35,8 @@ let globalWs: WebSocket | null = null
 let globalMessageHandlers: Set<(message: WebSocketMessage) => void> = new Set()
 let globalIsConnected = false
 let globalConnectionListeners: Set<() => void> = new Set()
+// Last event sequence seen per auction room, sent back on resubscribe so missed events are replayed
+let globalLastSeq: Map<string, number> = new Map()
 
 const notifyConnectionChange = () => {
   globalConnectionListeners.forEach(listener => listener())
@@ -51,7 +55,11 @@ export function WebSocketProvider({ children }: { children: React.ReactNode }):
 
     try {
       const wsUrl = process.env.NEXT_PUBLIC_WS_URL || 'ws://localhost:9999'
-      globalWs = new WebSocket(`${wsUrl}/api/v1/ws`)
+      const token = localStorage.getItem('auth_token')
+      if (!token) {
+        return // The server only accepts authenticated connections
+      }
+      globalWs = new WebSocket(`${wsUrl}/api/v1/ws?token=${encodeURIComponent(token)}`)
 
       // Set connection timeout
       const connectionTimeout = setTimeout(() => {
@@ -73,6 +81,13 @@ export function WebSocketProvider({ children }: { children: React.ReactNode }):
       globalWs.onmessage = (event) => {
         try {
           const message: WebSocketMessage = JSON.parse(event.data)
+          if (message.type === 'resync_required' && message.room) {
+            globalLastSeq.delete(message.room) // Handlers refetch state instead
+          } else if (message.room && message.seq) {
+            // A resubscribe may overlap live delivery; skip events already seen
+            if (message.seq <= (globalLastSeq.get(message.room) ?? 0)) return
+            globalLastSeq.set(message.room, message.seq)
+          }
           // Notify all handlers
           globalMessageHandlers.forEach(handler => handler(message))
         } catch (error) {
@@ -80,8 +95,12 @@ export function WebSocketProvider({ children }: { children: React.ReactNode }):
         }
       }
 
-      globalWs.onclose = () => {
+      globalWs.onclose = (event) => {
         console.log('WebSocket disconnected')
+        if (event.code === 4001) {
+          // Dropped for falling behind; reconnecting resubscribes with last_seq to catch up
+          reconnectAttempts.current = 0
+        }
         globalIsConnected = false
         setIsConnected(false)
         notifyConnectionChange()
@@ -106,6 +125,10 @@ export function WebSocketProvider({ children }: { children: React.ReactNode }):
 
   const sendMessage = useCallback((message: WebSocketMessage) => {
     if (globalWs && globalWs.readyState === WebSocket.OPEN) {
+      const room = message.data?.room
+      if (message.type === 'subscribe' && room && globalLastSeq.has(room)) {
+        message = { ...message, data: { ...message.data, last_seq: globalLastSeq.get(room) } }
+      }
       globalWs.send(JSON.stringify(message))
     } else {
       console.warn('WebSocket is not connected')